@@ -6,6 +6,7 @@ import (
 	"os"
 
 	"github.com/donnellyk/lazyruin/pkg/app"
+	"github.com/donnellyk/lazyruin/pkg/remote"
 )
 
 // version is injected at build time via ldflags (see .goreleaser.yml).
@@ -40,6 +41,7 @@ func main() {
 	flag.Var(&link, "link", "Open directly into new link capture, exit on save.\n  --link             open the link input popup\n  --link=<url>       skip the popup and resolve <url> immediately")
 	debugBindings := flag.Bool("debug-bindings", false, "Print all registered keybindings and exit")
 	openRef := flag.String("open", "", "Open a specific note (path/title) or parent bookmark on launch")
+	remoteMode := flag.Bool("remote", false, "Send a command to the lazyruin running on the vault, or launch one to run it:\n  --remote open <ref>          open a note (path/title) or parent bookmark\n  --remote search <query>      run a search\n  --remote capture [text]      save text as a new note, or open capture\n  --remote date <yyyy-mm-dd>   show a date\n  --remote refresh             reload the panels")
 	showVersion := flag.Bool("version", false, "Print version and exit")
 	flag.Parse()
 
@@ -48,6 +50,16 @@ func main() {
 		return
 	}
 
+	var remoteReq *remote.Request
+	if *remoteMode {
+		req, err := remote.ParseArgs(flag.Args())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(2)
+		}
+		remoteReq = &req
+	}
+
 	a, err := app.NewApp(*vaultPath, *ruinBin, version)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "error: %v\n", err)
@@ -59,6 +71,7 @@ func main() {
 	a.QuickLinkURL = link.url
 	a.DebugBindings = *debugBindings
 	a.OpenRef = *openRef
+	a.Remote = remoteReq
 
 	if err := a.Run(); err != nil {
 		fmt.Fprintf(os.Stderr, "error: %v\n", err)
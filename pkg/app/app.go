@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
-	"path/filepath"
 	"strings"
 
 	"github.com/donnellyk/lazyruin/pkg/commands"
@@ -14,6 +13,7 @@ import (
 	helperspkg "github.com/donnellyk/lazyruin/pkg/gui/helpers"
 	"github.com/donnellyk/lazyruin/pkg/gui/onboarding"
 	"github.com/donnellyk/lazyruin/pkg/migrations"
+	"github.com/donnellyk/lazyruin/pkg/remote"
 )
 
 // App is the main application struct that bootstraps and runs lazyruin.
@@ -21,13 +21,14 @@ type App struct {
 	Config          *config.Config
 	RuinCmd         *commands.RuinCommand
 	Gui             *gui.Gui
-	VaultSource     string // human-readable source of the resolved vault path
-	LazyruinVersion string // build-time version, used to detect upgrades
-	QuickCapture    bool   // when true, open directly into new note and exit on save
-	QuickLink       bool   // when true, open directly into new link and exit on save
-	QuickLinkURL    string // when set with QuickLink, skip input popup and resolve directly
-	DebugBindings   bool   // when true, print all registered bindings and exit
-	OpenRef         string // note path/title or parent bookmark to open on launch
+	VaultSource     string          // human-readable source of the resolved vault path
+	LazyruinVersion string          // build-time version, used to detect upgrades
+	QuickCapture    bool            // when true, open directly into new note and exit on save
+	QuickLink       bool            // when true, open directly into new link and exit on save
+	QuickLinkURL    string          // when set with QuickLink, skip input popup and resolve directly
+	DebugBindings   bool            // when true, print all registered bindings and exit
+	OpenRef         string          // note path/title or parent bookmark to open on launch
+	Remote          *remote.Request // --remote command for a running instance
 }
 
 // NewApp creates a new application instance.
@@ -59,8 +60,17 @@ func NewApp(vaultOverride, ruinBin, lazyruinVersion string) (*App, error) {
 	}, nil
 }
 
-// Run starts the application.
+// Run starts the application. With a Remote request, it first tries to
+// hand the request to an instance already running on the vault and only
+// starts the TUI when none is listening.
 func (a *App) Run() error {
+	if a.Remote != nil {
+		err := remote.Send(remote.SocketPath(a.RuinCmd.VaultPath()), *a.Remote)
+		if !errors.Is(err, remote.ErrNotRunning) {
+			return err
+		}
+	}
+
 	// Check ruin CLI version first — an outdated ruin binary may cause
 	// CheckVault to fail (e.g., if `ruin today` returns a different error
 	// format), so users deserve to see the upgrade hint rather than an
@@ -93,6 +103,7 @@ func (a *App) Run() error {
 	a.Gui.QuickLink = a.QuickLink
 	a.Gui.QuickLinkURL = a.QuickLinkURL
 	a.Gui.OpenRef = a.OpenRef
+	a.Gui.RemoteRequest = a.Remote
 	a.Gui.VaultSource = a.VaultSource
 	if needsInit {
 		a.Gui.SetNeedsInit()
@@ -110,11 +121,21 @@ func (a *App) Run() error {
 		a.attachMigrationsHelper()
 	}
 
+	// A runtime vault switch rebuilds the GUI's ruin command; keep ours
+	// in step and recompute migrations for the new vault.
+	a.Gui.OnVaultSwitch = func(cmd *commands.RuinCommand) {
+		a.RuinCmd = cmd
+		a.attachMigrationsHelper()
+	}
+
 	// Debug mode: print all registered bindings and exit without running the TUI.
 	if a.DebugBindings {
 		for _, b := range a.Gui.DumpBindings() {
 			fmt.Println(b)
 		}
+		for _, p := range a.Gui.KeybindingProblems() {
+			fmt.Fprintln(os.Stderr, "warning:", p)
+		}
 		return nil
 	}
 
@@ -163,20 +184,8 @@ func (a *App) attachMigrationsHelper() {
 	a.Gui.SetMigrationsHelper(helper)
 }
 
-// expandPath expands ~ to the user's home directory and resolves to absolute path.
-func expandPath(path string) string {
-	if strings.HasPrefix(path, "~/") {
-		if home, err := os.UserHomeDir(); err == nil {
-			path = filepath.Join(home, path[2:])
-		}
-	}
-	if abs, err := filepath.Abs(path); err == nil {
-		return abs
-	}
-	return path
-}
-
 // resolveVaultPath determines the vault path from CLI flag, config, env, or ruin CLI.
+// The --vault flag may also name one of the configured `vaults:`.
 // Returns a short human-readable label describing where the path came from
 // (shown in the about dialog). When no configured source yields a path,
 // falls back to the current working directory so the TUI's init dialog
@@ -185,17 +194,23 @@ func expandPath(path string) string {
 func resolveVaultPath(cfg *config.Config, cliOverride, ruinBin string) (string, string, error) {
 	// 1. Check CLI flag (highest priority)
 	if cliOverride != "" {
-		return expandPath(cliOverride), "--vault flag", nil
+		if v, ok := cfg.Vault(cliOverride); ok {
+			return config.ExpandPath(v.Path), "--vault flag", nil
+		}
+		return config.ExpandPath(cliOverride), "--vault flag", nil
 	}
 
-	// 2. Check config
+	// 2. Check config: vault_path, then the first of the named vaults
 	if cfg.VaultPath != "" {
-		return expandPath(cfg.VaultPath), "lazyruin config", nil
+		return config.ExpandPath(cfg.VaultPath), "lazyruin config", nil
+	}
+	if len(cfg.Vaults) > 0 {
+		return config.ExpandPath(cfg.Vaults[0].Path), "lazyruin config", nil
 	}
 
 	// 3. Check environment
 	if envVault := os.Getenv("LAZYRUIN_VAULT"); envVault != "" {
-		return expandPath(envVault), "LAZYRUIN_VAULT env", nil
+		return config.ExpandPath(envVault), "LAZYRUIN_VAULT env", nil
 	}
 
 	// 4. Ask ruin CLI for its configured vault path. The CLI exits 0 with
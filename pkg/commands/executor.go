@@ -1,7 +1,11 @@
 package commands
 
+import "context"
+
 // Executor defines the interface for executing ruin CLI commands.
 // Used by tests to inject mock executors via NewRuinCommandWithExecutor.
+// ctx is the RuinCommand's bound context (see WithContext); executors
+// should abandon work and return ctx.Err() once it is cancelled.
 type Executor interface {
-	Execute(args ...string) ([]byte, error)
+	Execute(ctx context.Context, args ...string) ([]byte, error)
 }
@@ -1,6 +1,10 @@
 package commands
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/donnellyk/lazyruin/pkg/models"
+)
 
 // NoteCommand wraps the `ruin note` subcommands.
 type NoteCommand struct {
@@ -19,6 +23,15 @@ func (n *NoteCommand) executeNoteSet(id string, args ...string) error {
 	return err
 }
 
+// Create logs a new note via `ruin log`, under parentRef when it's set.
+func (n *NoteCommand) Create(content, parentRef string) (*models.Note, error) {
+	args := []string{"log", content}
+	if parentRef != "" {
+		args = append(args, "--parent", parentRef)
+	}
+	return ExecuteAndUnmarshal[*models.Note](n.ruin, args...)
+}
+
 // Delete deletes a note via `ruin note delete`.
 func (n *NoteCommand) Delete(noteRef string) error {
 	_, err := n.ruin.Execute("note", "delete", noteRef, "-f")
@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"context"
 	"strings"
 	"testing"
 )
@@ -9,7 +10,7 @@ type argCapture struct {
 	calls [][]string
 }
 
-func (a *argCapture) Execute(args ...string) ([]byte, error) {
+func (a *argCapture) Execute(_ context.Context, args ...string) ([]byte, error) {
 	a.calls = append(a.calls, args)
 	return []byte("[]"), nil
 }
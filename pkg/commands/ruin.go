@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -33,6 +34,7 @@ type RuinCommand struct {
 	vaultPath string
 	bin       string
 	executor  Executor
+	ctx       context.Context
 	Search    *SearchCommand
 	Tags      *TagsCommand
 	Queries   *QueriesCommand
@@ -76,6 +78,44 @@ func (r *RuinCommand) initSubcommands() {
 	r.Embed = NewEmbedCommand(r)
 }
 
+// WithContext returns a copy of r whose commands run under ctx: cancelling
+// ctx kills any in-flight ruin subprocess started through the copy. The
+// copy shares vault, binary, and executor with r, so it is cheap to create
+// per background task.
+func (r *RuinCommand) WithContext(ctx context.Context) *RuinCommand {
+	c := &RuinCommand{
+		vaultPath: r.vaultPath,
+		bin:       r.bin,
+		executor:  r.executor,
+		ctx:       ctx,
+	}
+	c.initSubcommands()
+	return c
+}
+
+// ForVault returns a copy of r that runs against the vault at path. The
+// copy shares binary and executor with r; used when switching vaults at
+// runtime.
+func (r *RuinCommand) ForVault(path string) *RuinCommand {
+	c := &RuinCommand{
+		vaultPath: path,
+		bin:       r.bin,
+		executor:  r.executor,
+	}
+	c.initSubcommands()
+	return c
+}
+
+// Context returns the bound context, or context.Background() when none was
+// set via WithContext. Background tasks that run other subprocesses (git,
+// for one) bind them to it so they are cancelled along with the task.
+func (r *RuinCommand) Context() context.Context {
+	if r.ctx == nil {
+		return context.Background()
+	}
+	return r.ctx
+}
+
 // VaultPath returns the configured vault path.
 func (r *RuinCommand) VaultPath() string {
 	return r.vaultPath
@@ -84,7 +124,7 @@ func (r *RuinCommand) VaultPath() string {
 // buildCommand creates an exec.Cmd for the ruin CLI with --vault appended.
 func (r *RuinCommand) buildCommand(args ...string) *exec.Cmd {
 	fullArgs := append(args, "--vault", r.vaultPath)
-	return exec.Command(r.bin, fullArgs...)
+	return exec.CommandContext(r.Context(), r.bin, fullArgs...)
 }
 
 // IsInitialized reports whether the vault path has been initialized as a
@@ -168,7 +208,7 @@ func (r *RuinCommand) DoctorFullScan() error {
 func (r *RuinCommand) Execute(args ...string) ([]byte, error) {
 	// Use injected executor if available
 	if r.executor != nil {
-		return r.executor.Execute(args...)
+		return r.executor.Execute(r.Context(), args...)
 	}
 
 	// Default to CLI execution
@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestWithContext_CancelledContextAbortsCommands(t *testing.T) {
+	mock := NewMockExecutor()
+	ruin := NewRuinCommandWithExecutor(mock, mock.VaultPath())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	bound := ruin.WithContext(ctx)
+	cancel()
+
+	if _, err := bound.Search.Search("#x", SearchOptions{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("bound Search error = %v, want context.Canceled", err)
+	}
+	if _, err := ruin.Search.Search("#x", SearchOptions{}); err != nil {
+		t.Errorf("original command should be unaffected by the copy's context, got %v", err)
+	}
+}
+
+func TestWithContext_SharesVault(t *testing.T) {
+	ruin := NewRuinCommandWithExecutor(NewMockExecutor(), "/vault")
+	bound := ruin.WithContext(context.Background())
+
+	if bound.VaultPath() != "/vault" {
+		t.Errorf("VaultPath = %q, want /vault", bound.VaultPath())
+	}
+	if bound.Search == ruin.Search {
+		t.Error("copy should have its own subcommands bound to the copy")
+	}
+}
+
+func TestForVault_SwapsVaultOnly(t *testing.T) {
+	mock := NewMockExecutor()
+	ruin := NewRuinCommandWithExecutor(mock, "/work")
+	other := ruin.ForVault("/personal")
+
+	if other.VaultPath() != "/personal" {
+		t.Errorf("VaultPath = %q, want /personal", other.VaultPath())
+	}
+	if ruin.VaultPath() != "/work" {
+		t.Errorf("original VaultPath = %q, want /work", ruin.VaultPath())
+	}
+	if other.executor != ruin.executor {
+		t.Error("copy should share the executor")
+	}
+	if other.Search == ruin.Search {
+		t.Error("copy should have its own subcommands bound to the copy")
+	}
+}
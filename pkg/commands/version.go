@@ -21,7 +21,7 @@ func (r *RuinCommand) Version() (string, error) {
 	var out []byte
 	var err error
 	if r.executor != nil {
-		out, err = r.executor.Execute("--version")
+		out, err = r.executor.Execute(r.Context(), "--version")
 	} else {
 		if r.bin == "" {
 			return "", fmt.Errorf("ruin binary path not set")
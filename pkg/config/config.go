@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
@@ -11,7 +12,18 @@ import (
 // Each field has an explicit YAML key so zero-values map cleanly to the
 // "unset → default" case when a config file is older than a field.
 type ViewOptions struct {
-	HideDone bool `yaml:"hide_done"`
+	HideDone bool             `yaml:"hide_done"`
+	Todos    TodosViewOptions `yaml:"todos,omitempty"`
+}
+
+// TodosViewOptions holds the Todos dashboard's grouping and filters. With
+// every filter off the dashboard lists all open todos. HasDate and NoDate
+// exclude each other.
+type TodosViewOptions struct {
+	GroupBy    string `yaml:"group_by,omitempty"`    // note (default), tag, parent or date
+	HasDate    bool   `yaml:"has_date,omitempty"`    // only todos with an inline date
+	NoDate     bool   `yaml:"no_date,omitempty"`     // only todos without one
+	RecentDone bool   `yaml:"recent_done,omitempty"` // also todos done in the last 7 days
 }
 
 // NotesPaneSectionItem describes one selectable item inside a custom section
@@ -38,13 +50,104 @@ type NotesPaneConfig struct {
 	CustomSections []NotesPaneSection `yaml:"custom_sections,omitempty"`
 }
 
+// ThemeConfig selects the UI color preset ("dark", "light",
+// "high-contrast" or "monochrome"; empty follows the terminal background)
+// and overrides individual color roles, e.g. `selected: black on yellow`.
+type ThemeConfig struct {
+	Preset string            `yaml:"preset,omitempty"`
+	Roles  map[string]string `yaml:",inline"`
+}
+
+// VaultConfig names a vault that can be switched to at runtime. Path may
+// start with ~/.
+type VaultConfig struct {
+	Name string `yaml:"name"`
+	Path string `yaml:"path"`
+}
+
+// GitConfig controls what lazyruin does when the vault is in a git repo.
+// CommitMessage is a template for auto-commits: {action} becomes the
+// change's undo-history label (e.g. "Add tag #x") and {vault} the vault
+// directory's name. Empty means DefaultCommitMessage.
+type GitConfig struct {
+	AutoCommit    bool   `yaml:"auto_commit,omitempty"`
+	CommitMessage string `yaml:"commit_message,omitempty"`
+}
+
+// DefaultCommitMessage is the auto-commit template used when none is set.
+const DefaultCommitMessage = "lazyruin: {action}"
+
+// HooksConfig holds shell commands run after lazyruin changes a note. They
+// run with `sh -c` from the vault directory, with the note and what
+// changed in LAZYRUIN_* environment variables (see docs/configuration.md).
+// Empty means no hook.
+type HooksConfig struct {
+	OnCreate    string `yaml:"on_create,omitempty"`
+	OnEdit      string `yaml:"on_edit,omitempty"`
+	OnDelete    string `yaml:"on_delete,omitempty"`
+	OnTagChange string `yaml:"on_tag_change,omitempty"`
+}
+
+// CustomCommandPrompt asks for a value before a custom command runs. The
+// answer is available to the command template as {{.Form.<Key>}}.
+type CustomCommandPrompt struct {
+	Key          string `yaml:"key"`
+	Title        string `yaml:"title"`
+	InitialValue string `yaml:"initial_value,omitempty"`
+}
+
+// CustomCommand binds a shell command to a key in one context. Command is
+// a Go template over the focused note, tag and preview line (see
+// docs/configuration.md). Output is one of the CustomCommandOutput*
+// values; empty means CustomCommandOutputBackground.
+type CustomCommand struct {
+	Key     string                `yaml:"key,omitempty"`
+	Context string                `yaml:"context"`
+	Name    string                `yaml:"name"`
+	Command string                `yaml:"command"`
+	Prompts []CustomCommandPrompt `yaml:"prompts,omitempty"`
+	Output  string                `yaml:"output,omitempty"`
+}
+
+// Custom command output modes.
+const (
+	// CustomCommandOutputSuspend hands the terminal to the command.
+	CustomCommandOutputSuspend = "suspend"
+	// CustomCommandOutputBackground runs the command without blocking and
+	// reports failures in the status bar.
+	CustomCommandOutputBackground = "background"
+	// CustomCommandOutputPopup shows the command's output in a popup.
+	CustomCommandOutputPopup = "popup"
+)
+
+// NoteTemplate pre-fills the capture popup for a new note. Body is a Go
+// template over the date, time, weekday, parent and prompt answers (see
+// docs/configuration.md). Parent names a parent bookmark or note title to
+// file the note under; Tags are added to the note as global tags.
+type NoteTemplate struct {
+	Name    string                `yaml:"name"`
+	Body    string                `yaml:"body"`
+	Parent  string                `yaml:"parent,omitempty"`
+	Tags    []string              `yaml:"tags,omitempty"`
+	Prompts []CustomCommandPrompt `yaml:"prompts,omitempty"`
+}
+
+// TemplatesDir is the vault folder holding file-based note templates, one
+// `<name>.md` per template. It is hidden so ruin doesn't index the
+// templates as notes.
+const TemplatesDir = ".templates"
+
 // Config holds the application configuration.
 type Config struct {
 	VaultPath   string          `yaml:"vault_path"`
+	Vaults      []VaultConfig   `yaml:"vaults,omitempty"`
 	Editor      string          `yaml:"editor"`
 	ChromaTheme string          `yaml:"chroma_theme"`
+	Theme       ThemeConfig     `yaml:"theme,omitempty"`
 	ViewOptions ViewOptions     `yaml:"view_options,omitempty"`
 	NotesPane   NotesPaneConfig `yaml:"notes_pane,omitempty"`
+	Git         GitConfig       `yaml:"git,omitempty"`
+	Hooks       HooksConfig     `yaml:"hooks,omitempty"`
 
 	// SidebarWidth overrides the side panel width in columns. When 0 (or
 	// unset), the layout uses min(maxX/3, 40). Clamped at runtime so the
@@ -61,6 +164,25 @@ type Config struct {
 	// `ruin log` path regardless of content shape.
 	DisableBareURLAsLink bool `yaml:"disable_bare_url_as_link,omitempty"`
 
+	// TrashRetentionDays purges trashed notes older than this many days
+	// when the trash is next touched. When 0 (or unset), trashed notes are
+	// kept until purged by hand.
+	TrashRetentionDays int `yaml:"trash_retention_days,omitempty"`
+
+	// Keybindings remaps controller keys, keyed by context (e.g. "notes",
+	// "cardList", "global") and then by binding ID or description. The
+	// value is a key as shown in the help dialog ("x", "<c-d>", "enter");
+	// "<disabled>" unbinds the key and leaves the command in the palette.
+	Keybindings map[string]map[string]string `yaml:"keybindings,omitempty"`
+
+	// CustomCommands adds user-defined shell commands to controller
+	// keybindings and the command palette.
+	CustomCommands []CustomCommand `yaml:"custom_commands,omitempty"`
+
+	// Templates are note templates offered by "New from Template", ahead
+	// of those in the vault's TemplatesDir.
+	Templates []NoteTemplate `yaml:"templates,omitempty"`
+
 	// OnboardingOffered is flipped to true after the empty-vault onboarding
 	// prompt has been shown once (either accepted or declined), so we do not
 	// re-prompt on subsequent launches against empty vaults.
@@ -101,6 +223,40 @@ func Load() (*Config, error) {
 	return cfg, nil
 }
 
+// Vault returns the configured vault with the given name.
+func (cfg *Config) Vault(name string) (VaultConfig, bool) {
+	for _, v := range cfg.Vaults {
+		if v.Name == name {
+			return v, true
+		}
+	}
+	return VaultConfig{}, false
+}
+
+// VaultName returns the name of the configured vault at path, or "" when
+// path isn't one of the configured vaults.
+func (cfg *Config) VaultName(path string) string {
+	for _, v := range cfg.Vaults {
+		if ExpandPath(v.Path) == path {
+			return v.Name
+		}
+	}
+	return ""
+}
+
+// ExpandPath expands ~ to the user's home directory and resolves to absolute path.
+func ExpandPath(path string) string {
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[2:])
+		}
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
+
 // getConfigPath returns the path to the config file.
 func getConfigPath() string {
 	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
@@ -173,3 +173,160 @@ func TestConfig_NotesPane_DefaultsOff(t *testing.T) {
 		t.Errorf("expected CustomSections nil, got %+v", cfg.NotesPane.CustomSections)
 	}
 }
+
+// TestConfig_Keybindings_Load verifies that a keybindings section parses
+// into context → binding → key.
+func TestConfig_Keybindings_Load(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tmp)
+
+	configPath := filepath.Join(tmp, "lazyruin", "config.yml")
+	if err := os.MkdirAll(filepath.Dir(configPath), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	yml := "keybindings:\n  notes:\n    notes.delete: x\n    Open in editor: <disabled>\n"
+	if err := os.WriteFile(configPath, []byte(yml), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if got := cfg.Keybindings["notes"]["notes.delete"]; got != "x" {
+		t.Errorf("notes.delete = %q, want x", got)
+	}
+	if got := cfg.Keybindings["notes"]["Open in editor"]; got != "<disabled>" {
+		t.Errorf("Open in editor = %q, want <disabled>", got)
+	}
+}
+
+func TestConfig_CustomCommands_Load(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tmp)
+
+	configPath := filepath.Join(tmp, "lazyruin", "config.yml")
+	if err := os.MkdirAll(filepath.Dir(configPath), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	yml := `custom_commands:
+  - key: X
+    context: cardList
+    name: Publish note
+    command: publish {{quote .Note.Path}} --as {{.Form.Slug}}
+    output: popup
+    prompts:
+      - key: Slug
+        title: Slug
+        initial_value: draft
+`
+	if err := os.WriteFile(configPath, []byte(yml), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(cfg.CustomCommands) != 1 {
+		t.Fatalf("CustomCommands = %+v, want one entry", cfg.CustomCommands)
+	}
+	cc := cfg.CustomCommands[0]
+	if cc.Key != "X" || cc.Context != "cardList" || cc.Name != "Publish note" || cc.Output != CustomCommandOutputPopup {
+		t.Errorf("custom command = %+v", cc)
+	}
+	if len(cc.Prompts) != 1 || cc.Prompts[0].Key != "Slug" || cc.Prompts[0].InitialValue != "draft" {
+		t.Errorf("prompts = %+v", cc.Prompts)
+	}
+}
+
+func TestConfig_Hooks_Load(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tmp)
+
+	configPath := filepath.Join(tmp, "lazyruin", "config.yml")
+	if err := os.MkdirAll(filepath.Dir(configPath), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	yml := "hooks:\n  on_create: notify-send created\n  on_tag_change: ./sync-tags.sh\n"
+	if err := os.WriteFile(configPath, []byte(yml), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Hooks.OnCreate != "notify-send created" || cfg.Hooks.OnTagChange != "./sync-tags.sh" {
+		t.Errorf("Hooks = %+v", cfg.Hooks)
+	}
+	if cfg.Hooks.OnEdit != "" || cfg.Hooks.OnDelete != "" {
+		t.Errorf("unset hooks should stay empty, got %+v", cfg.Hooks)
+	}
+}
+
+// TestConfig_Theme_InlineRoles verifies that role keys sit next to preset
+// in the theme section and survive Save/Load.
+func TestConfig_Theme_InlineRoles(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	cfg := &Config{Theme: ThemeConfig{
+		Preset: "high-contrast",
+		Roles:  map[string]string{"selected": "black on yellow"},
+	}}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	reloaded, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if reloaded.Theme.Preset != "high-contrast" {
+		t.Errorf("Preset = %q, want high-contrast", reloaded.Theme.Preset)
+	}
+	if got := reloaded.Theme.Roles["selected"]; got != "black on yellow" {
+		t.Errorf("selected = %q, want %q", got, "black on yellow")
+	}
+	if _, ok := reloaded.Theme.Roles["preset"]; ok {
+		t.Error("preset should not leak into Roles")
+	}
+}
+
+// TestConfig_Vaults_LookupByNameAndPath verifies that named vaults load
+// from YAML and resolve by name, and by expanded path back to a name.
+func TestConfig_Vaults_LookupByNameAndPath(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tmp)
+	t.Setenv("HOME", tmp)
+
+	configPath := filepath.Join(tmp, "lazyruin", "config.yml")
+	if err := os.MkdirAll(filepath.Dir(configPath), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	yml := "vaults:\n  - name: work\n    path: ~/work\n  - name: personal\n    path: /tmp/personal\n"
+	if err := os.WriteFile(configPath, []byte(yml), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(cfg.Vaults) != 2 {
+		t.Fatalf("Vaults = %d entries, want 2", len(cfg.Vaults))
+	}
+	v, ok := cfg.Vault("personal")
+	if !ok || v.Path != "/tmp/personal" {
+		t.Errorf("Vault(personal) = %+v, %v", v, ok)
+	}
+	if _, ok := cfg.Vault("missing"); ok {
+		t.Error("Vault(missing) should not be found")
+	}
+	if got := cfg.VaultName(filepath.Join(tmp, "work")); got != "work" {
+		t.Errorf("VaultName(~/work) = %q, want work", got)
+	}
+	if got := cfg.VaultName("/elsewhere"); got != "" {
+		t.Errorf("VaultName(/elsewhere) = %q, want empty", got)
+	}
+}
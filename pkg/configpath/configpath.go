@@ -34,6 +34,13 @@ func VaultKey(vaultPath string) string {
 // per-vault files placed alongside one another in a single directory
 // (e.g., scratchpad/<short>.json).
 func VaultFileName(vaultPath, ext string) string {
+	return VaultDirName(vaultPath) + "." + ext
+}
+
+// VaultDirName returns the short hex name VaultFileName uses, without an
+// extension, for per-vault state that needs a whole directory (e.g.,
+// trash/<short>/).
+func VaultDirName(vaultPath string) string {
 	hash := sha256.Sum256([]byte(vaultPath))
-	return fmt.Sprintf("%x.%s", hash[:8], ext)
+	return fmt.Sprintf("%x", hash[:8])
 }
@@ -40,3 +40,9 @@ func TestVaultFileName(t *testing.T) {
 		t.Error("distinct paths produced the same filename")
 	}
 }
+
+func TestVaultDirName_MatchesFileNameStem(t *testing.T) {
+	if got, want := VaultDirName("/x")+".json", VaultFileName("/x", "json"); got != want {
+		t.Errorf("VaultDirName(/x)+.json = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,111 @@
+// Package donelog remembers, per vault, when todos were checked off in
+// lazyruin. Neither a checked box nor #done carries a date, so this is how
+// the Todos list tells a todo done this week from one done years ago.
+package donelog
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/donnellyk/lazyruin/pkg/configpath"
+)
+
+// Entry is one todo and when it was checked off. Todos are identified by
+// their note and text, since completing one can move it within the note.
+type Entry struct {
+	UUID string    `json:"uuid"`
+	Text string    `json:"text"`
+	Time time.Time `json:"time"`
+}
+
+// Store reads and writes one vault's log file.
+type Store struct {
+	path    string
+	entries []Entry
+}
+
+func NewStoreForVault(vaultPath string) *Store {
+	return NewStoreWithPath(PathForVault(vaultPath))
+}
+
+func NewStoreWithPath(path string) *Store {
+	return &Store{path: path}
+}
+
+// PathForVault returns the log file path for a given vault, stored under
+// the lazyruin config directory keyed by a hash of the vault path.
+func PathForVault(vaultPath string) string {
+	return filepath.Join(configpath.Dir(), "done", configpath.VaultFileName(vaultPath, "json"))
+}
+
+// Load reads the saved entries. A missing file leaves the store empty.
+func (s *Store) Load() error {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	var entries []Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+	s.entries = entries
+	return nil
+}
+
+// Save writes the entries.
+func (s *Store) Save() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s.entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0o644)
+}
+
+// Mark records the todo as done at now. A todo already marked keeps its
+// original time.
+func (s *Store) Mark(uuid, text string, now time.Time) {
+	if _, ok := s.DoneAt(uuid, text); ok {
+		return
+	}
+	s.entries = append(s.entries, Entry{UUID: uuid, Text: text, Time: now})
+}
+
+// Unmark forgets the todo, as when it is reopened.
+func (s *Store) Unmark(uuid, text string) {
+	kept := s.entries[:0]
+	for _, e := range s.entries {
+		if e.UUID != uuid || e.Text != text {
+			kept = append(kept, e)
+		}
+	}
+	s.entries = kept
+}
+
+// DoneAt returns when the todo was marked done.
+func (s *Store) DoneAt(uuid, text string) (time.Time, bool) {
+	for _, e := range s.entries {
+		if e.UUID == uuid && e.Text == text {
+			return e.Time, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// Prune drops entries marked before cutoff, which no filter looks at.
+func (s *Store) Prune(cutoff time.Time) {
+	kept := s.entries[:0]
+	for _, e := range s.entries {
+		if !e.Time.Before(cutoff) {
+			kept = append(kept, e)
+		}
+	}
+	s.entries = kept
+}
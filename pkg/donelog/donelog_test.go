@@ -0,0 +1,54 @@
+package donelog
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMarkUnmarkAndPrune(t *testing.T) {
+	s := NewStoreWithPath(filepath.Join(t.TempDir(), "d.json"))
+	old := time.Now().Add(-30 * 24 * time.Hour)
+	now := time.Now()
+	s.Mark("1", "pay rent", old)
+	s.Mark("1", "call Sam", now)
+	s.Mark("1", "call Sam", now.Add(time.Hour))
+
+	if at, ok := s.DoneAt("1", "call Sam"); !ok || !at.Equal(now) {
+		t.Errorf("DoneAt = %v, %v; marking again should keep the first time", at, ok)
+	}
+	if _, ok := s.DoneAt("2", "call Sam"); ok {
+		t.Error("the same text in another note is a different todo")
+	}
+
+	s.Prune(now.Add(-7 * 24 * time.Hour))
+	if _, ok := s.DoneAt("1", "pay rent"); ok {
+		t.Error("Prune should drop entries older than the cutoff")
+	}
+	s.Unmark("1", "call Sam")
+	if _, ok := s.DoneAt("1", "call Sam"); ok {
+		t.Error("Unmark should forget the todo")
+	}
+}
+
+func TestSaveLoad_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dir", "d.json")
+	s := NewStoreWithPath(path)
+	now := time.Now().Truncate(time.Second)
+	s.Mark("1", "call Sam", now)
+	if err := s.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := NewStoreWithPath(path)
+	if err := loaded.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if at, ok := loaded.DoneAt("1", "call Sam"); !ok || !at.Equal(now) {
+		t.Errorf("loaded DoneAt = %v, %v; want %v", at, ok, now)
+	}
+
+	if err := NewStoreWithPath(filepath.Join(t.TempDir(), "missing.json")).Load(); err != nil {
+		t.Errorf("a missing file should load empty, got %v", err)
+	}
+}
@@ -0,0 +1,153 @@
+// Package export writes a preview out as a standalone Markdown or HTML
+// document. Each part of the document keeps the note it came from as an
+// HTML comment, so an exported file can be traced back to the vault.
+package export
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format is an export file format.
+type Format string
+
+const (
+	FormatMarkdown Format = "markdown"
+	FormatHTML     Format = "html"
+)
+
+// Ext returns the file extension for the format, including the dot.
+func (f Format) Ext() string {
+	if f == FormatHTML {
+		return ".html"
+	}
+	return ".md"
+}
+
+var fileNameUnsafe = regexp.MustCompile(`[^\p{L}\p{N}]+`)
+
+// FileName suggests a file name for a document titled title.
+func FileName(title string, f Format) string {
+	name := strings.Trim(fileNameUnsafe.ReplaceAllString(strings.ToLower(title), "-"), "-")
+	if name == "" {
+		name = "export"
+	}
+	return name + f.Ext()
+}
+
+// Section is one source note's part of a document.
+type Section struct {
+	Heading string // drawn as a heading above Body; empty for none
+	UUID    string // source note; empty for text with no source
+	Path    string // source note path, for the anchor comment
+	Body    string // markdown
+}
+
+// Document is what gets exported: a title and its sections in order.
+type Document struct {
+	Title    string
+	Sections []Section
+}
+
+// Options controls the output.
+type Options struct {
+	FrontMatter bool      // add a header with the title, export time and sources
+	Now         time.Time // export time recorded in the front matter
+}
+
+// Render writes doc in format.
+func Render(doc Document, format Format, opts Options) (string, error) {
+	if format == FormatHTML {
+		return HTML(doc, opts)
+	}
+	return Markdown(doc, opts)
+}
+
+// Markdown writes doc as a Markdown file: the optional YAML front matter,
+// the title as a level-one heading, then each section behind its source
+// anchor.
+func Markdown(doc Document, opts Options) (string, error) {
+	var sb strings.Builder
+	if opts.FrontMatter {
+		fm, err := frontMatter(doc, opts)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString("---\n")
+		sb.WriteString(fm)
+		sb.WriteString("---\n\n")
+	}
+	if doc.Title != "" {
+		fmt.Fprintf(&sb, "# %s\n\n", doc.Title)
+	}
+	for _, s := range doc.Sections {
+		if a := anchor(s); a != "" {
+			sb.WriteString(a + "\n")
+		}
+		if s.Heading != "" {
+			fmt.Fprintf(&sb, "## %s\n\n", s.Heading)
+		}
+		if body := strings.Trim(s.Body, "\n"); body != "" {
+			sb.WriteString(body + "\n")
+		}
+		sb.WriteString("\n")
+	}
+	return strings.TrimRight(sb.String(), "\n") + "\n", nil
+}
+
+// meta is the front matter header.
+type meta struct {
+	Title    string   `yaml:"title,omitempty"`
+	Exported string   `yaml:"exported"`
+	Sources  []string `yaml:"sources,omitempty"`
+}
+
+func frontMatter(doc Document, opts Options) (string, error) {
+	data, err := yaml.Marshal(meta{
+		Title:    doc.Title,
+		Exported: opts.Now.Format(time.RFC3339),
+		Sources:  Sources(doc),
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// Sources returns the UUIDs of the notes doc was built from, once each,
+// in document order.
+func Sources(doc Document) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, s := range doc.Sections {
+		if s.UUID == "" || seen[s.UUID] {
+			continue
+		}
+		seen[s.UUID] = true
+		out = append(out, s.UUID)
+	}
+	return out
+}
+
+// anchor is the comment naming a section's source note, or "" when it
+// has none.
+func anchor(s Section) string {
+	if s.UUID == "" {
+		return ""
+	}
+	text := "source: " + s.UUID
+	if s.Path != "" {
+		text += " " + s.Path
+	}
+	return comment(text)
+}
+
+// comment wraps text in an HTML comment. "--" can't appear inside one, and
+// removing it also means nothing in text can close the comment early.
+func comment(text string) string {
+	return "<!-- " + strings.ReplaceAll(text, "--", "- -") + " -->"
+}
@@ -0,0 +1,71 @@
+package export
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func sampleDoc() Document {
+	return Document{
+		Title: "Project Plan",
+		Sections: []Section{
+			{UUID: "parent-1", Path: "plan.md", Body: "Intro text\n"},
+			{Heading: "Goals", UUID: "child-1", Path: "goals.md", Body: "- [ ] ship it\n"},
+			{UUID: "child-1", Path: "goals.md", Body: "more goals"},
+		},
+	}
+}
+
+func TestMarkdown_AnchorsEachSection(t *testing.T) {
+	got, err := Markdown(sampleDoc(), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "# Project Plan\n\n" +
+		"<!-- source: parent-1 plan.md -->\nIntro text\n\n" +
+		"<!-- source: child-1 goals.md -->\n## Goals\n\n- [ ] ship it\n\n" +
+		"<!-- source: child-1 goals.md -->\nmore goals\n"
+	if got != want {
+		t.Errorf("markdown =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestMarkdown_FrontMatterListsSourcesOnce(t *testing.T) {
+	now := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	got, err := Markdown(sampleDoc(), Options{FrontMatter: true, Now: now})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantHeader := "---\ntitle: Project Plan\nexported: \"2026-03-04T05:06:07Z\"\nsources:\n    - parent-1\n    - child-1\n---\n\n# Project Plan\n"
+	if !strings.HasPrefix(got, wantHeader) {
+		t.Errorf("markdown =\n%s\nwant it to start with\n%s", got, wantHeader)
+	}
+}
+
+func TestAnchor_NeverClosesTheCommentEarly(t *testing.T) {
+	got := anchor(Section{UUID: "u", Path: "a--b.md"})
+	if strings.Count(got, "--") != 2 {
+		t.Errorf("anchor = %q, want no -- inside the comment", got)
+	}
+	if anchor(Section{Body: "x"}) != "" {
+		t.Error("a section without a source should have no anchor")
+	}
+}
+
+func TestFileName(t *testing.T) {
+	tests := []struct {
+		title  string
+		format Format
+		want   string
+	}{
+		{"Pick: #work @today", FormatMarkdown, "pick-work-today.md"},
+		{"Project Plan", FormatHTML, "project-plan.html"},
+		{"", FormatMarkdown, "export.md"},
+	}
+	for _, tc := range tests {
+		if got := FileName(tc.title, tc.format); got != tc.want {
+			t.Errorf("FileName(%q) = %q, want %q", tc.title, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,270 @@
+package export
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const htmlStyle = `body { max-width: 46rem; margin: 2rem auto; padding: 0 1rem; font-family: system-ui, sans-serif; line-height: 1.6; color: #222; }
+nav.toc { border: 1px solid #ddd; border-radius: 6px; padding: 0.5rem 1rem; margin-bottom: 2rem; }
+nav.toc ul { list-style: none; padding-left: 0; margin: 0; }
+nav.toc .toc-3 { padding-left: 1rem; }
+nav.toc .toc-4 { padding-left: 2rem; }
+pre { background: #f5f5f5; padding: 0.75rem; overflow-x: auto; border-radius: 4px; }
+code { font-family: ui-monospace, monospace; font-size: 0.9em; }
+blockquote { margin-left: 0; padding-left: 1rem; border-left: 3px solid #ddd; color: #555; }
+li.task { list-style: none; }
+.tag { color: #36c; }
+`
+
+// HTML writes doc as a standalone HTML page: the optional front matter as
+// meta tags, a table of contents built from the headings, then each
+// section behind its source anchor.
+func HTML(doc Document, opts Options) (string, error) {
+	r := &htmlRenderer{ids: map[string]int{}}
+	var body strings.Builder
+	for _, s := range doc.Sections {
+		if a := anchor(s); a != "" {
+			body.WriteString(a + "\n")
+		}
+		if s.Heading != "" {
+			body.WriteString(r.heading(2, s.Heading))
+		}
+		r.blocks(&body, strings.Split(strings.Trim(s.Body, "\n"), "\n"))
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(&sb, "<title>%s</title>\n", html.EscapeString(doc.Title))
+	if opts.FrontMatter {
+		fmt.Fprintf(&sb, "<meta name=\"exported\" content=\"%s\">\n", opts.Now.Format(time.RFC3339))
+		if src := Sources(doc); len(src) > 0 {
+			fmt.Fprintf(&sb, "<meta name=\"sources\" content=\"%s\">\n", html.EscapeString(strings.Join(src, " ")))
+		}
+	}
+	sb.WriteString("<style>\n" + htmlStyle + "</style>\n</head>\n<body>\n")
+	if doc.Title != "" {
+		fmt.Fprintf(&sb, "<h1>%s</h1>\n", inline(doc.Title))
+	}
+	if len(r.toc) > 0 {
+		sb.WriteString("<nav class=\"toc\">\n<ul>\n")
+		for _, e := range r.toc {
+			fmt.Fprintf(&sb, "<li class=\"toc-%d\"><a href=\"#%s\">%s</a></li>\n", e.level, e.id, e.text)
+		}
+		sb.WriteString("</ul>\n</nav>\n")
+	}
+	sb.WriteString("<main>\n")
+	sb.WriteString(body.String())
+	sb.WriteString("</main>\n</body>\n</html>\n")
+	return sb.String(), nil
+}
+
+// tocEntry is one heading listed in the table of contents.
+type tocEntry struct {
+	level int
+	id    string
+	text  string // rendered inline HTML
+}
+
+// htmlRenderer converts the Markdown notes are written in to HTML. It
+// covers headings, paragraphs, lists and tasks, quotes, fenced code and
+// rules; anything else passes through as paragraph text.
+type htmlRenderer struct {
+	toc []tocEntry
+	ids map[string]int
+}
+
+var (
+	headingRe   = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	ruleRe      = regexp.MustCompile(`^\s*([-*_])(\s*[-*_]){2,}\s*$`)
+	listItemRe  = regexp.MustCompile(`^(\s*)([-*+]|\d+[.)])\s+(.*)$`)
+	taskRe      = regexp.MustCompile(`^\[([ xX])\]\s+(.*)$`)
+	fenceRe     = regexp.MustCompile("^\\s*(```|~~~)\\s*(\\S*)")
+	quoteRe     = regexp.MustCompile(`^\s*>\s?(.*)$`)
+	commentRe   = regexp.MustCompile(`^\s*<!--.*-->\s*$`)
+	nonSlugRune = regexp.MustCompile(`[^\p{L}\p{N}]+`)
+)
+
+// heading renders a heading with a unique id and lists it in the table of
+// contents.
+func (r *htmlRenderer) heading(level int, text string) string {
+	slug := strings.Trim(nonSlugRune.ReplaceAllString(strings.ToLower(text), "-"), "-")
+	if slug == "" {
+		slug = "section"
+	}
+	id := slug
+	if n := r.ids[slug]; n > 0 {
+		id = slug + "-" + strconv.Itoa(n+1)
+	}
+	r.ids[slug]++
+	rendered := inline(text)
+	if level <= 4 {
+		r.toc = append(r.toc, tocEntry{level: max(level, 2), id: id, text: rendered})
+	}
+	return fmt.Sprintf("<h%d id=\"%s\">%s</h%d>\n", level, id, rendered, level)
+}
+
+// blocks renders lines of Markdown into sb.
+func (r *htmlRenderer) blocks(sb *strings.Builder, lines []string) {
+	var para []string
+	flush := func() {
+		if len(para) > 0 {
+			fmt.Fprintf(sb, "<p>%s</p>\n", inline(strings.Join(para, "\n")))
+			para = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		switch {
+		case strings.TrimSpace(line) == "":
+			flush()
+		case fenceRe.MatchString(line):
+			flush()
+			m := fenceRe.FindStringSubmatch(line)
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), m[1]); i++ {
+				code = append(code, lines[i])
+			}
+			class := ""
+			if m[2] != "" {
+				class = fmt.Sprintf(" class=\"language-%s\"", html.EscapeString(m[2]))
+			}
+			fmt.Fprintf(sb, "<pre><code%s>%s</code></pre>\n", class, html.EscapeString(strings.Join(code, "\n")))
+		case commentRe.MatchString(line):
+			flush()
+			// Re-wrap rather than copy, so a line like
+			// "<!-- --><script>…<!-- -->" stays one inert comment.
+			inner := strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(line), "<!--"), "-->")
+			sb.WriteString(comment(strings.TrimSpace(inner)) + "\n")
+		case headingRe.MatchString(line):
+			flush()
+			m := headingRe.FindStringSubmatch(line)
+			sb.WriteString(r.heading(len(m[1]), m[2]))
+		case ruleRe.MatchString(line):
+			flush()
+			sb.WriteString("<hr>\n")
+		case quoteRe.MatchString(line):
+			flush()
+			var quoted []string
+			for ; i < len(lines) && quoteRe.MatchString(lines[i]); i++ {
+				quoted = append(quoted, quoteRe.FindStringSubmatch(lines[i])[1])
+			}
+			i--
+			sb.WriteString("<blockquote>\n")
+			r.blocks(sb, quoted)
+			sb.WriteString("</blockquote>\n")
+		case listItemRe.MatchString(line):
+			flush()
+			i = r.list(sb, lines, i) - 1
+		default:
+			para = append(para, strings.TrimSpace(line))
+		}
+	}
+	flush()
+}
+
+// list renders the list starting at lines[start] and returns the index of
+// the first line after it. Items indented deeper than the first become
+// nested lists.
+func (r *htmlRenderer) list(sb *strings.Builder, lines []string, start int) int {
+	first := listItemRe.FindStringSubmatch(lines[start])
+	indent := len(first[1])
+	tag := "ul"
+	if first[2][0] >= '0' && first[2][0] <= '9' {
+		tag = "ol"
+	}
+	fmt.Fprintf(sb, "<%s>\n", tag)
+
+	i := start
+	for i < len(lines) {
+		m := listItemRe.FindStringSubmatch(lines[i])
+		if m == nil || len(m[1]) < indent {
+			break
+		}
+		if len(m[1]) > indent {
+			i = r.list(sb, lines, i)
+			continue
+		}
+		text := m[3]
+		if t := taskRe.FindStringSubmatch(text); t != nil {
+			checked := ""
+			if t[1] != " " {
+				checked = " checked"
+			}
+			fmt.Fprintf(sb, "<li class=\"task\"><input type=\"checkbox\" disabled%s> %s</li>\n", checked, inline(t[2]))
+		} else {
+			fmt.Fprintf(sb, "<li>%s</li>\n", inline(text))
+		}
+		i++
+	}
+	fmt.Fprintf(sb, "</%s>\n", tag)
+	return i
+}
+
+var (
+	codeSpanRe = regexp.MustCompile("`([^`]+)`")
+	wikiLinkRe = regexp.MustCompile(`\[\[([^\]|]+)(?:\|([^\]]+))?\]\]`)
+	linkRe     = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	boldRe     = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	italicRe   = regexp.MustCompile(`\*([^*\s][^*]*)\*`)
+	strikeRe   = regexp.MustCompile(`~~([^~]+)~~`)
+	tagRe      = regexp.MustCompile(`(^|\s)(#[\p{L}\p{N}_/-]+)`)
+)
+
+// inline renders the spans within a block: code, links, emphasis and
+// tags. Text inside code spans is left as is.
+func inline(text string) string {
+	var sb strings.Builder
+	last := 0
+	for _, loc := range codeSpanRe.FindAllStringSubmatchIndex(text, -1) {
+		sb.WriteString(inlineText(text[last:loc[0]]))
+		sb.WriteString("<code>" + html.EscapeString(text[loc[2]:loc[3]]) + "</code>")
+		last = loc[1]
+	}
+	sb.WriteString(inlineText(text[last:]))
+	return sb.String()
+}
+
+// safeLinkTarget reports whether a link may be emitted as an href: a
+// relative reference or an http, https or mailto URL. Anything else, such
+// as javascript:, is rendered as its label alone.
+func safeLinkTarget(target string) bool {
+	scheme, _, found := strings.Cut(target, ":")
+	if !found || strings.ContainsAny(scheme, "/?#") {
+		return true
+	}
+	switch strings.ToLower(scheme) {
+	case "http", "https", "mailto":
+		return true
+	}
+	return false
+}
+
+func inlineText(text string) string {
+	s := html.EscapeString(text)
+	s = wikiLinkRe.ReplaceAllStringFunc(s, func(m string) string {
+		sub := wikiLinkRe.FindStringSubmatch(m)
+		label := sub[1]
+		if sub[2] != "" {
+			label = sub[2]
+		}
+		return "<span class=\"wikilink\">" + label + "</span>"
+	})
+	s = linkRe.ReplaceAllStringFunc(s, func(m string) string {
+		sub := linkRe.FindStringSubmatch(m)
+		if !safeLinkTarget(html.UnescapeString(sub[2])) {
+			return sub[1]
+		}
+		return `<a href="` + sub[2] + `">` + sub[1] + `</a>`
+	})
+	s = boldRe.ReplaceAllString(s, "<strong>$1$2</strong>")
+	s = italicRe.ReplaceAllString(s, "<em>$1</em>")
+	s = strikeRe.ReplaceAllString(s, "<del>$1</del>")
+	s = tagRe.ReplaceAllString(s, `$1<span class="tag">$2</span>`)
+	return s
+}
@@ -0,0 +1,79 @@
+package export
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHTML_TableOfContentsAndAnchors(t *testing.T) {
+	doc := Document{
+		Title: "Plan <v2>",
+		Sections: []Section{
+			{Heading: "Goals", UUID: "child-1", Path: "goals.md", Body: "## Goals\ntext"},
+			{UUID: "child-2", Body: "### Next steps"},
+		},
+	}
+	got, err := HTML(doc, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"<title>Plan &lt;v2&gt;</title>",
+		"<!-- source: child-1 goals.md -->\n<h2 id=\"goals\">Goals</h2>",
+		"<h2 id=\"goals-2\">Goals</h2>",
+		"<!-- source: child-2 -->\n<h3 id=\"next-steps\">Next steps</h3>",
+		`<li class="toc-2"><a href="#goals-2">Goals</a></li>`,
+		`<li class="toc-3"><a href="#next-steps">Next steps</a></li>`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("html missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, `name="exported"`) {
+		t.Error("front matter meta tags should only be written when asked for")
+	}
+}
+
+func TestHTMLRenderer_Blocks(t *testing.T) {
+	tests := []struct {
+		name string
+		md   string
+		want string
+	}{
+		{"paragraph", "one\ntwo\n\nthree", "<p>one\ntwo</p>\n<p>three</p>\n"},
+		{"nested list", "- a\n  - b\n- c", "<ul>\n<li>a</li>\n<ul>\n<li>b</li>\n</ul>\n<li>c</li>\n</ul>\n"},
+		{"ordered", "1. a\n2. b", "<ol>\n<li>a</li>\n<li>b</li>\n</ol>\n"},
+		{"tasks", "- [ ] open\n- [x] done", "<ul>\n<li class=\"task\"><input type=\"checkbox\" disabled> open</li>\n<li class=\"task\"><input type=\"checkbox\" disabled checked> done</li>\n</ul>\n"},
+		{"code", "```go\nx := <y>\n```", "<pre><code class=\"language-go\">x := &lt;y&gt;</code></pre>\n"},
+		{"quote", "> quoted\n> text", "<blockquote>\n<p>quoted\ntext</p>\n</blockquote>\n"},
+		{"rule", "---", "<hr>\n"},
+		{"comment", "<!-- keep -->", "<!-- keep -->\n"},
+		{"comment breakout", "<!-- --><script>x()</script><!-- -->", "<!-- - -><script>x()</script><!- - -->\n"},
+	}
+	for _, tc := range tests {
+		r := &htmlRenderer{ids: map[string]int{}}
+		var sb strings.Builder
+		r.blocks(&sb, strings.Split(tc.md, "\n"))
+		if sb.String() != tc.want {
+			t.Errorf("%s: got %q, want %q", tc.name, sb.String(), tc.want)
+		}
+	}
+}
+
+func TestInline(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"**bold** and *em* ~~old~~", "<strong>bold</strong> and <em>em</em> <del>old</del>"},
+		{"[site](https://x.io?a=1&b=2)", `<a href="https://x.io?a=1&amp;b=2">site</a>`},
+		{"[mail](mailto:a@b.c) [rel](notes/a.md#top)", `<a href="mailto:a@b.c">mail</a> <a href="notes/a.md#top">rel</a>`},
+		{"[click](javascript:alert.call) [x](JavaScript:y) [d](data:text/html,hi)", "click x d"},
+		{"see [[Other Note|other]] #work", `see <span class="wikilink">other</span> <span class="tag">#work</span>`},
+		{"`a *b* <c>` *d*", "<code>a *b* &lt;c&gt;</code> <em>d</em>"},
+	}
+	for _, tc := range tests {
+		if got := inline(tc.in); got != tc.want {
+			t.Errorf("inline(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
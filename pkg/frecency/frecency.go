@@ -0,0 +1,115 @@
+// Package frecency remembers how often and how recently palette commands
+// were run and notes opened, per vault, so the palette can rank the ones
+// in daily use first.
+package frecency
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/donnellyk/lazyruin/pkg/configpath"
+)
+
+// maxEntries bounds the file; the lowest-scoring entries are dropped on
+// Save beyond it.
+const maxEntries = 500
+
+// Entry is the use history of one command or note.
+type Entry struct {
+	Count int       `json:"count"`
+	Last  time.Time `json:"last"`
+}
+
+// Store reads and writes one vault's frecency file.
+type Store struct {
+	path    string
+	entries map[string]Entry
+}
+
+func NewStoreForVault(vaultPath string) *Store {
+	return NewStoreWithPath(PathForVault(vaultPath))
+}
+
+func NewStoreWithPath(path string) *Store {
+	return &Store{path: path, entries: map[string]Entry{}}
+}
+
+// PathForVault returns the frecency file path for a given vault, stored
+// under the lazyruin config directory keyed by a hash of the vault path.
+func PathForVault(vaultPath string) string {
+	return filepath.Join(configpath.Dir(), "frecency", configpath.VaultFileName(vaultPath, "json"))
+}
+
+// Load reads the saved entries. A missing file leaves the store empty.
+func (s *Store) Load() error {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	entries := map[string]Entry{}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+	s.entries = entries
+	return nil
+}
+
+// Save writes the entries, keeping the maxEntries highest-scoring.
+func (s *Store) Save() error {
+	if len(s.entries) > maxEntries {
+		now := time.Now()
+		keys := make([]string, 0, len(s.entries))
+		for k := range s.entries {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool { return s.Score(keys[i], now) > s.Score(keys[j], now) })
+		for _, k := range keys[maxEntries:] {
+			delete(s.entries, k)
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s.entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0o644)
+}
+
+// Record counts one use of key at now.
+func (s *Store) Record(key string, now time.Time) {
+	e := s.entries[key]
+	e.Count++
+	e.Last = now
+	s.entries[key] = e
+}
+
+// Score weighs key's use count by how recently it was last used: uses in
+// the last hour count four times, the last day twice, the last week once,
+// the last month half and older ones a quarter. Unused keys score 0.
+func (s *Store) Score(key string, now time.Time) float64 {
+	e, ok := s.entries[key]
+	if !ok {
+		return 0
+	}
+	age := now.Sub(e.Last)
+	weight := 0.25
+	switch {
+	case age < time.Hour:
+		weight = 4
+	case age < 24*time.Hour:
+		weight = 2
+	case age < 7*24*time.Hour:
+		weight = 1
+	case age < 30*24*time.Hour:
+		weight = 0.5
+	}
+	return float64(e.Count) * weight
+}
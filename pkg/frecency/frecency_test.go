@@ -0,0 +1,54 @@
+package frecency
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestScore_WeighsRecentUse(t *testing.T) {
+	s := NewStoreWithPath(filepath.Join(t.TempDir(), "f.json"))
+	now := time.Now()
+	s.Record("fresh", now.Add(-time.Minute))
+	for range 4 {
+		s.Record("stale", now.Add(-60*24*time.Hour))
+	}
+
+	if got := s.Score("fresh", now); got != 4 {
+		t.Errorf("one use a minute ago = %v, want 4", got)
+	}
+	if got := s.Score("stale", now); got != 1 {
+		t.Errorf("four uses two months ago = %v, want 1", got)
+	}
+	if got := s.Score("never", now); got != 0 {
+		t.Errorf("unused key = %v, want 0", got)
+	}
+}
+
+func TestSaveLoad_RoundTripAndPrune(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dir", "f.json")
+	s := NewStoreWithPath(path)
+	now := time.Now()
+	for i := range maxEntries + 10 {
+		s.Record(string(rune('a'+i%26))+string(rune(i)), now.Add(-time.Duration(i)*time.Hour))
+	}
+	s.Record("cmd:Global:Quit", now)
+	if err := s.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := NewStoreWithPath(path)
+	if err := loaded.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.entries) != maxEntries {
+		t.Errorf("entries = %d, want pruned to %d", len(loaded.entries), maxEntries)
+	}
+	if loaded.Score("cmd:Global:Quit", now) != 4 {
+		t.Error("the most recent entry should survive pruning")
+	}
+
+	if err := NewStoreWithPath(filepath.Join(t.TempDir(), "missing.json")).Load(); err != nil {
+		t.Errorf("a missing file should load empty, got %v", err)
+	}
+}
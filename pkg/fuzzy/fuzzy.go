@@ -0,0 +1,120 @@
+// Package fuzzy scores how well a typed pattern matches a name, for
+// ranking command palette and Quick Open entries.
+package fuzzy
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Score weights. A matched character is worth scoreMatch; starting a word
+// or following the previous match adds a bonus, and skipped characters
+// cost a little each.
+const (
+	scoreMatch       = 16
+	bonusBoundary    = 10
+	bonusConsecutive = 8
+	penaltyGap       = 1
+	maxLeadPenalty   = 8
+)
+
+// Match reports whether every space-separated word of pattern appears in
+// text as a case-insensitive subsequence ("mtg nts" matches "Meeting
+// notes"). It returns a score, higher for tighter matches at word starts
+// in shorter text, and the sorted rune indexes of the matched characters.
+// An empty pattern matches everything with a zero score.
+func Match(pattern, text string) (int, []int, bool) {
+	words := strings.Fields(strings.ToLower(pattern))
+	if len(words) == 0 {
+		return 0, nil, true
+	}
+	runes := []rune(text)
+	lower := []rune(strings.ToLower(text))
+	if len(lower) != len(runes) {
+		// Lowercasing changed the length (rare non-ASCII cases); match
+		// on the original runes so indexes still line up.
+		lower = runes
+	}
+
+	total := 0
+	seen := map[int]bool{}
+	var positions []int
+	for _, w := range words {
+		score, pos, ok := matchWord([]rune(w), lower, runes)
+		if !ok {
+			return 0, nil, false
+		}
+		total += score
+		for _, p := range pos {
+			if !seen[p] {
+				seen[p] = true
+				positions = append(positions, p)
+			}
+		}
+	}
+	sort.Ints(positions)
+	return total - len(runes)/4, positions, true
+}
+
+// matchWord finds the best-scoring subsequence match of word in lower,
+// trying each place the word's first character occurs.
+func matchWord(word, lower, orig []rune) (int, []int, bool) {
+	best, bestPos, found := 0, []int(nil), false
+	for start := range lower {
+		if lower[start] != word[0] {
+			continue
+		}
+		pos := []int{start}
+		j := start + 1
+		for _, r := range word[1:] {
+			for j < len(lower) && lower[j] != r {
+				j++
+			}
+			if j == len(lower) {
+				break
+			}
+			pos = append(pos, j)
+			j++
+		}
+		if len(pos) < len(word) {
+			// Later starts only leave less text to match in.
+			break
+		}
+		if s := scorePositions(pos, orig); !found || s > best {
+			best, bestPos, found = s, pos, true
+		}
+	}
+	return best, bestPos, found
+}
+
+func scorePositions(pos []int, orig []rune) int {
+	score := -min(pos[0], maxLeadPenalty)
+	for i, p := range pos {
+		score += scoreMatch
+		if isBoundary(orig, p) {
+			score += bonusBoundary
+		}
+		if i > 0 {
+			if p == pos[i-1]+1 {
+				score += bonusConsecutive
+			} else {
+				score -= (p - pos[i-1] - 1) * penaltyGap
+			}
+		}
+	}
+	return score
+}
+
+// isBoundary reports whether the rune at i starts a word: the first rune,
+// one after a separator, or an upper-case letter after a lower-case one.
+func isBoundary(r []rune, i int) bool {
+	if i == 0 {
+		return true
+	}
+	prev, cur := r[i-1], r[i]
+	if !unicode.IsLetter(prev) && !unicode.IsDigit(prev) {
+		return true
+	}
+	return unicode.IsLower(prev) && unicode.IsUpper(cur)
+}
@@ -0,0 +1,54 @@
+package fuzzy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern, text string
+		ok            bool
+		positions     []int
+	}{
+		{"", "anything", true, nil},
+		{"mtg nts", "Meeting notes", true, []int{0, 3, 6, 8, 10, 12}},
+		{"QUIT", "Quit", true, []int{0, 1, 2, 3}},
+		{"nts mtg", "Meeting notes", true, []int{0, 3, 6, 8, 10, 12}},
+		{"mtgx", "Meeting notes", false, nil},
+		{"zz", "Quit", false, nil},
+	}
+	for _, tt := range tests {
+		_, positions, ok := Match(tt.pattern, tt.text)
+		if ok != tt.ok || !reflect.DeepEqual(positions, tt.positions) {
+			t.Errorf("Match(%q, %q) = %v, %v; want %v, %v", tt.pattern, tt.text, positions, ok, tt.positions, tt.ok)
+		}
+	}
+}
+
+func TestMatch_Ranking(t *testing.T) {
+	better := []struct{ pattern, a, b string }{
+		{"td", "Toggle Todo", "Set Inline Date"},           // word starts over letters mid-word
+		{"note", "New Note", "New Note from Template"},     // shorter text
+		{"quit", "Quit", "Squint at Quoted Items"},         // consecutive over gaps
+		{"fm", "Toggle FrontMatter", "Toggle Frontmatter"}, // camel-case humps are word starts
+	}
+	for _, tt := range better {
+		sa, _, okA := Match(tt.pattern, tt.a)
+		sb, _, okB := Match(tt.pattern, tt.b)
+		if !okA || !okB {
+			t.Errorf("%q should match both %q and %q", tt.pattern, tt.a, tt.b)
+			continue
+		}
+		if sa <= sb {
+			t.Errorf("%q: %q (%d) should outrank %q (%d)", tt.pattern, tt.a, sa, tt.b, sb)
+		}
+	}
+}
+
+func TestMatch_PrefersWordStartOccurrence(t *testing.T) {
+	_, positions, _ := Match("n", "Edit Note")
+	if !reflect.DeepEqual(positions, []int{5}) {
+		t.Errorf("positions = %v, want the N of Note", positions)
+	}
+}
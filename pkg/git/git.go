@@ -0,0 +1,152 @@
+// Package git reads the history of note files from the git repository a
+// vault lives in, and commits changes made from lazyruin back to it. It
+// shells out to the git binary; a vault outside any repository simply has
+// no history.
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ErrNotRepo is returned when a path is not inside a git work tree.
+var ErrNotRepo = errors.New("not in a git repository")
+
+// Revision is one commit that touched a note file.
+type Revision struct {
+	Hash    string
+	Date    time.Time
+	Subject string
+	Repo    string // work tree root
+	Path    string // the file's path at this revision, relative to Repo
+}
+
+// ShortHash returns the abbreviated commit hash.
+func (r Revision) ShortHash() string {
+	if len(r.Hash) > 7 {
+		return r.Hash[:7]
+	}
+	return r.Hash
+}
+
+// run runs git in dir and returns its stdout. Failures carry git's stderr.
+func run(dir string, args ...string) ([]byte, error) {
+	return runContext(context.Background(), dir, args...)
+}
+
+// runContext is run with the git process bound to ctx, so cancelling ctx
+// kills it.
+func runContext(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return nil, fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return out, nil
+}
+
+// Root returns the root of the work tree containing dir.
+func Root(dir string) (string, error) {
+	out, err := run(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", ErrNotRepo
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// Log lists the commits that touched the file at path, newest first,
+// following renames. Cancelling ctx stops the lookup.
+func Log(ctx context.Context, path string) ([]Revision, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	root, err := Root(filepath.Dir(abs))
+	if err != nil {
+		return nil, err
+	}
+	out, err := runContext(ctx, root, "log", "--follow", "--name-only",
+		"--format=%x1e%H%x1f%aI%x1f%s", "--", abs)
+	if err != nil {
+		return nil, err
+	}
+	return parseLog(root, string(out)), nil
+}
+
+// parseLog parses `git log --name-only` output whose records start with a
+// 0x1e byte and hold hash, date and subject separated by 0x1f, followed by
+// the file name on its own line.
+func parseLog(root, out string) []Revision {
+	var revs []Revision
+	for _, rec := range strings.Split(out, "\x1e") {
+		lines := strings.Split(strings.TrimSpace(rec), "\n")
+		fields := strings.SplitN(lines[0], "\x1f", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		rev := Revision{Hash: fields[0], Subject: fields[2], Repo: root}
+		rev.Date, _ = time.Parse(time.RFC3339, fields[1])
+		for _, l := range lines[1:] {
+			if l = strings.TrimSpace(l); l != "" {
+				rev.Path = l
+				break
+			}
+		}
+		revs = append(revs, rev)
+	}
+	return revs
+}
+
+// Diff returns the patch rev made to its file, without color codes.
+// Cancelling ctx stops git.
+func Diff(ctx context.Context, rev Revision) (string, error) {
+	out, err := runContext(ctx, rev.Repo, "show", "--no-color", "--format=", rev.Hash, "--", rev.Path)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// Show returns the file's full contents as of rev.
+func Show(rev Revision) ([]byte, error) {
+	return run(rev.Repo, "show", rev.Hash+":"+rev.Path)
+}
+
+// vaultPathspec limits staging and commits to dir, minus ruin's index in
+// .ruin/, which changes with every note and isn't note content.
+var vaultPathspec = []string{"--", ".", ":(exclude).ruin"}
+
+// CommitAll stages every change under dir and commits it with message.
+// Changes elsewhere in the repository and in the vault's .ruin/ index are
+// left alone. It reports false, without error, when there is nothing to
+// commit.
+func CommitAll(dir, message string) (bool, error) {
+	if _, err := Root(dir); err != nil {
+		return false, err
+	}
+	status, err := run(dir, append([]string{"status", "--porcelain"}, vaultPathspec...)...)
+	if err != nil {
+		return false, err
+	}
+	if len(bytes.TrimSpace(status)) == 0 {
+		return false, nil
+	}
+	if _, err := run(dir, append([]string{"add", "-A"}, vaultPathspec...)...); err != nil {
+		return false, err
+	}
+	if _, err := run(dir, append([]string{"commit", "-q", "-m", message}, vaultPathspec...)...); err != nil {
+		return false, err
+	}
+	return true, nil
+}
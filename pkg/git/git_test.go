@@ -0,0 +1,132 @@
+package git
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// initRepo creates a git repo in a temp dir with a committer identity so
+// commits work on machines without a global git config.
+func initRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"config", "user.name", "Test"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "commit.gpgsign", "false"},
+	} {
+		if _, err := run(dir, args...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func writeAndCommit(t *testing.T, dir, name, content, message string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := CommitAll(dir, message); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLogDiffAndShow(t *testing.T) {
+	dir := initRepo(t)
+	writeAndCommit(t, dir, "note.md", "first\n", "add note")
+	writeAndCommit(t, dir, "other.md", "x\n", "unrelated")
+	writeAndCommit(t, dir, "note.md", "second\n", "edit note")
+
+	revs, err := Log(context.Background(), filepath.Join(dir, "note.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(revs) != 2 || revs[0].Subject != "edit note" || revs[1].Subject != "add note" {
+		t.Fatalf("revisions = %+v, want the two commits touching note.md, newest first", revs)
+	}
+	if revs[0].Path != "note.md" || revs[0].Date.IsZero() || len(revs[0].ShortHash()) != 7 {
+		t.Errorf("revision = %+v", revs[0])
+	}
+
+	diff, err := Diff(context.Background(), revs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(diff, "-first") || !strings.Contains(diff, "+second") {
+		t.Errorf("diff =\n%s", diff)
+	}
+
+	old, err := Show(revs[1])
+	if err != nil || string(old) != "first\n" {
+		t.Errorf("Show = %q, %v; want the first version", old, err)
+	}
+}
+
+func TestLogFollowsRenames(t *testing.T) {
+	dir := initRepo(t)
+	writeAndCommit(t, dir, "old.md", "a long enough body to be detected as a rename\n", "add")
+	if _, err := run(dir, "mv", "old.md", "new.md"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := CommitAll(dir, "rename"); err != nil {
+		t.Fatal(err)
+	}
+
+	revs, err := Log(context.Background(), filepath.Join(dir, "new.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(revs) != 2 || revs[1].Path != "old.md" {
+		t.Fatalf("revisions = %+v, want the pre-rename commit at old.md", revs)
+	}
+	if data, err := Show(revs[1]); err != nil || !strings.HasPrefix(string(data), "a long") {
+		t.Errorf("Show = %q, %v", data, err)
+	}
+}
+
+func TestCommitAll(t *testing.T) {
+	dir := initRepo(t)
+	if committed, err := CommitAll(dir, "nothing"); err != nil || committed {
+		t.Errorf("CommitAll on a clean tree = %v, %v; want false, nil", committed, err)
+	}
+	writeAndCommit(t, dir, "a.md", "a\n", "lazyruin: add a")
+	out, err := run(dir, "log", "--format=%s")
+	if err != nil || strings.TrimSpace(string(out)) != "lazyruin: add a" {
+		t.Errorf("log = %q, %v", out, err)
+	}
+
+	if _, err := CommitAll(t.TempDir(), "x"); err != ErrNotRepo {
+		t.Errorf("CommitAll outside a repo = %v, want ErrNotRepo", err)
+	}
+}
+
+func TestCommitAll_SkipsRuinIndex(t *testing.T) {
+	dir := initRepo(t)
+	if err := os.Mkdir(filepath.Join(dir, ".ruin"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".ruin", "index.db"), []byte("index"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if committed, err := CommitAll(dir, "index only"); err != nil || committed {
+		t.Errorf("CommitAll with only .ruin/ changed = %v, %v; want false, nil", committed, err)
+	}
+
+	writeAndCommit(t, dir, "a.md", "a\n", "add a")
+	out, err := run(dir, "ls-tree", "-r", "--name-only", "HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if files := strings.Fields(string(out)); len(files) != 1 || files[0] != "a.md" {
+		t.Errorf("committed files = %v, want only a.md", files)
+	}
+}
@@ -0,0 +1,106 @@
+package gui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/donnellyk/lazyruin/pkg/models"
+	"github.com/donnellyk/lazyruin/pkg/testutil"
+)
+
+// backlinksMock builds a vault where "Plan" is referenced by title from
+// one note and by alias from another, and a third note doesn't mention it.
+func backlinksMock(t *testing.T) *testutil.MockExecutor {
+	t.Helper()
+	dir := t.TempDir()
+	note := func(uuid, title, frontmatter, content string) models.Note {
+		path := filepath.Join(dir, uuid+".md")
+		body := "---\nuuid: " + uuid + "\n" + frontmatter + "---\n" + content
+		if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return models.Note{UUID: uuid, Title: title, Path: path, Content: content}
+	}
+	return testutil.NewMockExecutor().WithNotes(
+		note("plan", "Plan", "aliases: [Roadmap]\n", "steps\n"),
+		note("a", "Meeting", "", "agreed on [[Plan]]\n"),
+		note("b", "Ideas", "", "first\n\nsee the [[roadmap#Q3]]\n"),
+		note("c", "Other", "", "nothing here\n"),
+	)
+}
+
+func TestBacklinks_ListsReferencingNotesAndOpensAtLine(t *testing.T) {
+	tg := newTestGui(t, backlinksMock(t))
+	defer tg.Close()
+
+	if err := tg.gui.helpers.PreviewNav().OpenNoteByUUID("plan"); err != nil {
+		t.Fatal(err)
+	}
+	if err := tg.gui.helpers.Backlinks().ShowBacklinks(); err != nil {
+		t.Fatal(err)
+	}
+
+	if tg.gui.contexts.ActivePreviewKey != "pickResults" {
+		t.Fatalf("ActivePreviewKey = %q, want pickResults", tg.gui.contexts.ActivePreviewKey)
+	}
+	pr := tg.gui.contexts.PickResults
+	if pr.Title() != "Backlinks: Plan" {
+		t.Errorf("title = %q", pr.Title())
+	}
+	if len(pr.Results) != 2 || pr.Results[0].UUID != "a" || pr.Results[1].UUID != "b" {
+		t.Fatalf("results = %+v, want notes a and b", pr.Results)
+	}
+	if m := pr.Results[1].Matches; len(m) != 1 || m[0].Line != 3 || m[0].Content != "see the [[roadmap#Q3]]" {
+		t.Errorf("alias match = %+v, want line 3 with snippet", m)
+	}
+
+	// Enter on the second result's snippet opens Ideas at that line.
+	if err := tg.g.ForceLayoutAndRedraw(); err != nil {
+		t.Fatal(err)
+	}
+	ns := pr.NavState()
+	placed := false
+	for i, sl := range ns.Lines {
+		if sl.UUID == "b" && sl.LineNum == 3 {
+			ns.CursorLine = i
+			placed = true
+		}
+	}
+	if !placed {
+		t.Fatal("the snippet line should carry the referencing line number")
+	}
+	pr.SelectedCardIdx = 1
+	if err := tg.gui.helpers.PreviewNav().PreviewEnter(); err != nil {
+		t.Fatal(err)
+	}
+	cl := tg.gui.contexts.CardList
+	if tg.gui.contexts.ActivePreviewKey != "cardList" || len(cl.Cards) != 1 || cl.Cards[0].UUID != "b" {
+		t.Fatalf("Enter should open the referencing note, got %q %+v", tg.gui.contexts.ActivePreviewKey, cl.Cards)
+	}
+}
+
+func TestBacklinks_RequeriesOnHistoryRestore(t *testing.T) {
+	mock := backlinksMock(t)
+	tg := newTestGui(t, mock)
+	defer tg.Close()
+
+	if err := tg.gui.helpers.PreviewNav().OpenNoteByUUID("plan"); err != nil {
+		t.Fatal(err)
+	}
+	if err := tg.gui.helpers.Backlinks().ShowBacklinks(); err != nil {
+		t.Fatal(err)
+	}
+	if err := tg.gui.helpers.Navigator().Back(); err != nil {
+		t.Fatal(err)
+	}
+	if err := tg.gui.helpers.Navigator().Forward(); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(tg.gui.contexts.PickResults.Results); got != 2 {
+		t.Errorf("restored backlinks = %d results, want 2", got)
+	}
+	if tg.gui.contexts.PickResults.Source.Requery == nil {
+		t.Error("restored backlinks should stay filterable")
+	}
+}
@@ -43,10 +43,12 @@ func (gui *Gui) statusBarHints() []statusBarEntry {
 	kind := ctx.GetKind()
 	isPopup := kind == types.PERSISTENT_POPUP || kind == types.TEMPORARY_POPUP
 
-	opts := types.KeybindingsOpts{}
 	var entries []statusBarEntry
 
-	for _, b := range ctx.GetKeybindings(opts) {
+	for _, b := range gui.contextBindings(ctx) {
+		if bindingKeyDisplay(b) == "" {
+			continue // unbound by the user
+		}
 		if isPopup {
 			if b.Description == "" {
 				continue
@@ -70,8 +72,8 @@ func (gui *Gui) statusBarHints() []statusBarEntry {
 	if !isPopup {
 		globalCtx := gui.contextMgr.ContextByKey("global")
 		if globalCtx != nil && string(ctxKey) != "global" {
-			for _, b := range globalCtx.GetKeybindings(opts) {
-				if !b.DisplayOnScreen {
+			for _, b := range gui.contextBindings(globalCtx) {
+				if !b.DisplayOnScreen || bindingKeyDisplay(b) == "" {
 					continue
 				}
 				label := b.StatusBarLabel
@@ -134,12 +136,11 @@ func (gui *Gui) helpDialogItems() []types.MenuItem {
 	ctxKey := gui.contextMgr.Current()
 	ctx := gui.contextMgr.ContextByKey(ctxKey)
 
-	opts := types.KeybindingsOpts{}
 	var contextGroups []categoryGroup
 	var navGroup categoryGroup
 
 	if ctx != nil {
-		contextGroups, navGroup = collectBindingGroups(ctx.GetKeybindings(opts))
+		contextGroups, navGroup = collectBindingGroups(gui.contextBindings(ctx))
 	}
 
 	var items []types.MenuItem
@@ -165,7 +166,7 @@ func (gui *Gui) helpDialogItems() []types.MenuItem {
 
 	globalCtx := gui.contextMgr.ContextByKey("global")
 	if globalCtx != nil {
-		globalGroups, _ := collectBindingGroups(globalCtx.GetKeybindings(opts))
+		globalGroups, _ := collectBindingGroups(gui.contextBindings(globalCtx))
 		for _, g := range globalGroups {
 			items = append(items, types.MenuItem{Label: g.name, IsHeader: true})
 			items = append(items, g.items...)
@@ -0,0 +1,56 @@
+package gui
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestBreadcrumb_ShowsAncestorsAndOpensThemInCompose(t *testing.T) {
+	mock := parentTreeMock()
+	tg := newTestGui(t, mock)
+	defer tg.Close()
+
+	// Start cold so the chain has to be fetched link by link.
+	tg.gui.helpers.TitleCache().Clear()
+	if err := tg.gui.helpers.PreviewNav().OpenNoteByUUID("alpha-1"); err != nil {
+		t.Fatal(err)
+	}
+	tg.g.ForceLayoutAndRedraw()
+
+	v := tg.gui.GetView(PreviewView)
+	if want := []string{"Root", "Alpha", "Alpha One"}; !slices.Equal(v.Tabs, want) {
+		t.Fatalf("preview tabs = %q, want %q", v.Tabs, want)
+	}
+	if v.TabIndex != 2 {
+		t.Errorf("TabIndex = %d, want the note itself highlighted", v.TabIndex)
+	}
+
+	if err := tg.gui.helpers.Breadcrumb().ShowAncestorsMenu(); err != nil {
+		t.Fatal(err)
+	}
+	d := tg.gui.state.Dialog
+	if d == nil || d.Type != "menu" || len(d.MenuItems) != 2 {
+		t.Fatalf("dialog = %+v, want a two-item ancestors menu", d)
+	}
+	if d.MenuItems[0].Label != "Alpha" || d.MenuItems[0].Key != "1" || d.MenuItems[1].Label != "Root" {
+		t.Errorf("menu = %+v, want the nearest parent first", d.MenuItems)
+	}
+	tg.gui.state.Dialog = nil
+
+	mock.Calls = nil
+	if err := tg.gui.helpers.Breadcrumb().ClickCrumb(0); err != nil {
+		t.Fatal(err)
+	}
+	if tg.gui.contexts.ActivePreviewKey != "compose" || tg.gui.contexts.Compose.Parent.UUID != "root" {
+		t.Errorf("active preview = %s, parent = %+v; want Root composed",
+			tg.gui.contexts.ActivePreviewKey, tg.gui.contexts.Compose.Parent)
+	}
+	if !slices.ContainsFunc(mock.Calls, func(c []string) bool { return len(c) > 1 && c[0] == "compose" && c[1] == "root" }) {
+		t.Errorf("expected a compose of root; calls=%v", mock.Calls)
+	}
+
+	tg.g.ForceLayoutAndRedraw()
+	if v.Tabs != nil {
+		t.Errorf("compose preview should drop the breadcrumb tabs, got %q", v.Tabs)
+	}
+}
@@ -41,8 +41,8 @@ func (gui *Gui) createCalendarViews(g *gocui.Gui, maxX, maxY int) error {
 	setRoundedCorners(iv)
 
 	if s.Focus == calFocusInput {
-		iv.FrameColor = gocui.ColorGreen
-		iv.TitleColor = gocui.ColorGreen
+		iv.FrameColor = theme.FrameActive
+		iv.TitleColor = theme.FrameActive
 	} else {
 		iv.FrameColor = gocui.ColorDefault
 		iv.TitleColor = gocui.ColorDefault
@@ -51,7 +51,7 @@ func (gui *Gui) createCalendarViews(g *gocui.Gui, maxX, maxY int) error {
 	// Show dimmed placeholder when empty and not focused
 	if s.Focus != calFocusInput && iv.TextArea.GetContent() == "" {
 		iv.Clear()
-		fmt.Fprintf(iv, "%s/ jump to date%s", AnsiDim, AnsiReset)
+		fmt.Fprintf(iv, "%s/ jump to date%s", theme.Muted, AnsiReset)
 	}
 
 	g.SetViewOnTop(CalendarInputView)
@@ -71,8 +71,8 @@ func (gui *Gui) createCalendarViews(g *gocui.Gui, maxX, maxY int) error {
 	setRoundedCorners(gv)
 
 	if s.Focus == calFocusGrid {
-		gv.FrameColor = gocui.ColorGreen
-		gv.TitleColor = gocui.ColorGreen
+		gv.FrameColor = theme.FrameActive
+		gv.TitleColor = theme.FrameActive
 	} else {
 		gv.FrameColor = gocui.ColorDefault
 		gv.TitleColor = gocui.ColorDefault
@@ -102,8 +102,8 @@ func (gui *Gui) createCalendarViews(g *gocui.Gui, maxX, maxY int) error {
 	setRoundedCorners(nv)
 
 	if s.Focus == calFocusNotes {
-		nv.FrameColor = gocui.ColorGreen
-		nv.TitleColor = gocui.ColorGreen
+		nv.FrameColor = theme.FrameActive
+		nv.TitleColor = theme.FrameActive
 	} else {
 		nv.FrameColor = gocui.ColorDefault
 		nv.TitleColor = gocui.ColorDefault
@@ -150,7 +150,7 @@ func (gui *Gui) renderCalendarGrid(v *gocui.View) {
 	fmt.Fprintf(v, "%s Su Mo Tu We Th Fr Sa\n", leftPad)
 
 	// Separator
-	fmt.Fprintf(v, "%s %s%s%s\n", leftPad, AnsiDim, strings.Repeat("─", gridWidth-1), AnsiReset)
+	fmt.Fprintf(v, "%s %s%s%s\n", leftPad, theme.Muted, strings.Repeat("─", gridWidth-1), AnsiReset)
 
 	// First day of month
 	first := time.Date(s.Year, time.Month(s.Month), 1, 0, 0, 0, 0, time.Local)
@@ -176,18 +176,18 @@ func (gui *Gui) renderCalendarGrid(v *gocui.View) {
 			cellIdx := row*7 + col
 			if cellIdx < startWeekday {
 				d := prevMonthDays - startWeekday + cellIdx + 1
-				fmt.Fprintf(&line, "%s%3d%s", AnsiDim, d, AnsiReset)
+				fmt.Fprintf(&line, "%s%3d%s", theme.Muted, d, AnsiReset)
 			} else if day <= daysInMonth {
 				if day == s.SelectedDay {
-					fmt.Fprintf(&line, "%s%3d%s", AnsiBlueBgWhite, day, AnsiReset)
+					fmt.Fprintf(&line, "%s%3d%s", theme.Selected, day, AnsiReset)
 				} else if day == today && s.Month == todayMonth && s.Year == todayYear {
-					fmt.Fprintf(&line, "%s%3d%s", AnsiBoldWhite, day, AnsiReset)
+					fmt.Fprintf(&line, "%s%3d%s", theme.Title, day, AnsiReset)
 				} else {
 					fmt.Fprintf(&line, "%3d", day)
 				}
 				day++
 			} else {
-				fmt.Fprintf(&line, "%s%3d%s", AnsiDim, nextMonthDay, AnsiReset)
+				fmt.Fprintf(&line, "%s%3d%s", theme.Muted, nextMonthDay, AnsiReset)
 				nextMonthDay++
 			}
 		}
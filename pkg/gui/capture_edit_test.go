@@ -299,9 +299,13 @@ func TestSubmitCapture_MtimeConflict_KeepsPopupOpen(t *testing.T) {
 		t.Fatalf("SubmitCapture returned error: %v (it should show an error in-GUI and return nil)", err)
 	}
 
-	// Popup should remain open so the user can recover their edits.
-	if tg.gui.contextMgr.Current() != "capture" {
-		t.Errorf("popup should stay open on conflict, current context: %v", tg.gui.contextMgr.Current())
+	// The edits conflict with the external change, so the resolution
+	// popup opens over the capture popup, which keeps the user's edits.
+	if tg.gui.contextMgr.Current() != "mergeConflict" {
+		t.Errorf("conflict should open the resolution popup, current context: %v", tg.gui.contextMgr.Current())
+	}
+	if !tg.gui.contextMgr.Contains("capture") {
+		t.Error("capture popup should stay open under the resolution popup")
 	}
 	if tg.gui.contexts.Capture.EditingPath == "" {
 		t.Error("EditingPath should remain set on conflict")
@@ -1,5 +1,7 @@
 package gui
 
+// ANSI sequences the built-in themes are assembled from. Rendering code
+// should use the semantic roles on theme (theme.go) rather than these.
 const (
 	AnsiReset       = "\x1b[0m"
 	AnsiDim         = "\x1b[2m"
@@ -8,8 +10,9 @@ const (
 	AnsiCyan        = "\x1b[36m"
 	AnsiBlueBgWhite = "\x1b[44;37m"
 	AnsiDimBg       = "\x1b[48;5;238m" // subtle dark gray background
-	AnsiBgReset     = "\x1b[49m"       // reset background only
 	AnsiBoldWhite   = "\x1b[1;37m"
+	AnsiBold        = "\x1b[1m"
+	AnsiNoBold      = "\x1b[22m"      // ends bold without resetting colors
 	AnsiGreen1      = "\x1b[38;5;22m" // dark green (1 note)
 	AnsiGreen2      = "\x1b[38;5;28m" // medium green (2 notes)
 	AnsiGreen3      = "\x1b[38;5;34m" // bright green (3+ notes)
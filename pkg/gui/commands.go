@@ -101,6 +101,7 @@ var keyNames = map[gocui.Key]string{
 	gocui.KeyArrowDown:  "down",
 	gocui.KeyArrowLeft:  "left",
 	gocui.KeyArrowRight: "right",
+	gocui.KeyF5:         "f5",
 }
 
 // ctrlKeyNames maps ctrl+letter gocui keys to display strings.
@@ -95,8 +95,8 @@ func (gui *Gui) renderSuggestionView(g *gocui.Gui, viewName string, state *types
 	}
 
 	v.Frame = true
-	v.FrameColor = gocui.ColorYellow
-	v.TitleColor = gocui.ColorYellow
+	v.FrameColor = theme.FrameAlert
+	v.TitleColor = theme.FrameAlert
 	setRoundedCorners(v)
 
 	v.Clear()
@@ -124,7 +124,7 @@ func (gui *Gui) renderSuggestionView(g *gocui.Gui, viewName string, state *types
 			label := " " + item.Label
 			visualLen := len([]rune(label))
 			pad := strings.Repeat(" ", max(innerWidth-visualLen, 0))
-			fmt.Fprintf(v, "%s%s%s%s\n", AnsiDim, label, pad, AnsiReset)
+			fmt.Fprintf(v, "%s%s%s%s\n", theme.Muted, label, pad, AnsiReset)
 			continue
 		}
 
@@ -137,13 +137,13 @@ func (gui *Gui) renderSuggestionView(g *gocui.Gui, viewName string, state *types
 		labelRunes := len([]rune(label))
 		pad := max(detailCol-labelRunes, 1)
 
-		line := label + strings.Repeat(" ", pad) + AnsiDim + detail + AnsiReset
+		line := label + strings.Repeat(" ", pad) + theme.Muted + detail + AnsiReset
 		// Pad to full width for highlight (account for ANSI not taking visual space)
 		visualLen := labelRunes + pad + len([]rune(detail))
 		line = line + strings.Repeat(" ", max(innerWidth-visualLen, 0))
 
 		if selected {
-			fmt.Fprintf(v, "%s%s%s\n", AnsiBlueBgWhite, line, AnsiReset)
+			fmt.Fprintf(v, "%s%s%s\n", theme.Selected, line, AnsiReset)
 		} else {
 			fmt.Fprintln(v, line)
 		}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -75,8 +76,9 @@ func newComposeFixture(t *testing.T) composeFixture {
 
 	mock := testutil.NewMockExecutor().
 		WithNotes(
-			models.Note{UUID: "child-a-uuid", Title: "Child A Title", Path: childAPath, Created: time.Now()},
-			models.Note{UUID: "child-b-uuid", Title: "Child B Title", Path: childBPath, Created: time.Now()},
+			models.Note{UUID: "parent-1", Title: "Daily Journal", Path: filepath.Join(dir, "parent.md"), Created: time.Now()},
+			models.Note{UUID: "child-a-uuid", Title: "Child A Title", Path: childAPath, Parent: "parent-1", Created: time.Now()},
+			models.Note{UUID: "child-b-uuid", Title: "Child B Title", Path: childBPath, Parent: "parent-1", Created: time.Now()},
 		).
 		WithParents(
 			models.ParentBookmark{Name: "journal", UUID: "parent-1", Title: "Daily Journal"},
@@ -228,3 +230,180 @@ func TestReloadActivePreview_Compose_ReRunsParentCompose(t *testing.T) {
 		t.Errorf("ReloadActivePreview did not re-run compose (before=%d after=%d)", before, after)
 	}
 }
+
+// editDocumentWith points $EDITOR at a command that replaces the scratch
+// document with edited, then runs Edit Whole Document.
+func editDocumentWith(t *testing.T, tg *testGui, edited string) {
+	t.Helper()
+	src := filepath.Join(t.TempDir(), "edited.md")
+	if err := os.WriteFile(src, []byte(edited), 0644); err != nil {
+		t.Fatalf("write edited doc: %v", err)
+	}
+	tg.gui.config.Editor = "cp " + src
+	if err := invokeComposeBinding(t, tg, "compose.edit_document"); err != nil {
+		t.Fatalf("invoke compose.edit_document: %v", err)
+	}
+}
+
+func TestComposeEditDocument_AppliesEditsNewChildrenAndOrder(t *testing.T) {
+	fx := newComposeFixture(t)
+	tg := newTestGuiWithOpts(t, fx.mock, testGuiOpts{OpenRef: "journal"})
+	defer tg.Close()
+
+	editDocumentWith(t, tg, "<!-- child:child-b-uuid Child B Title -->\n\n"+
+		"# Child B Title\n\nContent line B1\n- Task B #todo\n\n"+
+		"<!-- child:child-a-uuid Child A Title -->\n\n"+
+		"# Child A Title\n\nContent line A1 (edited)\n- Task A #todo\nContent line A3\n\n"+
+		"<!-- child:new -->\n\nA new child\n")
+
+	dialog := tg.gui.state.Dialog
+	if dialog == nil || dialog.OnConfirm == nil {
+		t.Fatal("expected a confirmation dialog summarising the edits")
+	}
+	for _, want := range []string{"Update: Child A Title", "Create 1 new child note", "Reorder children"} {
+		if !strings.Contains(dialog.Message, want) {
+			t.Errorf("summary %q missing %q", dialog.Message, want)
+		}
+	}
+	if err := dialog.OnConfirm(); err != nil {
+		t.Fatalf("OnConfirm: %v", err)
+	}
+
+	data, err := os.ReadFile(fx.childAPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "---\nuuid: child-a-uuid\n---\n# Child A Title\n\nContent line A1 (edited)\n- Task A #todo\nContent line A3\n"; string(data) != want {
+		t.Errorf("child A file = %q, want %q", data, want)
+	}
+
+	var logged, ordered []string
+	for _, args := range fx.mock.Calls {
+		switch {
+		case len(args) > 0 && args[0] == "log":
+			logged = args
+		case len(args) > 4 && args[0] == "note" && args[3] == "--order":
+			ordered = append(ordered, args[2]+"="+args[4])
+		}
+	}
+	if want := []string{"log", "A new child", "--parent", "parent-1"}; strings.Join(logged, " ") != strings.Join(want, " ") {
+		t.Errorf("log call = %q, want %q", logged, want)
+	}
+	if want := "child-b-uuid=1 child-a-uuid=2"; strings.Join(ordered, " ") != want {
+		t.Errorf("order calls = %q, want %q", ordered, want)
+	}
+}
+
+func TestComposeEditDocument_DeletedSectionTrashesChild(t *testing.T) {
+	fx := newComposeFixture(t)
+	tg := newTestGuiWithOpts(t, fx.mock, testGuiOpts{OpenRef: "journal"})
+	defer tg.Close()
+
+	editDocumentWith(t, tg, "<!-- child:child-a-uuid Child A Title -->\n\n"+
+		"# Child A Title\n\nContent line A1\n- Task A #todo\nContent line A3\n")
+
+	dialog := tg.gui.state.Dialog
+	if dialog == nil || !strings.Contains(dialog.Message, "Child B Title") {
+		t.Fatalf("expected a delete summary naming Child B, got %+v", dialog)
+	}
+	_ = dialog.OnConfirm()
+
+	deleted := false
+	for _, args := range fx.mock.Calls {
+		if len(args) > 2 && args[0] == "note" && args[1] == "delete" && args[2] == "child-b-uuid" {
+			deleted = true
+		}
+	}
+	if !deleted {
+		t.Error("expected child B to be deleted")
+	}
+}
+
+func TestComposeEditDocument_NoChangesSkipsConfirm(t *testing.T) {
+	fx := newComposeFixture(t)
+	tg := newTestGuiWithOpts(t, fx.mock, testGuiOpts{OpenRef: "journal"})
+	defer tg.Close()
+
+	tg.gui.config.Editor = "true"
+	if err := invokeComposeBinding(t, tg, "compose.edit_document"); err != nil {
+		t.Fatalf("invoke compose.edit_document: %v", err)
+	}
+	if d := tg.gui.state.Dialog; d != nil && d.Active {
+		t.Errorf("unexpected dialog %q after an unchanged edit", d.Title)
+	}
+}
+
+func TestComposeEditDocument_GrandchildIsReadOnly(t *testing.T) {
+	fx := newComposeFixture(t)
+	// Make child B a grandchild: compose still lists it, but it belongs
+	// to child A.
+	fx.mock.WithNotes(
+		models.Note{UUID: "parent-1", Title: "Daily Journal", Path: filepath.Join(fx.dir, "parent.md")},
+		models.Note{UUID: "child-a-uuid", Title: "Child A Title", Path: fx.childAPath, Parent: "parent-1"},
+		models.Note{UUID: "child-b-uuid", Title: "Child B Title", Path: fx.childBPath, Parent: "child-a-uuid"},
+	)
+	tg := newTestGuiWithOpts(t, fx.mock, testGuiOpts{OpenRef: "journal"})
+	defer tg.Close()
+
+	// Moving child B ahead of child A isn't a reorder among siblings.
+	editDocumentWith(t, tg, "<!-- readonly:child-b-uuid Child B Title -->\n\n"+
+		"# Child B Title\n\nContent line B1\n- Task B #todo\n\n"+
+		"<!-- child:child-a-uuid Child A Title -->\n\n"+
+		"# Child A Title\n\nContent line A1\n- Task A #todo\nContent line A3\n")
+	if d := tg.gui.state.Dialog; d != nil && d.Active {
+		t.Fatalf("unexpected dialog %q: moving a grandchild should change nothing", d.Title)
+	}
+
+	editDocumentWith(t, tg, "<!-- child:child-a-uuid Child A Title -->\n\n"+
+		"# Child A Title\n\nContent line A1\n- Task A #todo\nContent line A3\n")
+	if d := tg.gui.state.Dialog; d != nil && d.Active {
+		t.Fatalf("unexpected dialog %q: a grandchild can't be deleted here", d.Title)
+	}
+	if status := tg.gui.views.Status.Buffer(); !strings.Contains(status, "can't be deleted here") {
+		t.Errorf("status = %q, want the delete rejected", status)
+	}
+	for _, args := range fx.mock.Calls {
+		if len(args) > 1 && args[0] == "note" && (args[1] == "delete" || (len(args) > 3 && args[3] == "--order")) {
+			t.Errorf("unexpected call %q", args)
+		}
+	}
+}
+
+func TestComposeEditDocument_UndoTrashesCreatedChildren(t *testing.T) {
+	fx := newComposeFixture(t)
+	newPath := writeNote(t, fx.dir, "new-child.md", "---\nuuid: new-uuid\nparent: parent-1\n---\nA new child\n")
+	fx.mock.WithCreatedNote(models.Note{UUID: "new-uuid", Title: "A new child", Path: newPath, Parent: "parent-1"})
+	tg := newTestGuiWithOpts(t, fx.mock, testGuiOpts{OpenRef: "journal"})
+	defer tg.Close()
+
+	doc := "<!-- child:child-a-uuid Child A Title -->\n\n" +
+		"# Child A Title\n\nContent line A1\n- Task A #todo\nContent line A3\n\n" +
+		"<!-- child:child-b-uuid Child B Title -->\n\n" +
+		"# Child B Title\n\nContent line B1\n- Task B #todo\n\n" +
+		"<!-- child:new -->\n\nA new child\n"
+	editDocumentWith(t, tg, doc)
+	dialog := tg.gui.state.Dialog
+	if dialog == nil || dialog.OnConfirm == nil {
+		t.Fatal("expected a confirmation dialog")
+	}
+	if err := dialog.OnConfirm(); err != nil {
+		t.Fatal(err)
+	}
+
+	fx.mock.Calls = nil
+	if err := tg.gui.helpers.Undo().Undo(); err != nil {
+		t.Fatal(err)
+	}
+	if !hasCall(fx.mock.Calls, "note", "delete", "new-uuid", "-f") {
+		t.Errorf("undo should delete the created note; calls=%v", fx.mock.Calls)
+	}
+
+	// The mock doesn't touch the filesystem; remove the file as ruin would.
+	os.Remove(newPath)
+	if err := tg.gui.helpers.Undo().Redo(); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := os.ReadFile(newPath); err != nil || !strings.Contains(string(data), "uuid: new-uuid") {
+		t.Errorf("redo should restore the created note from the trash, got %q, %v", data, err)
+	}
+}
@@ -42,6 +42,7 @@ type CaptureContext struct {
 	EditingUUID      string    // UUID of the note being edited; used to apply frontmatter mutations (parent, etc.) on save
 	EditingTitle     string    // title of the note being edited, shown as popup title
 	EditingMtime     time.Time // modification time of the file when the popup opened; used to detect external edits
+	EditingBase      string    // body as loaded when the popup opened; the common ancestor when merging external edits
 	ResolveState     LinkResolveState
 	ResolveResult    *LinkResolveResult
 	ResolveDone      chan struct{}
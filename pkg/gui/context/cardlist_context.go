@@ -8,9 +8,10 @@ import (
 // CardListSource holds metadata about the query that populated a card list,
 // enabling re-query for filtering.
 type CardListSource struct {
-	Query    string                                         // for display/seed in filter dialog
-	Requery  func(filterText string) ([]models.Note, error) // combines filter with original query
-	Triggers func() []types.CompletionTrigger               // completion triggers for filter dialog
+	Query      string                                         // for display/seed in filter dialog
+	Requery    func(filterText string) ([]models.Note, error) // combines filter with original query
+	Triggers   func() []types.CompletionTrigger               // completion triggers for filter dialog
+	Descriptor SourceDescriptor                               // plain-data form of the query, for snapshots
 }
 
 // CardListState holds state specific to the card-list preview mode.
@@ -18,6 +19,9 @@ type CardListState struct {
 	Cards            []models.Note
 	TemporarilyMoved map[int]bool
 
+	// Selection holds the cards marked for a bulk action (space / V).
+	Selection MultiSelect
+
 	FilterText      string
 	Source          CardListSource
 	UnfilteredCount int
@@ -31,6 +35,12 @@ type CardListState struct {
 	ComposedSourceMaps [][]models.SourceMapEntry
 }
 
+// SelectedNotes returns the multi-selected cards in list order, or nil
+// when no selection is active.
+func (s *CardListState) SelectedNotes(cursor int) []models.Note {
+	return selectedNotes(&s.Selection, s.Cards, cursor)
+}
+
 func (s *CardListState) FilterActive() bool {
 	return s.FilterText != ""
 }
@@ -108,19 +118,21 @@ func (self *CardListContext) RequeryAndApply(filterText string) error {
 }
 
 // cardListSnapshot is the CardListContext-specific snapshot. Carries enough
-// view params to re-run the query (Source with a Requery closure) and enough
-// view state to reconstruct the exact visual position on restore.
+// view params to re-run the query (the Source descriptor) and enough view
+// state to reconstruct the exact visual position on restore. The frozen
+// cards stay in memory only; a snapshot read back from the session file
+// re-queries.
 type cardListSnapshot struct {
-	Title              string
-	Source             CardListSource
-	FilterText         string
-	FrozenCards        []models.Note
-	SelectedCardIdx    int
-	CursorLine         int
-	ScrollOffset       int
-	Display            PreviewDisplayState
-	ComposedCards      []*models.Note
-	ComposedSourceMaps [][]models.SourceMapEntry
+	Title              string                    `json:"title"`
+	Source             SourceDescriptor          `json:"source"`
+	FilterText         string                    `json:"filter_text,omitempty"`
+	FrozenCards        []models.Note             `json:"-"`
+	SelectedCardIdx    int                       `json:"selected_card"`
+	CursorLine         int                       `json:"cursor_line"`
+	ScrollOffset       int                       `json:"scroll_offset"`
+	Display            PreviewDisplayState       `json:"display"`
+	ComposedCards      []*models.Note            `json:"-"`
+	ComposedSourceMaps [][]models.SourceMapEntry `json:"-"`
 }
 
 // CaptureSnapshot captures the CardList's current state.
@@ -128,7 +140,7 @@ func (self *CardListContext) CaptureSnapshot() types.Snapshot {
 	ns := self.NavState()
 	return &cardListSnapshot{
 		Title:              self.Title(),
-		Source:             self.Source,
+		Source:             self.Source.Descriptor,
 		FilterText:         self.FilterText,
 		FrozenCards:        append([]models.Note(nil), self.Cards...),
 		SelectedCardIdx:    self.SelectedCardIdx,
@@ -148,14 +160,18 @@ func (self *CardListContext) RestoreSnapshot(s types.Snapshot) error {
 		return nil
 	}
 	self.SetTitle(snap.Title)
-	self.Source = snap.Source
+	self.Source = CardListSource{Query: snap.Source.Query, Descriptor: snap.Source}
+	if self.resolver != nil && snap.Source.Kind != "" {
+		self.Source = self.resolver.CardListSource(snap.Source)
+	}
 	self.FilterText = snap.FilterText
+	self.Selection.Clear()
 	*self.DisplayState() = snap.Display
 	self.ComposedCards = append([]*models.Note(nil), snap.ComposedCards...)
 	self.ComposedSourceMaps = append([][]models.SourceMapEntry(nil), snap.ComposedSourceMaps...)
 
-	if snap.Source.Requery != nil {
-		notes, err := snap.Source.Requery(snap.FilterText)
+	if self.Source.Requery != nil {
+		notes, err := self.Source.Requery(snap.FilterText)
 		if err == nil {
 			self.Cards = notes
 		} else {
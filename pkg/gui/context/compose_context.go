@@ -44,18 +44,17 @@ func NewComposeContext() *ComposeContext {
 
 func (self *ComposeContext) CardCount() int { return 1 }
 
-// composeSnapshot carries view params (Parent + Requery closure) and view
+// composeSnapshot carries view params (the Parent to re-compose) and view
 // state for Compose restoration.
 type composeSnapshot struct {
-	Title           string
-	Parent          models.ParentBookmark
-	Requery         ComposeRequery
-	FrozenNote      models.Note
-	FrozenSourceMap []models.SourceMapEntry
-	SelectedCardIdx int
-	CursorLine      int
-	ScrollOffset    int
-	Display         PreviewDisplayState
+	Title           string                  `json:"title"`
+	Parent          models.ParentBookmark   `json:"parent"`
+	FrozenNote      models.Note             `json:"-"`
+	FrozenSourceMap []models.SourceMapEntry `json:"-"`
+	SelectedCardIdx int                     `json:"selected_card"`
+	CursorLine      int                     `json:"cursor_line"`
+	ScrollOffset    int                     `json:"scroll_offset"`
+	Display         PreviewDisplayState     `json:"display"`
 }
 
 func (self *ComposeContext) CaptureSnapshot() types.Snapshot {
@@ -63,7 +62,6 @@ func (self *ComposeContext) CaptureSnapshot() types.Snapshot {
 	return &composeSnapshot{
 		Title:           self.Title(),
 		Parent:          self.Parent,
-		Requery:         self.Requery,
 		FrozenNote:      self.Note,
 		FrozenSourceMap: append([]models.SourceMapEntry(nil), self.SourceMap...),
 		SelectedCardIdx: self.SelectedCardIdx,
@@ -80,11 +78,14 @@ func (self *ComposeContext) RestoreSnapshot(s types.Snapshot) error {
 	}
 	self.SetTitle(snap.Title)
 	self.Parent = snap.Parent
-	self.Requery = snap.Requery
+	self.Requery = nil
+	if self.resolver != nil && snap.Parent != (models.ParentBookmark{}) {
+		self.Requery = self.resolver.ComposeRequery(snap.Parent)
+	}
 	*self.DisplayState() = snap.Display
 
-	if snap.Requery != nil {
-		note, sm, err := snap.Requery()
+	if self.Requery != nil {
+		note, sm, err := self.Requery()
 		if err == nil {
 			self.Note = note
 			self.SourceMap = sm
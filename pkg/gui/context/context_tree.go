@@ -21,7 +21,13 @@ type ContextTree struct {
 	PickDialog        *PickResultsContext
 	DatePreview       *DatePreviewContext
 	ScratchpadBrowser *ScratchpadBrowserContext
+	TrashBrowser      *TrashBrowserContext
+	ParentTree        *ParentTreeContext
+	NoteHistory       *NoteHistoryContext
+	MergeConflict     *MergeConflictContext
 	NotesHome         *NotesHomeContext
+	Todos             *TodosContext
+	PreviewFind       *PreviewFindContext
 	ActivePreviewKey  types.ContextKey // "cardList", "pickResults", "compose", or "datePreview"
 }
 
@@ -91,9 +97,27 @@ func (self *ContextTree) All() []types.Context {
 	if self.ScratchpadBrowser != nil {
 		all = append(all, self.ScratchpadBrowser)
 	}
+	if self.TrashBrowser != nil {
+		all = append(all, self.TrashBrowser)
+	}
+	if self.ParentTree != nil {
+		all = append(all, self.ParentTree)
+	}
+	if self.NoteHistory != nil {
+		all = append(all, self.NoteHistory)
+	}
+	if self.MergeConflict != nil {
+		all = append(all, self.MergeConflict)
+	}
 	if self.NotesHome != nil {
 		all = append(all, self.NotesHome)
 	}
+	if self.Todos != nil {
+		all = append(all, self.Todos)
+	}
+	if self.PreviewFind != nil {
+		all = append(all, self.PreviewFind)
+	}
 	return all
 }
 
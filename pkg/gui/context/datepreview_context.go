@@ -78,19 +78,18 @@ func (s *DatePreviewState) LocalCardIdx(globalIdx int) int {
 	return globalIdx - s.SectionRanges[sec][0]
 }
 
-// datePreviewSnapshot carries view params (TargetDate + Requery closure) and
-// view state for DatePreview restoration.
+// datePreviewSnapshot carries view params (TargetDate, a date or
+// "start..end" range) and view state for DatePreview restoration.
 type datePreviewSnapshot struct {
-	Title           string
-	TargetDate      string
-	Requery         DatePreviewRequery
-	FrozenTagPicks  []models.PickResult
-	FrozenTodoPicks []models.PickResult
-	FrozenNotes     []models.Note
-	SelectedCardIdx int
-	CursorLine      int
-	ScrollOffset    int
-	Display         PreviewDisplayState
+	Title           string              `json:"title"`
+	TargetDate      string              `json:"target_date"`
+	FrozenTagPicks  []models.PickResult `json:"-"`
+	FrozenTodoPicks []models.PickResult `json:"-"`
+	FrozenNotes     []models.Note       `json:"-"`
+	SelectedCardIdx int                 `json:"selected_card"`
+	CursorLine      int                 `json:"cursor_line"`
+	ScrollOffset    int                 `json:"scroll_offset"`
+	Display         PreviewDisplayState `json:"display"`
 }
 
 func (self *DatePreviewContext) CaptureSnapshot() types.Snapshot {
@@ -98,7 +97,6 @@ func (self *DatePreviewContext) CaptureSnapshot() types.Snapshot {
 	return &datePreviewSnapshot{
 		Title:           self.Title(),
 		TargetDate:      self.TargetDate,
-		Requery:         self.Requery,
 		FrozenTagPicks:  append([]models.PickResult(nil), self.TagPicks...),
 		FrozenTodoPicks: append([]models.PickResult(nil), self.TodoPicks...),
 		FrozenNotes:     append([]models.Note(nil), self.Notes...),
@@ -116,11 +114,14 @@ func (self *DatePreviewContext) RestoreSnapshot(s types.Snapshot) error {
 	}
 	self.SetTitle(snap.Title)
 	self.TargetDate = snap.TargetDate
-	self.Requery = snap.Requery
+	self.Requery = nil
+	if self.resolver != nil && snap.TargetDate != "" {
+		self.Requery = self.resolver.DatePreviewRequery(snap.TargetDate)
+	}
 	*self.DisplayState() = snap.Display
 
-	if snap.Requery != nil {
-		tag, todo, notes, err := snap.Requery()
+	if self.Requery != nil {
+		tag, todo, notes, err := self.Requery()
 		if err == nil {
 			self.TagPicks = tag
 			self.TodoPicks = todo
@@ -0,0 +1,46 @@
+package context
+
+import (
+	"time"
+
+	"github.com/donnellyk/lazyruin/pkg/gui/types"
+	"github.com/donnellyk/lazyruin/pkg/merge"
+)
+
+// MergeConflictContext holds the conflict resolution popup opened over an
+// edit whose note changed on disk and could not be merged cleanly. Sides
+// records the choice for each conflict, in order.
+type MergeConflictContext struct {
+	BaseContext
+	Path        string
+	NoteTitle   string
+	Mtime       time.Time // mtime of the on-disk version that was merged
+	Result      merge.Result
+	Sides       []merge.Side
+	SelectedIdx int // index of the selected conflict
+}
+
+func NewMergeConflictContext() *MergeConflictContext {
+	return &MergeConflictContext{
+		BaseContext: NewBaseContext(NewBaseContextOpts{
+			Kind:      types.TEMPORARY_POPUP,
+			Key:       "mergeConflict",
+			ViewName:  "mergeConflict",
+			Focusable: true,
+			Title:     "Resolve Conflicts",
+		}),
+	}
+}
+
+// Unresolved returns the number of conflicts still without a choice.
+func (self *MergeConflictContext) Unresolved() int {
+	n := 0
+	for _, s := range self.Sides {
+		if s == merge.Unresolved {
+			n++
+		}
+	}
+	return n
+}
+
+var _ types.Context = &MergeConflictContext{}
@@ -0,0 +1,114 @@
+package context
+
+import "github.com/donnellyk/lazyruin/pkg/models"
+
+// MultiSelect tracks the notes marked for a bulk action in a list of
+// notes or cards. Marks are keyed by UUID so they survive refreshes that
+// reload or reorder the list. A range anchored with V follows the cursor
+// until V is pressed again, which commits it to the marks.
+//
+// The zero value is an empty selection.
+type MultiSelect struct {
+	marked      map[string]bool
+	rangeActive bool
+	rangeAnchor int
+}
+
+// Toggle marks or unmarks a single note.
+func (self *MultiSelect) Toggle(uuid string) {
+	if self.marked == nil {
+		self.marked = map[string]bool{}
+	}
+	if self.marked[uuid] {
+		delete(self.marked, uuid)
+	} else {
+		self.marked[uuid] = true
+	}
+}
+
+// ToggleRange anchors a range at cursor, or, when a range is already
+// active, marks every item between the anchor and cursor and ends it.
+func (self *MultiSelect) ToggleRange(uuids []string, cursor int) {
+	if !self.rangeActive {
+		self.rangeActive = true
+		self.rangeAnchor = cursor
+		return
+	}
+	lo, hi := self.bounds(len(uuids), cursor)
+	if self.marked == nil {
+		self.marked = map[string]bool{}
+	}
+	for i := lo; i <= hi; i++ {
+		self.marked[uuids[i]] = true
+	}
+	self.rangeActive = false
+}
+
+// RangeActive reports whether a range is following the cursor.
+func (self *MultiSelect) RangeActive() bool {
+	return self.rangeActive
+}
+
+// Active reports whether anything is marked or a range is in progress.
+func (self *MultiSelect) Active() bool {
+	return self.rangeActive || len(self.marked) > 0
+}
+
+// Clear drops all marks and any range in progress.
+func (self *MultiSelect) Clear() {
+	self.marked = nil
+	self.rangeActive = false
+}
+
+// Contains reports whether the item at idx (with the given uuid) is
+// selected, either marked or inside the active range.
+func (self *MultiSelect) Contains(idx int, uuid string, length, cursor int) bool {
+	if self.marked[uuid] {
+		return true
+	}
+	if !self.rangeActive {
+		return false
+	}
+	lo, hi := self.bounds(length, cursor)
+	return idx >= lo && idx <= hi
+}
+
+// Indices returns the selected positions in uuids, in list order.
+func (self *MultiSelect) Indices(uuids []string, cursor int) []int {
+	var out []int
+	for i, uuid := range uuids {
+		if self.Contains(i, uuid, len(uuids), cursor) {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// bounds returns the inclusive range between the anchor and cursor,
+// clamped to the list so a shrinking list can't index out of range.
+func (self *MultiSelect) bounds(length, cursor int) (int, int) {
+	lo, hi := min(self.rangeAnchor, cursor), max(self.rangeAnchor, cursor)
+	return max(lo, 0), min(hi, length-1)
+}
+
+// NoteUUIDs returns the UUIDs of notes, in order, for MultiSelect calls.
+func NoteUUIDs(notes []models.Note) []string {
+	uuids := make([]string, len(notes))
+	for i, n := range notes {
+		uuids[i] = n.UUID
+	}
+	return uuids
+}
+
+// selectedNotes resolves sel against notes, returning nil when nothing is
+// selected.
+func selectedNotes(sel *MultiSelect, notes []models.Note, cursor int) []models.Note {
+	if !sel.Active() {
+		return nil
+	}
+	var out []models.Note
+	for _, i := range sel.Indices(NoteUUIDs(notes), cursor) {
+		out = append(out, notes[i])
+	}
+	return out
+}
@@ -0,0 +1,68 @@
+package context
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMultiSelect_ZeroValueIsEmpty(t *testing.T) {
+	var s MultiSelect
+	if s.Active() {
+		t.Error("zero value should not be active")
+	}
+	if got := s.Indices([]string{"a", "b"}, 0); got != nil {
+		t.Errorf("Indices = %v, want nil", got)
+	}
+}
+
+func TestMultiSelect_Toggle(t *testing.T) {
+	var s MultiSelect
+	uuids := []string{"a", "b", "c"}
+	s.Toggle("b")
+	s.Toggle("c")
+	s.Toggle("c")
+	if got := s.Indices(uuids, 0); !slices.Equal(got, []int{1}) {
+		t.Errorf("Indices = %v, want [1]", got)
+	}
+}
+
+func TestMultiSelect_RangeFollowsCursorUntilCommitted(t *testing.T) {
+	var s MultiSelect
+	uuids := []string{"a", "b", "c", "d", "e"}
+	s.ToggleRange(uuids, 3)
+	if !s.RangeActive() {
+		t.Fatal("range should be active after the first V")
+	}
+	// Cursor moves up to 1: the live range covers 1..3.
+	if got := s.Indices(uuids, 1); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("live range = %v, want [1 2 3]", got)
+	}
+
+	s.ToggleRange(uuids, 1)
+	if s.RangeActive() {
+		t.Error("second V should end the range")
+	}
+	// Committed marks stay put when the cursor moves on.
+	if got := s.Indices(uuids, 4); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("committed range = %v, want [1 2 3]", got)
+	}
+}
+
+func TestMultiSelect_RangeClampsToShrunkList(t *testing.T) {
+	var s MultiSelect
+	s.ToggleRange([]string{"a", "b", "c", "d"}, 3)
+	// The list reloads with two items while the anchor is still at 3.
+	if got := s.Indices([]string{"a", "b"}, 0); !slices.Equal(got, []int{0, 1}) {
+		t.Errorf("Indices = %v, want [0 1]", got)
+	}
+}
+
+func TestMultiSelect_Clear(t *testing.T) {
+	var s MultiSelect
+	s.Toggle("a")
+	s.ToggleRange([]string{"a", "b"}, 0)
+	s.Clear()
+	if s.Active() || s.RangeActive() {
+		t.Error("Clear should drop marks and the range")
+	}
+}
@@ -121,6 +121,20 @@ func (m *NavigationManager) JumpTo(idx int) (NavigationEvent, bool) {
 	return m.entries[idx], true
 }
 
+// Load replaces the history with entries, positioned at index. Used when a
+// saved session is restored; the cap still applies, keeping the newest.
+func (m *NavigationManager) Load(entries []NavigationEvent, index int) {
+	m.entries = append([]NavigationEvent(nil), entries...)
+	m.index = index
+	if m.index >= len(m.entries) {
+		m.index = len(m.entries) - 1
+	}
+	if m.index < 0 && len(m.entries) > 0 {
+		m.index = 0
+	}
+	m.SetCap(m.cap)
+}
+
 // Clear empties the history.
 func (m *NavigationManager) Clear() {
 	m.entries = nil
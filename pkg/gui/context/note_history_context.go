@@ -0,0 +1,45 @@
+package context
+
+import (
+	"time"
+
+	"github.com/donnellyk/lazyruin/pkg/git"
+	"github.com/donnellyk/lazyruin/pkg/gui/types"
+	"github.com/donnellyk/lazyruin/pkg/models"
+)
+
+// NoteHistoryContext holds the note history popup state: the note, the git
+// revisions that touched its file and the diff of the selected one, which
+// the preview shows while the popup is open.
+type NoteHistoryContext struct {
+	BaseContext
+	Note        models.Note
+	Mtime       time.Time // file mtime when the history was opened, for restore conflict checks
+	Revisions   []git.Revision
+	SelectedIdx int
+	Diff        string
+	DiffErr     error
+	DiffScroll  int
+}
+
+func NewNoteHistoryContext() *NoteHistoryContext {
+	return &NoteHistoryContext{
+		BaseContext: NewBaseContext(NewBaseContextOpts{
+			Kind:      types.TEMPORARY_POPUP,
+			Key:       "noteHistory",
+			ViewName:  "noteHistory",
+			Focusable: true,
+			Title:     "Note History",
+		}),
+	}
+}
+
+// Selected returns the revision under the cursor, or nil when there are none.
+func (self *NoteHistoryContext) Selected() *git.Revision {
+	if self.SelectedIdx < 0 || self.SelectedIdx >= len(self.Revisions) {
+		return nil
+	}
+	return &self.Revisions[self.SelectedIdx]
+}
+
+var _ types.Context = &NoteHistoryContext{}
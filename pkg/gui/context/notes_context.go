@@ -25,6 +25,7 @@ type NotesContext struct {
 
 	Items      []models.Note
 	CurrentTab NotesTab
+	Selection  MultiSelect
 	list       *notesList
 }
 
@@ -85,6 +86,12 @@ func (self *NotesContext) Selected() *models.Note {
 	return &self.Items[idx]
 }
 
+// SelectedNotes returns the multi-selected notes in list order, or nil
+// when no selection is active.
+func (self *NotesContext) SelectedNotes() []models.Note {
+	return selectedNotes(&self.Selection, self.Items, self.GetSelectedLineIdx())
+}
+
 // TabIndex returns the current tab index.
 func (self *NotesContext) TabIndex() int { return TabIndexOf(NotesTabs, self.CurrentTab) }
 
@@ -0,0 +1,122 @@
+package context
+
+import "github.com/donnellyk/lazyruin/pkg/gui/types"
+
+// ParentTreeNode is a note in the parent tree with its loaded children.
+type ParentTreeNode struct {
+	UUID     string
+	Title    string
+	Children []*ParentTreeNode
+}
+
+// ParentTreeRow is a visible line of the tree: a node plus where it sits.
+type ParentTreeRow struct {
+	Node   *ParentTreeNode
+	Parent *ParentTreeNode // nil for roots
+	Depth  int
+	Last   []bool // per level, whether the node (or its ancestor) is its parent's last child
+}
+
+// ParentTreeContext holds the tree navigator popup state: the roots, which
+// nodes are expanded, the selected row and the node cut for reparenting.
+type ParentTreeContext struct {
+	BaseContext
+	RootRefs    []string // notes the roots were loaded from, for reloads
+	Roots       []*ParentTreeNode
+	Expanded    map[string]bool
+	SelectedIdx int
+	CutUUID     string
+}
+
+func NewParentTreeContext() *ParentTreeContext {
+	return &ParentTreeContext{
+		BaseContext: NewBaseContext(NewBaseContextOpts{
+			Kind:      types.TEMPORARY_POPUP,
+			Key:       "parentTree",
+			ViewName:  "parentTree",
+			Focusable: true,
+			Title:     "Parent Tree",
+		}),
+		Expanded: make(map[string]bool),
+	}
+}
+
+// Rows flattens the expanded part of the tree in display order.
+func (self *ParentTreeContext) Rows() []ParentTreeRow {
+	var rows []ParentTreeRow
+	var walk func(nodes []*ParentTreeNode, parent *ParentTreeNode, last []bool)
+	walk = func(nodes []*ParentTreeNode, parent *ParentTreeNode, last []bool) {
+		for i, n := range nodes {
+			l := append(append([]bool(nil), last...), i == len(nodes)-1)
+			rows = append(rows, ParentTreeRow{Node: n, Parent: parent, Depth: len(last), Last: l})
+			if self.Expanded[n.UUID] {
+				walk(n.Children, n, l)
+			}
+		}
+	}
+	walk(self.Roots, nil, nil)
+	return rows
+}
+
+// SelectedRow returns the row under the cursor, or nil when the tree is empty.
+func (self *ParentTreeContext) SelectedRow() *ParentTreeRow {
+	rows := self.Rows()
+	if self.SelectedIdx < 0 || self.SelectedIdx >= len(rows) {
+		return nil
+	}
+	return &rows[self.SelectedIdx]
+}
+
+// Select moves the cursor to the first visible row for uuid.
+func (self *ParentTreeContext) Select(uuid string) bool {
+	for i, r := range self.Rows() {
+		if r.Node.UUID == uuid {
+			self.SelectedIdx = i
+			return true
+		}
+	}
+	return false
+}
+
+// Find returns the first node with uuid anywhere in the tree, expanded or not.
+func (self *ParentTreeContext) Find(uuid string) *ParentTreeNode {
+	var find func(nodes []*ParentTreeNode) *ParentTreeNode
+	find = func(nodes []*ParentTreeNode) *ParentTreeNode {
+		for _, n := range nodes {
+			if n.UUID == uuid {
+				return n
+			}
+			if found := find(n.Children); found != nil {
+				return found
+			}
+		}
+		return nil
+	}
+	return find(self.Roots)
+}
+
+// Contains reports whether uuid is n or one of its descendants.
+func (n *ParentTreeNode) Contains(uuid string) bool {
+	if n.UUID == uuid {
+		return true
+	}
+	for _, c := range n.Children {
+		if c.Contains(uuid) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClampSelection keeps the cursor within the visible rows.
+func (self *ParentTreeContext) ClampSelection() {
+	n := len(self.Rows())
+	if self.SelectedIdx >= n {
+		self.SelectedIdx = n - 1
+	}
+	if self.SelectedIdx < 0 {
+		self.SelectedIdx = 0
+	}
+}
+
+var _ types.Context = &ParentTreeContext{}
@@ -8,9 +8,10 @@ import (
 // PickResultsSource holds metadata about the query that populated pick results,
 // enabling re-query for filtering.
 type PickResultsSource struct {
-	Query    string                                               // for display/seed in filter dialog
-	Requery  func(filterText string) ([]models.PickResult, error) // combines filter with original query
-	Triggers func() []types.CompletionTrigger                     // completion triggers for filter dialog
+	Query      string                                               // for display/seed in filter dialog
+	Requery    func(filterText string) ([]models.PickResult, error) // combines filter with original query
+	Triggers   func() []types.CompletionTrigger                     // completion triggers for filter dialog
+	Descriptor SourceDescriptor                                     // plain-data form of the query, for snapshots
 }
 
 // PickResultsState holds state specific to the pick-results preview mode.
@@ -98,24 +99,24 @@ func NewPickDialogContext() *PickResultsContext {
 	}
 }
 
-// pickResultsSnapshot carries view params (Source with Requery closure) and
-// view state for PickResults restoration.
+// pickResultsSnapshot carries view params (the Source descriptor) and view
+// state for PickResults restoration.
 type pickResultsSnapshot struct {
-	Title           string
-	Source          PickResultsSource
-	FilterText      string
-	FrozenResults   []models.PickResult
-	SelectedCardIdx int
-	CursorLine      int
-	ScrollOffset    int
-	Display         PreviewDisplayState
+	Title           string              `json:"title"`
+	Source          SourceDescriptor    `json:"source"`
+	FilterText      string              `json:"filter_text,omitempty"`
+	FrozenResults   []models.PickResult `json:"-"`
+	SelectedCardIdx int                 `json:"selected_card"`
+	CursorLine      int                 `json:"cursor_line"`
+	ScrollOffset    int                 `json:"scroll_offset"`
+	Display         PreviewDisplayState `json:"display"`
 }
 
 func (self *PickResultsContext) CaptureSnapshot() types.Snapshot {
 	ns := self.NavState()
 	return &pickResultsSnapshot{
 		Title:           self.Title(),
-		Source:          self.Source,
+		Source:          self.Source.Descriptor,
 		FilterText:      self.FilterText,
 		FrozenResults:   append([]models.PickResult(nil), self.Results...),
 		SelectedCardIdx: self.SelectedCardIdx,
@@ -131,12 +132,15 @@ func (self *PickResultsContext) RestoreSnapshot(s types.Snapshot) error {
 		return nil
 	}
 	self.SetTitle(snap.Title)
-	self.Source = snap.Source
+	self.Source = PickResultsSource{Query: snap.Source.Query, Descriptor: snap.Source}
+	if self.resolver != nil && snap.Source.Kind != "" {
+		self.Source = self.resolver.PickResultsSource(snap.Source)
+	}
 	self.FilterText = snap.FilterText
 	*self.DisplayState() = snap.Display
 
-	if snap.Source.Requery != nil {
-		results, err := snap.Source.Requery(snap.FilterText)
+	if self.Source.Requery != nil {
+		results, err := self.Source.Requery(snap.FilterText)
 		if err == nil {
 			self.Results = results
 		} else {
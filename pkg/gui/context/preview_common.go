@@ -1,6 +1,10 @@
 package context
 
-import "github.com/donnellyk/lazyruin/pkg/gui/types"
+import (
+	"regexp"
+
+	"github.com/donnellyk/lazyruin/pkg/gui/types"
+)
 
 // PreviewNavState holds navigation state shared across all preview contexts.
 type PreviewNavState struct {
@@ -10,8 +14,33 @@ type PreviewNavState struct {
 	HeaderLines     []int              // absolute line numbers containing markdown headers
 	Lines           []types.SourceLine // indexed by visual line number; populated during rendering
 	Links           []PreviewLink
-	HighlightedLink int // index into Links; -1 = none, auto-cleared each render
-	RenderedLink    int // snapshot of HighlightedLink used during current render
+	HighlightedLink int                  // index into Links; -1 = none, auto-cleared each render
+	RenderedLink    int                  // snapshot of HighlightedLink used during current render
+	Matches         []PreviewLinkSegment // search-term or find spans in Lines; populated during rendering
+	CurrentMatch    int                  // index into Matches last jumped to with n/N; -1 = none
+	Find            PreviewFind          // in-preview find; replaces search-term highlighting while active
+}
+
+// PreviewFind is the text found in the preview with the / find bar.
+type PreviewFind struct {
+	Pattern       string
+	Regex         bool
+	CaseSensitive bool
+	Re            *regexp.Regexp // compiled from the fields above; nil when empty or invalid
+}
+
+// Active reports whether the find highlights anything.
+func (f *PreviewFind) Active() bool {
+	return f.Re != nil
+}
+
+// ActiveMatch returns CurrentMatch while the cursor is still on that
+// match's line, or -1.
+func (ns *PreviewNavState) ActiveMatch() int {
+	if ns.CurrentMatch < 0 || ns.CurrentMatch >= len(ns.Matches) || ns.Matches[ns.CurrentMatch].Line != ns.CursorLine {
+		return -1
+	}
+	return ns.CurrentMatch
 }
 
 // PreviewDisplayState holds display toggle state shared across all preview contexts.
@@ -38,13 +67,17 @@ type PreviewState struct {
 // common accessors.
 type PreviewContextTrait struct {
 	PreviewState
+
+	// resolver rebuilds the query on RestoreSnapshot. Without one,
+	// restores fall back to the frozen results.
+	resolver SourceResolver
 }
 
 // NewPreviewContextTrait creates a PreviewContextTrait with sensible defaults.
 func NewPreviewContextTrait() PreviewContextTrait {
 	return PreviewContextTrait{
 		PreviewState: PreviewState{
-			PreviewNavState:     PreviewNavState{HighlightedLink: -1},
+			PreviewNavState:     PreviewNavState{HighlightedLink: -1, CurrentMatch: -1},
 			PreviewDisplayState: PreviewDisplayState{RenderMarkdown: true, DimDone: true, ShowCompose: true},
 		},
 	}
@@ -54,6 +87,7 @@ func (t *PreviewContextTrait) NavState() *PreviewNavState         { return &t.Pr
 func (t *PreviewContextTrait) DisplayState() *PreviewDisplayState { return &t.PreviewDisplayState }
 func (t *PreviewContextTrait) SelectedCardIndex() int             { return t.SelectedCardIdx }
 func (t *PreviewContextTrait) SetSelectedCardIndex(idx int)       { t.SelectedCardIdx = idx }
+func (t *PreviewContextTrait) SetSourceResolver(r SourceResolver) { t.resolver = r }
 
 // IPreviewContext is the interface that all preview contexts implement,
 // allowing helpers to work generically across CardList, PickResults, Compose,
@@ -67,6 +101,7 @@ type IPreviewContext interface {
 	SetSelectedCardIndex(int)
 	CardCount() int
 	SetTitle(string)
+	SetSourceResolver(SourceResolver)
 }
 
 // Filterable abstracts the filter state shared by CardListContext and
@@ -0,0 +1,26 @@
+package context
+
+import "github.com/donnellyk/lazyruin/pkg/gui/types"
+
+// PreviewFindContext owns the find bar drawn over the bottom of the
+// preview. What is found lives in the preview's PreviewNavState.Find.
+type PreviewFindContext struct {
+	BaseContext
+	Origin  int  // preview cursor line when the bar opened; restored on cancel
+	Invalid bool // the typed pattern is not a valid regular expression
+}
+
+// NewPreviewFindContext creates a PreviewFindContext.
+func NewPreviewFindContext() *PreviewFindContext {
+	return &PreviewFindContext{
+		BaseContext: NewBaseContext(NewBaseContextOpts{
+			Kind:      types.TEMPORARY_POPUP,
+			Key:       "previewFind",
+			ViewName:  "previewFind",
+			Focusable: true,
+			Title:     "Find",
+		}),
+	}
+}
+
+var _ types.Context = &PreviewFindContext{}
@@ -0,0 +1,91 @@
+package context
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/donnellyk/lazyruin/pkg/gui/types"
+	"github.com/donnellyk/lazyruin/pkg/models"
+)
+
+// SourceKind names the query behind a card list or pick results.
+type SourceKind string
+
+const (
+	SourceSearch    SourceKind = "search"     // ruin search for Query, sorted by Sort
+	SourceSearchRaw SourceKind = "search_raw" // user-typed search; sort: is extracted from Query
+	SourceNote      SourceKind = "note"       // a single note, Query is its UUID
+	SourceLinks     SourceKind = "links"      // the Links browser
+	SourcePickTag   SourceKind = "pick_tag"   // inline pick of the tag in Query
+	SourcePick      SourceKind = "pick"       // pick dialog query, with Any/Todo/All flags
+	SourceBacklinks SourceKind = "backlinks"  // notes referencing the note whose UUID is Query
+)
+
+// SourceDescriptor is the plain-data form of a preview query. Sources and
+// snapshots carry it instead of only a Requery closure, so a snapshot can
+// be written to the session file and re-run in a later launch. The zero
+// value describes a list that can't be re-queried.
+type SourceDescriptor struct {
+	Kind  SourceKind `json:"kind,omitempty"`
+	Query string     `json:"query,omitempty"`
+	Sort  string     `json:"sort,omitempty"`
+	Any   bool       `json:"any,omitempty"`
+	Todo  bool       `json:"todo,omitempty"`
+	All   bool       `json:"all,omitempty"`
+}
+
+// SourceResolver turns descriptors back into live queries. Preview
+// contexts use it in RestoreSnapshot; helpers.PreviewHelper implements it.
+type SourceResolver interface {
+	CardListSource(d SourceDescriptor) CardListSource
+	PickResultsSource(d SourceDescriptor) PickResultsSource
+	ComposeRequery(parent models.ParentBookmark) ComposeRequery
+	DatePreviewRequery(target string) DatePreviewRequery
+}
+
+// MarshalSnapshot encodes a preview snapshot for the session file. Only
+// view parameters and view state are written; the cards themselves are
+// re-queried on restore. Reports false for snapshots that can't be
+// re-queried, which would restore empty.
+func MarshalSnapshot(s types.Snapshot) (json.RawMessage, bool) {
+	switch snap := s.(type) {
+	case *cardListSnapshot:
+		if snap.Source.Kind == "" {
+			return nil, false
+		}
+	case *pickResultsSnapshot:
+		if snap.Source.Kind == "" {
+			return nil, false
+		}
+	case *composeSnapshot, *datePreviewSnapshot:
+	default:
+		return nil, false
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// UnmarshalSnapshot decodes a snapshot written by MarshalSnapshot for the
+// preview context with the given key.
+func UnmarshalSnapshot(key types.ContextKey, data json.RawMessage) (types.Snapshot, error) {
+	var snap types.Snapshot
+	switch key {
+	case "cardList":
+		snap = &cardListSnapshot{}
+	case "pickResults":
+		snap = &pickResultsSnapshot{}
+	case "compose":
+		snap = &composeSnapshot{}
+	case "datePreview":
+		snap = &datePreviewSnapshot{}
+	default:
+		return nil, fmt.Errorf("no snapshot for context %q", key)
+	}
+	if err := json.Unmarshal(data, snap); err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
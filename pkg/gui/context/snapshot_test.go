@@ -1,6 +1,7 @@
 package context
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/donnellyk/lazyruin/pkg/models"
@@ -61,12 +62,8 @@ func TestCardListContext_RestoreViaRequery(t *testing.T) {
 	ctx := NewCardListContext()
 	ctx.SetTitle("Query")
 	fresh := []models.Note{{UUID: "fresh-1", Title: "Fresh"}}
-	ctx.Source = CardListSource{
-		Query: "q",
-		Requery: func(filterText string) ([]models.Note, error) {
-			return fresh, nil
-		},
-	}
+	ctx.SetSourceResolver(&stubResolver{notes: fresh})
+	ctx.Source = CardListSource{Query: "q", Descriptor: SourceDescriptor{Kind: SourceSearch, Query: "q"}}
 	ctx.Cards = []models.Note{{UUID: "stale"}}
 
 	snap := ctx.CaptureSnapshot()
@@ -113,10 +110,10 @@ func TestComposeContext_SnapshotRoundTrip(t *testing.T) {
 	ctx.NavState().CursorLine = 5
 
 	requeryCalled := false
-	ctx.Requery = func() (models.Note, []models.SourceMapEntry, error) {
+	ctx.SetSourceResolver(&stubResolver{compose: func() (models.Note, []models.SourceMapEntry, error) {
 		requeryCalled = true
 		return models.Note{UUID: "n-fresh", Title: "Fresh"}, nil, nil
-	}
+	}})
 
 	snap := ctx.CaptureSnapshot()
 	ctx.Note = models.Note{}
@@ -142,9 +139,7 @@ func TestDatePreviewContext_SnapshotRoundTrip(t *testing.T) {
 	ctx.TargetDate = "2026-04-17"
 	ctx.Notes = []models.Note{{UUID: "stale"}}
 
-	ctx.Requery = func() ([]models.PickResult, []models.PickResult, []models.Note, error) {
-		return nil, nil, []models.Note{{UUID: "fresh"}}, nil
-	}
+	ctx.SetSourceResolver(&stubResolver{notes: []models.Note{{UUID: "fresh"}}})
 
 	snap := ctx.CaptureSnapshot()
 	ctx.Notes = nil
@@ -164,12 +159,8 @@ func TestCardListContext_RestoreFallsBackToFrozenOnRequeryError(t *testing.T) {
 	ctx := NewCardListContext()
 	ctx.Cards = []models.Note{{UUID: "frozen"}}
 
-	failErr := &errStub{"requery fail"}
-	ctx.Source = CardListSource{
-		Requery: func(filterText string) ([]models.Note, error) {
-			return nil, failErr
-		},
-	}
+	ctx.SetSourceResolver(&stubResolver{err: &errStub{"requery fail"}})
+	ctx.Source = CardListSource{Descriptor: SourceDescriptor{Kind: SourceSearch}}
 
 	snap := ctx.CaptureSnapshot()
 	ctx.Cards = nil
@@ -188,11 +179,8 @@ func TestCardListContext_RestoreClampsSelectedCardIdx(t *testing.T) {
 	ctx.SelectedCardIdx = 2
 
 	// Requery returns only one note — clamp should apply.
-	ctx.Source = CardListSource{
-		Requery: func(filterText string) ([]models.Note, error) {
-			return []models.Note{{UUID: "a"}}, nil
-		},
-	}
+	ctx.SetSourceResolver(&stubResolver{notes: []models.Note{{UUID: "a"}}})
+	ctx.Source = CardListSource{Descriptor: SourceDescriptor{Kind: SourceSearch}}
 
 	snap := ctx.CaptureSnapshot()
 	_ = ctx.RestoreSnapshot(snap)
@@ -207,11 +195,8 @@ func TestPickResultsContext_RestoreClampsSelectedCardIdx(t *testing.T) {
 	ctx.Results = []models.PickResult{{UUID: "a"}, {UUID: "b"}, {UUID: "c"}}
 	ctx.SelectedCardIdx = 2
 
-	ctx.Source = PickResultsSource{
-		Requery: func(filterText string) ([]models.PickResult, error) {
-			return []models.PickResult{{UUID: "a"}}, nil
-		},
-	}
+	ctx.SetSourceResolver(&stubResolver{picks: []models.PickResult{{UUID: "a"}}})
+	ctx.Source = PickResultsSource{Descriptor: SourceDescriptor{Kind: SourcePick}}
 
 	snap := ctx.CaptureSnapshot()
 	_ = ctx.RestoreSnapshot(snap)
@@ -221,6 +206,93 @@ func TestPickResultsContext_RestoreClampsSelectedCardIdx(t *testing.T) {
 	}
 }
 
+func TestMarshalSnapshot_RoundTripsViewParams(t *testing.T) {
+	ctx := NewPickResultsContext()
+	ctx.SetTitle("Pick: #todo")
+	ctx.Source = PickResultsSource{Query: "#todo", Descriptor: SourceDescriptor{Kind: SourcePick, Query: "#todo", Any: true}}
+	ctx.Results = []models.PickResult{{UUID: "a"}, {UUID: "b"}}
+	ctx.SelectedCardIdx = 1
+	ctx.NavState().CursorLine = 9
+
+	data, ok := MarshalSnapshot(ctx.CaptureSnapshot())
+	if !ok {
+		t.Fatal("MarshalSnapshot refused a re-queryable snapshot")
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatal(err)
+	}
+	if _, has := fields["FrozenResults"]; has {
+		t.Error("frozen results should not be written")
+	}
+
+	snap, err := UnmarshalSnapshot("pickResults", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored := NewPickResultsContext()
+	var got SourceDescriptor
+	restored.SetSourceResolver(&stubResolver{
+		picks:   []models.PickResult{{UUID: "a"}, {UUID: "b"}},
+		onPicks: func(d SourceDescriptor) { got = d },
+	})
+	if err := restored.RestoreSnapshot(snap); err != nil {
+		t.Fatal(err)
+	}
+	if got != ctx.Source.Descriptor {
+		t.Errorf("resolver got %+v, want %+v", got, ctx.Source.Descriptor)
+	}
+	if restored.Title() != "Pick: #todo" || restored.SelectedCardIdx != 1 || restored.NavState().CursorLine != 9 {
+		t.Errorf("view state not restored: title=%q idx=%d cursor=%d",
+			restored.Title(), restored.SelectedCardIdx, restored.NavState().CursorLine)
+	}
+	if len(restored.Results) != 2 {
+		t.Errorf("Results = %+v, want re-queried results", restored.Results)
+	}
+}
+
+func TestMarshalSnapshot_SkipsUnqueryableLists(t *testing.T) {
+	ctx := NewCardListContext()
+	ctx.Cards = []models.Note{{UUID: "a"}}
+	if _, ok := MarshalSnapshot(ctx.CaptureSnapshot()); ok {
+		t.Error("a card list without a source descriptor can't be restored and shouldn't be saved")
+	}
+}
+
+// stubResolver returns canned data for every descriptor.
+type stubResolver struct {
+	notes   []models.Note
+	picks   []models.PickResult
+	compose ComposeRequery
+	err     error
+	onPicks func(SourceDescriptor)
+}
+
+func (r *stubResolver) CardListSource(d SourceDescriptor) CardListSource {
+	return CardListSource{Query: d.Query, Descriptor: d, Requery: func(string) ([]models.Note, error) {
+		return r.notes, r.err
+	}}
+}
+
+func (r *stubResolver) PickResultsSource(d SourceDescriptor) PickResultsSource {
+	if r.onPicks != nil {
+		r.onPicks(d)
+	}
+	return PickResultsSource{Query: d.Query, Descriptor: d, Requery: func(string) ([]models.PickResult, error) {
+		return r.picks, r.err
+	}}
+}
+
+func (r *stubResolver) ComposeRequery(models.ParentBookmark) ComposeRequery {
+	return r.compose
+}
+
+func (r *stubResolver) DatePreviewRequery(string) DatePreviewRequery {
+	return func() ([]models.PickResult, []models.PickResult, []models.Note, error) {
+		return nil, nil, r.notes, r.err
+	}
+}
+
 type errStub struct{ msg string }
 
 func (e *errStub) Error() string { return e.msg }
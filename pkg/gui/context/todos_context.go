@@ -0,0 +1,127 @@
+package context
+
+import "github.com/donnellyk/lazyruin/pkg/gui/types"
+
+// TodoGroupBy is how the Todos dashboard groups its items.
+type TodoGroupBy string
+
+const (
+	TodoGroupByNote   TodoGroupBy = "note"
+	TodoGroupByTag    TodoGroupBy = "tag"
+	TodoGroupByParent TodoGroupBy = "parent"
+	TodoGroupByDate   TodoGroupBy = "date"
+)
+
+// TodoGroupByOrder is the order `g` cycles through groupings.
+var TodoGroupByOrder = []TodoGroupBy{TodoGroupByNote, TodoGroupByTag, TodoGroupByParent, TodoGroupByDate}
+
+// TodoItem is one todo line in the vault.
+type TodoItem struct {
+	UUID    string
+	Title   string // title of the note the todo is in
+	Path    string // absolute path of the note
+	Line    int    // 1-indexed content line
+	Content string // the line without its checkbox
+	Tags    []string
+	Done    bool
+	Date    string // first inline date on the line, yyyy-mm-dd, or ""
+}
+
+// ID identifies the item across reloads so the cursor can stay on it.
+func (i *TodoItem) ID() string {
+	return i.UUID + ":" + i.Content
+}
+
+// TodoRow is a line in the dashboard: a group header or an item.
+type TodoRow struct {
+	Header string // non-empty for a group header
+	Item   *TodoItem
+}
+
+// TodosContext owns the vault-wide todo dashboard.
+type TodosContext struct {
+	BaseContext
+	Rows        []TodoRow
+	SelectedIdx int // always an item row when there is one
+	GroupBy     TodoGroupBy
+}
+
+func NewTodosContext() *TodosContext {
+	return &TodosContext{
+		BaseContext: NewBaseContext(NewBaseContextOpts{
+			Kind:      types.TEMPORARY_POPUP,
+			Key:       "todos",
+			ViewName:  "todos",
+			Focusable: true,
+			Title:     "Todos",
+		}),
+		GroupBy: TodoGroupByNote,
+	}
+}
+
+// Selected returns the item under the cursor, or nil.
+func (self *TodosContext) Selected() *TodoItem {
+	if self.SelectedIdx < 0 || self.SelectedIdx >= len(self.Rows) {
+		return nil
+	}
+	return self.Rows[self.SelectedIdx].Item
+}
+
+// ItemCount returns the number of item rows.
+func (self *TodosContext) ItemCount() int {
+	n := 0
+	for _, r := range self.Rows {
+		if r.Item != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// NextItem returns the next item row after from, or from if there is none.
+func (self *TodosContext) NextItem(from int) int {
+	for i := from + 1; i < len(self.Rows); i++ {
+		if self.Rows[i].Item != nil {
+			return i
+		}
+	}
+	return from
+}
+
+// PrevItem returns the previous item row before from, or from if there is
+// none.
+func (self *TodosContext) PrevItem(from int) int {
+	for i := from - 1; i >= 0; i-- {
+		if self.Rows[i].Item != nil {
+			return i
+		}
+	}
+	return from
+}
+
+// SetRows replaces Rows, keeping the cursor on the item with preserveID
+// when it is still listed. Otherwise the cursor stays about where it was,
+// on the nearest item, so checking off a todo lands on the next one.
+func (self *TodosContext) SetRows(rows []TodoRow, preserveID string) {
+	self.Rows = rows
+	if preserveID != "" {
+		for i, r := range rows {
+			if r.Item != nil && r.Item.ID() == preserveID {
+				self.SelectedIdx = i
+				return
+			}
+		}
+	}
+	idx := min(max(self.SelectedIdx, 0), max(len(rows)-1, 0))
+	if len(rows) == 0 || rows[idx].Item != nil {
+		self.SelectedIdx = idx
+		return
+	}
+	if next := self.NextItem(idx); next != idx {
+		self.SelectedIdx = next
+	} else {
+		self.SelectedIdx = max(self.PrevItem(idx), 0)
+	}
+}
+
+var _ types.Context = &TodosContext{}
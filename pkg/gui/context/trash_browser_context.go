@@ -0,0 +1,26 @@
+package context
+
+import (
+	"github.com/donnellyk/lazyruin/pkg/gui/types"
+	"github.com/donnellyk/lazyruin/pkg/trash"
+)
+
+type TrashBrowserContext struct {
+	BaseContext
+	Items       []trash.Item
+	SelectedIdx int
+}
+
+func NewTrashBrowserContext() *TrashBrowserContext {
+	return &TrashBrowserContext{
+		BaseContext: NewBaseContext(NewBaseContextOpts{
+			Kind:      types.TEMPORARY_POPUP,
+			Key:       "trashBrowser",
+			ViewName:  "trashBrowser",
+			Focusable: true,
+			Title:     "Trash",
+		}),
+	}
+}
+
+var _ types.Context = &TrashBrowserContext{}
@@ -96,3 +96,10 @@ func (m *ContextMgr) SetStack(keys []types.ContextKey) {
 	defer m.mu.Unlock()
 	m.stack = keys
 }
+
+// Stack returns a copy of the stack, bottom first.
+func (m *ContextMgr) Stack() []types.ContextKey {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Clone(m.stack)
+}
@@ -42,8 +42,8 @@ func (gui *Gui) createContribViews(g *gocui.Gui, maxX, maxY int) error {
 	setRoundedCorners(gv)
 
 	if s.Focus == 0 {
-		gv.FrameColor = gocui.ColorGreen
-		gv.TitleColor = gocui.ColorGreen
+		gv.FrameColor = theme.FrameActive
+		gv.TitleColor = theme.FrameActive
 	} else {
 		gv.FrameColor = gocui.ColorDefault
 		gv.TitleColor = gocui.ColorDefault
@@ -73,8 +73,8 @@ func (gui *Gui) createContribViews(g *gocui.Gui, maxX, maxY int) error {
 	setRoundedCorners(nv)
 
 	if s.Focus == 1 {
-		nv.FrameColor = gocui.ColorGreen
-		nv.TitleColor = gocui.ColorGreen
+		nv.FrameColor = theme.FrameActive
+		nv.TitleColor = theme.FrameActive
 	} else {
 		nv.FrameColor = gocui.ColorDefault
 		nv.TitleColor = gocui.ColorDefault
@@ -182,7 +182,7 @@ func (gui *Gui) renderContribGrid(v *gocui.View) {
 			}
 
 			if c.date == s.SelectedDate {
-				fmt.Fprintf(&line, "%s◼%s ", AnsiBlueBgWhite, AnsiReset)
+				fmt.Fprintf(&line, "%s◼%s ", theme.Selected, AnsiReset)
 			} else {
 				line.WriteString(contribChar(c.count))
 			}
@@ -192,10 +192,10 @@ func (gui *Gui) renderContribGrid(v *gocui.View) {
 
 	// Legend row
 	fmt.Fprintf(v, "  %s◼%s = 0  %s◼%s = 1  %s◼%s = 2  %s◼%s = 3+\n",
-		AnsiDim, AnsiReset,
-		AnsiGreen1, AnsiReset,
-		AnsiGreen2, AnsiReset,
-		AnsiGreen3, AnsiReset,
+		theme.Muted, AnsiReset,
+		theme.Activity[0], AnsiReset,
+		theme.Activity[1], AnsiReset,
+		theme.Activity[2], AnsiReset,
 	)
 }
 
@@ -203,12 +203,12 @@ func (gui *Gui) renderContribGrid(v *gocui.View) {
 func contribChar(count int) string {
 	switch count {
 	case 0:
-		return fmt.Sprintf("%s◼%s ", AnsiDim, AnsiReset)
+		return fmt.Sprintf("%s◼%s ", theme.Muted, AnsiReset)
 	case 1:
-		return fmt.Sprintf("%s◼%s ", AnsiGreen1, AnsiReset)
+		return fmt.Sprintf("%s◼%s ", theme.Activity[0], AnsiReset)
 	case 2:
-		return fmt.Sprintf("%s◼%s ", AnsiGreen2, AnsiReset)
+		return fmt.Sprintf("%s◼%s ", theme.Activity[1], AnsiReset)
 	default:
-		return fmt.Sprintf("%s◼%s ", AnsiGreen3, AnsiReset)
+		return fmt.Sprintf("%s◼%s ", theme.Activity[2], AnsiReset)
 	}
 }
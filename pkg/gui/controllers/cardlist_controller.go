@@ -79,10 +79,24 @@ func (self *CardListController) GetKeybindings(opts types.KeybindingsOpts) []*ty
 			ID: "cardList.remove_parent", Key: 'P',
 			Handler: self.removeParent, Description: "Remove Parent", Category: "Note Actions",
 		},
+		&types.Binding{
+			ID: "cardList.ancestors", Key: '<',
+			Handler:           self.c.Helpers().Breadcrumb().ShowAncestorsMenu,
+			GetDisabledReason: self.noAncestors,
+			Description:       "Open Ancestor", Category: "Preview",
+		},
 		&types.Binding{
 			ID: "cardList.toggle_bookmark", Key: 'b',
 			Handler: self.toggleBookmark, Description: "Toggle Bookmark", Category: "Note Actions",
 		},
+		&types.Binding{
+			ID: "cardList.toggle_select", Key: gocui.KeySpace,
+			Handler: self.c.Helpers().Selection().ToggleNote, Description: "Toggle Selection", Category: "Selection",
+		},
+		&types.Binding{
+			ID: "cardList.range_select", Key: 'V',
+			Handler: self.c.Helpers().Selection().ToggleRange, Description: "Select Range", Category: "Selection",
+		},
 		&types.Binding{
 			ID:      "cardList.order_cards",
 			Handler: self.mutations().OrderCards, Description: "Order Cards", Category: "Preview",
@@ -109,9 +123,20 @@ func (self *CardListController) GetKeybindings(opts types.KeybindingsOpts) []*ty
 			Description:       "Clear Filter", Category: "Preview",
 			DisplayOnScreen: true, StatusBarLabel: "Clear",
 		},
+		&types.Binding{
+			ID: "cardList.export", Key: 'w',
+			Handler: self.c.Helpers().Export().Export, Description: "Export Cards", Category: "Preview",
+		},
 	)
 }
 
+func (self *CardListController) noAncestors() *types.DisabledReason {
+	if len(self.c.Helpers().Breadcrumb().Crumbs()) == 0 {
+		return &types.DisabledReason{Text: "Not a single note with a parent"}
+	}
+	return nil
+}
+
 func (self *CardListController) openURL() error {
 	card := self.c.Helpers().Preview().CurrentPreviewCard()
 	if card == nil {
@@ -45,6 +45,14 @@ func (self *ComposeController) GetKeybindings(opts types.KeybindingsOpts) []*typ
 			ID: "compose.edit_inline", Key: 'e',
 			Handler: self.editInline, Description: "Edit Child in Popup", Category: "Preview",
 		},
+		&types.Binding{
+			ID: "compose.edit_document", Key: 'W',
+			Handler: self.c.Helpers().ComposeEdit().EditWholeDocument, Description: "Edit Whole Document", Category: "Preview",
+		},
+		&types.Binding{
+			ID: "compose.export", Key: 'w',
+			Handler: self.c.Helpers().Export().Export, Description: "Export Document", Category: "Preview",
+		},
 	)
 }
 
@@ -36,8 +36,27 @@ type IHelpers interface {
 	Link() *helpers.LinkHelper
 	CardListFilter() *helpers.CardListFilterHelper
 	Scratchpad() *helpers.ScratchpadHelper
+	Trash() *helpers.TrashHelper
+	Session() *helpers.SessionHelper
+	Backlinks() *helpers.BacklinksHelper
+	ComposeEdit() *helpers.ComposeEditHelper
+	ParentTree() *helpers.ParentTreeHelper
+	Breadcrumb() *helpers.BreadcrumbHelper
+	Git() *helpers.GitHelper
+	MergeConflict() *helpers.MergeConflictHelper
+	CustomCommands() *helpers.CustomCommandsHelper
+	Hooks() *helpers.HooksHelper
+	Templates() *helpers.TemplatesHelper
+	Todos() *helpers.TodosHelper
+	SearchHistory() *helpers.SearchHistoryHelper
+	PreviewFind() *helpers.PreviewFindHelper
+	Export() *helpers.ExportHelper
 	NotesHome() *helpers.NotesHomeHelper
 	Navigator() *helpers.Navigator
+	Async() *helpers.AsyncHelper
+	Undo() *helpers.UndoHelper
+	Selection() *helpers.SelectionHelper
+	Vault() *helpers.VaultHelper
 }
 
 // ControllerCommon provides shared dependencies for all controllers.
@@ -66,6 +85,11 @@ func (self *ControllerCommon) RuinCmd() *commands.RuinCommand {
 	return self.ruinCmd
 }
 
+// SetRuinCmd swaps the ruin command wrapper after a vault switch.
+func (self *ControllerCommon) SetRuinCmd(cmd *commands.RuinCommand) {
+	self.ruinCmd = cmd
+}
+
 // Helpers returns the helpers aggregator.
 func (self *ControllerCommon) Helpers() IHelpers {
 	return self.helpers
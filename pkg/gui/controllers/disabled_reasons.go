@@ -5,6 +5,17 @@ import (
 	"github.com/donnellyk/lazyruin/pkg/models"
 )
 
+// requireNote returns a disabled-reason check that fails when getNote
+// returns nil.
+func requireNote(getNote func() *models.Note) func() *types.DisabledReason {
+	return func() *types.DisabledReason {
+		if getNote() == nil {
+			return &types.DisabledReason{Text: "No note selected"}
+		}
+		return nil
+	}
+}
+
 // requireLinkNote returns a disabled-reason check that fails when the current
 // note is nil or not a link note. The getNote parameter lets callers supply
 // whatever note-fetching logic is appropriate for their context.
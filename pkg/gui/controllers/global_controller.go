@@ -169,6 +169,10 @@ func (self *GlobalController) newNote() error {
 	return self.c.Helpers().Capture().OpenCapture()
 }
 
+func (self *GlobalController) newFromTemplate() error {
+	return self.c.Helpers().Templates().Open()
+}
+
 func (self *GlobalController) newLink() error {
 	return self.c.Helpers().Link().CreateLink(false)
 }
@@ -185,16 +189,44 @@ func (self *GlobalController) openScratchpad() error {
 	return self.c.Helpers().Scratchpad().OpenBrowser()
 }
 
+func (self *GlobalController) openParentTree() error {
+	return self.c.Helpers().ParentTree().OpenBookmarks()
+}
+
+func (self *GlobalController) openTrash() error {
+	return self.c.Helpers().Trash().OpenBrowser()
+}
+
+func (self *GlobalController) openTodos() error {
+	return self.c.Helpers().Todos().Open()
+}
+
 func (self *GlobalController) showAbout() error {
 	self.c.GuiCommon().ShowAbout()
 	return nil
 }
 
+func (self *GlobalController) switchVault() error {
+	return self.c.Helpers().Vault().OpenSwitcher()
+}
+
 func (self *GlobalController) refresh() error {
 	self.c.Helpers().Refresh().ReloadAndRefresh()
 	return nil
 }
 
+func (self *GlobalController) undo() error {
+	return self.c.Helpers().Undo().Undo()
+}
+
+func (self *GlobalController) redo() error {
+	return self.c.Helpers().Undo().Redo()
+}
+
+func (self *GlobalController) undoHistory() error {
+	return self.c.Helpers().Undo().ShowHistory()
+}
+
 func (self *GlobalController) focusSearchFilter() error {
 	return self.c.Helpers().Search().FocusSearchFilter()
 }
@@ -210,15 +242,23 @@ func (self *GlobalController) GetKeybindings(opts types.KeybindingsOpts) []*type
 		{ID: "global.search", Key: 'S', Handler: self.openSearch, Description: "Search", Category: "Global", DisplayOnScreen: true, StatusBarLabel: "Search"},
 		{ID: "global.pick", Key: 'p', Handler: self.openPick, Description: "Pick", Category: "Global"},
 		{ID: "global.new_note", Key: 'n', Handler: self.newNote, Description: "New Note", Category: "Global"},
+		{ID: "global.new_from_template", Key: 'N', Handler: self.newFromTemplate, Description: "New from Template", Category: "Global"},
 		{ID: "global.new_link", Key: gocui.KeyCtrlL, Handler: self.newLink, Description: "New Link", Category: "Global"},
-		{ID: "global.refresh", Key: gocui.KeyCtrlR, Handler: self.refresh, Description: "Refresh", Category: "Global"},
+		{ID: "global.refresh", Key: gocui.KeyF5, Handler: self.refresh, Description: "Refresh", Category: "Global"},
+		{ID: "global.undo", Key: 'u', Handler: self.undo, Description: "Undo", Category: "Global"},
+		{ID: "global.redo", Key: gocui.KeyCtrlR, Handler: self.redo, Description: "Redo", Category: "Global"},
+		{ID: "global.undo_history", Handler: self.undoHistory, Description: "Undo History", Category: "Global"},
 		{ID: "global.help", Key: '?', Handler: self.onHelp, Description: "Keybindings", Category: "Global", DisplayOnScreen: true, StatusBarLabel: "Keys"},
 		{ID: "global.palette", Key: ':', Handler: self.onPalette}, // no Description = not in palette
 		{ID: "global.quick_open", Key: gocui.KeyCtrlO, Handler: self.onQuickOpen, Description: "Quick Open", Category: "Global"},
 		{ID: "global.calendar", Key: 'c', Handler: self.openCalendar, Description: "Calendar", Category: "Global"},
 		{ID: "global.contrib", Key: 'C', Handler: self.openContrib, Description: "Contributions", Category: "Global"},
 		{ID: "global.scratchpad", Key: 'i', Handler: self.openScratchpad, Description: "Scratchpad", Category: "Global"},
+		{ID: "global.parent_tree", Key: 'H', Handler: self.openParentTree, Description: "Parent Tree", Category: "Global"},
+		{ID: "global.todos", Key: 'A', Handler: self.openTodos, Description: "Todos", Category: "Global"},
+		{ID: "global.trash", Handler: self.openTrash, Description: "Trash", Category: "Global"},
 		{ID: "global.about", Handler: self.showAbout, Description: "About", Category: "Global"},
+		{ID: "global.switch_vault", Handler: self.switchVault, Description: "Switch Vault", Category: "Global"},
 
 		// Focus shortcuts
 		{ID: "global.focus_notes", Key: '1', Handler: self.FocusNotes, Description: "Focus Notes", Category: "Focus"},
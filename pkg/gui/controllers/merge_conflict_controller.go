@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	"github.com/donnellyk/lazyruin/pkg/gui/context"
+	"github.com/donnellyk/lazyruin/pkg/gui/helpers"
+	"github.com/donnellyk/lazyruin/pkg/gui/types"
+	"github.com/donnellyk/lazyruin/pkg/merge"
+
+	"github.com/jesseduffield/gocui"
+)
+
+// MergeConflictController handles keybindings for the conflict resolution popup.
+type MergeConflictController struct {
+	baseController
+	c          *ControllerCommon
+	getContext func() *context.MergeConflictContext
+}
+
+var _ types.IController = &MergeConflictController{}
+
+func NewMergeConflictController(
+	c *ControllerCommon,
+	getContext func() *context.MergeConflictContext,
+) *MergeConflictController {
+	return &MergeConflictController{
+		c:          c,
+		getContext: getContext,
+	}
+}
+
+func (self *MergeConflictController) Context() types.Context {
+	return self.getContext()
+}
+
+func (self *MergeConflictController) mergeConflict() *helpers.MergeConflictHelper {
+	return self.c.Helpers().MergeConflict()
+}
+
+func (self *MergeConflictController) GetMouseKeybindings(opts types.KeybindingsOpts) []*gocui.ViewMouseBinding {
+	return WheelScrollBindings("mergeConflict", func() IGuiCommon { return self.c.GuiCommon() })
+}
+
+func (self *MergeConflictController) GetKeybindings(opts types.KeybindingsOpts) []*types.Binding {
+	return []*types.Binding{
+		{Key: 'j', Handler: self.next},
+		{Key: 'k', Handler: self.prev},
+		{Key: gocui.KeyArrowDown, Handler: self.next},
+		{Key: gocui.KeyArrowUp, Handler: self.prev},
+		{Key: '1', Description: "Keep your edit", Handler: self.chooseOurs},
+		{Key: '2', Description: "Keep on-disk version", Handler: self.chooseTheirs},
+		{Key: '3', Description: "Keep both", Handler: self.chooseBoth},
+		{Key: gocui.KeyEnter, Description: "Save resolved note", Handler: self.mergeConflict().Save},
+		{Key: gocui.KeyEsc, Description: "Back to edit", Handler: self.mergeConflict().Cancel},
+	}
+}
+
+func (self *MergeConflictController) next() error { return self.mergeConflict().Move(1) }
+func (self *MergeConflictController) prev() error { return self.mergeConflict().Move(-1) }
+func (self *MergeConflictController) chooseOurs() error {
+	return self.mergeConflict().Choose(merge.Ours)
+}
+func (self *MergeConflictController) chooseTheirs() error {
+	return self.mergeConflict().Choose(merge.Theirs)
+}
+func (self *MergeConflictController) chooseBoth() error {
+	return self.mergeConflict().Choose(merge.Both)
+}
@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"github.com/donnellyk/lazyruin/pkg/gui/context"
+	"github.com/donnellyk/lazyruin/pkg/gui/helpers"
+	"github.com/donnellyk/lazyruin/pkg/gui/types"
+
+	"github.com/jesseduffield/gocui"
+)
+
+// NoteHistoryController handles keybindings for the note history popup.
+type NoteHistoryController struct {
+	baseController
+	c          *ControllerCommon
+	getContext func() *context.NoteHistoryContext
+}
+
+var _ types.IController = &NoteHistoryController{}
+
+func NewNoteHistoryController(
+	c *ControllerCommon,
+	getContext func() *context.NoteHistoryContext,
+) *NoteHistoryController {
+	return &NoteHistoryController{
+		c:          c,
+		getContext: getContext,
+	}
+}
+
+func (self *NoteHistoryController) Context() types.Context {
+	return self.getContext()
+}
+
+func (self *NoteHistoryController) git() *helpers.GitHelper {
+	return self.c.Helpers().Git()
+}
+
+func (self *NoteHistoryController) GetMouseKeybindings(opts types.KeybindingsOpts) []*gocui.ViewMouseBinding {
+	return WheelScrollBindings("noteHistory", func() IGuiCommon { return self.c.GuiCommon() })
+}
+
+func (self *NoteHistoryController) GetKeybindings(opts types.KeybindingsOpts) []*types.Binding {
+	return []*types.Binding{
+		{Key: 'j', Handler: self.next},
+		{Key: 'k', Handler: self.prev},
+		{Key: gocui.KeyArrowDown, Handler: self.next},
+		{Key: gocui.KeyArrowUp, Handler: self.prev},
+		{Key: 'J', Description: "Scroll diff down", Handler: self.scrollDown},
+		{Key: 'K', Description: "Scroll diff up", Handler: self.scrollUp},
+		{Key: gocui.KeyEnter, Description: "Restore revision", Handler: self.git().Restore},
+		{Key: gocui.KeyEsc, Description: "Close", Handler: self.git().Close},
+	}
+}
+
+func (self *NoteHistoryController) next() error       { return self.git().Move(1) }
+func (self *NoteHistoryController) prev() error       { return self.git().Move(-1) }
+func (self *NoteHistoryController) scrollDown() error { return self.git().ScrollDiff(5) }
+func (self *NoteHistoryController) scrollUp() error   { return self.git().ScrollDiff(-5) }
@@ -135,6 +135,38 @@ func (self *NotesController) GetKeybindings(opts types.KeybindingsOpts) []*types
 			DisplayOnScreen:   true,
 			StatusBarLabel:    "Delete",
 		},
+		{
+			ID:                "notes.merge",
+			Key:               'M',
+			Handler:           self.mergeNotes,
+			GetDisabledReason: self.require(noteAction, self.multipleSelected()),
+			Description:       "Merge Selected Notes",
+			Category:          "Notes",
+		},
+		{
+			ID:                "notes.toggle_select",
+			Key:               gocui.KeySpace,
+			Handler:           self.c.Helpers().Selection().ToggleNote,
+			GetDisabledReason: self.require(noteAction, self.singleItemSelected()),
+			Description:       "Toggle Selection",
+			Category:          "Selection",
+		},
+		{
+			ID:                "notes.range_select",
+			Key:               'V',
+			Handler:           self.c.Helpers().Selection().ToggleRange,
+			GetDisabledReason: self.require(noteAction, self.singleItemSelected()),
+			Description:       "Select Range",
+			Category:          "Selection",
+		},
+		{
+			ID:                "notes.clear_selection",
+			Key:               gocui.KeyEsc,
+			Handler:           self.clearSelection,
+			GetDisabledReason: self.require(noteAction, self.selectionActive()),
+			Description:       "Clear Selection",
+			Category:          "Selection",
+		},
 		{
 			ID:                "notes.copy",
 			Key:               'y',
@@ -442,9 +474,46 @@ func (self *NotesController) editInline(note models.Note) error {
 }
 
 func (self *NotesController) deleteNote(note models.Note) error {
+	ctx := self.getContext()
+	if notes := ctx.SelectedNotes(); len(notes) > 0 {
+		return self.c.Helpers().Notes().DeleteNotes(notes, func(map[string]bool) {
+			ctx.Selection.Clear()
+		})
+	}
 	return self.c.Helpers().Notes().DeleteNote(&note)
 }
 
+func (self *NotesController) mergeNotes() error {
+	ctx := self.getContext()
+	return self.c.Helpers().PreviewMutations().MergeNotes(ctx.SelectedNotes(), func(string, []string, map[string]bool) {
+		ctx.Selection.Clear()
+		self.c.Helpers().Preview().ReloadActivePreview()
+	})
+}
+
+func (self *NotesController) clearSelection() error {
+	self.c.Helpers().Selection().Clear()
+	return nil
+}
+
+func (self *NotesController) selectionActive() func() *types.DisabledReason {
+	return func() *types.DisabledReason {
+		if !self.getContext().Selection.Active() {
+			return &types.DisabledReason{Text: "No notes selected"}
+		}
+		return nil
+	}
+}
+
+func (self *NotesController) multipleSelected() func() *types.DisabledReason {
+	return func() *types.DisabledReason {
+		if len(self.getContext().SelectedNotes()) < 2 {
+			return &types.DisabledReason{Text: "Select at least two notes"}
+		}
+		return nil
+	}
+}
+
 func (self *NotesController) copyPath(note models.Note) error {
 	return self.c.Helpers().Clipboard().CopyToClipboard(note.Path)
 }
@@ -12,6 +12,7 @@ type PaletteController struct {
 	getContext  func() *context.PaletteContext
 	onEnter     func() error
 	onEsc       func() error
+	onSave      func() error
 	onListClick func() error
 	guiCommon   func() IGuiCommon
 }
@@ -23,6 +24,7 @@ type PaletteControllerOpts struct {
 	GetContext  func() *context.PaletteContext
 	OnEnter     func() error
 	OnEsc       func() error
+	OnSave      func() error
 	OnListClick func() error
 	GuiCommon   func() IGuiCommon
 }
@@ -33,6 +35,7 @@ func NewPaletteController(opts PaletteControllerOpts) *PaletteController {
 		getContext:  opts.GetContext,
 		onEnter:     opts.OnEnter,
 		onEsc:       opts.OnEsc,
+		onSave:      opts.OnSave,
 		onListClick: opts.OnListClick,
 		guiCommon:   opts.GuiCommon,
 	}
@@ -48,6 +51,7 @@ func (self *PaletteController) GetKeybindings(opts types.KeybindingsOpts) []*typ
 	return []*types.Binding{
 		{ViewName: "palette", Key: gocui.KeyEnter, Handler: self.onEnter, Description: "Execute"},
 		{ViewName: "palette", Key: gocui.KeyEsc, Handler: self.onEsc, Description: "Cancel"},
+		{ViewName: "palette", Key: gocui.KeyCtrlS, Handler: self.onSave, Description: "Save as Query"},
 	}
 }
 
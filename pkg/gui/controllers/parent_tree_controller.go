@@ -0,0 +1,60 @@
+package controllers
+
+import (
+	"github.com/donnellyk/lazyruin/pkg/gui/context"
+	"github.com/donnellyk/lazyruin/pkg/gui/helpers"
+	"github.com/donnellyk/lazyruin/pkg/gui/types"
+
+	"github.com/jesseduffield/gocui"
+)
+
+// ParentTreeController handles keybindings for the parent tree navigator.
+type ParentTreeController struct {
+	baseController
+	c          *ControllerCommon
+	getContext func() *context.ParentTreeContext
+}
+
+var _ types.IController = &ParentTreeController{}
+
+func NewParentTreeController(
+	c *ControllerCommon,
+	getContext func() *context.ParentTreeContext,
+) *ParentTreeController {
+	return &ParentTreeController{
+		c:          c,
+		getContext: getContext,
+	}
+}
+
+func (self *ParentTreeController) Context() types.Context {
+	return self.getContext()
+}
+
+func (self *ParentTreeController) tree() *helpers.ParentTreeHelper {
+	return self.c.Helpers().ParentTree()
+}
+
+func (self *ParentTreeController) GetMouseKeybindings(opts types.KeybindingsOpts) []*gocui.ViewMouseBinding {
+	return WheelScrollBindings("parentTree", func() IGuiCommon { return self.c.GuiCommon() })
+}
+
+func (self *ParentTreeController) GetKeybindings(opts types.KeybindingsOpts) []*types.Binding {
+	return []*types.Binding{
+		{Key: 'j', Handler: self.next},
+		{Key: 'k', Handler: self.prev},
+		{Key: gocui.KeyArrowDown, Handler: self.next},
+		{Key: gocui.KeyArrowUp, Handler: self.prev},
+		{Key: 'l', Description: "Expand", Handler: self.tree().Expand},
+		{Key: 'h', Description: "Collapse", Handler: self.tree().Collapse},
+		{Key: gocui.KeyArrowRight, Handler: self.tree().Expand},
+		{Key: gocui.KeyArrowLeft, Handler: self.tree().Collapse},
+		{Key: gocui.KeyEnter, Description: "Open", Handler: self.tree().OpenSelected},
+		{Key: 'x', Description: "Cut (move)", Handler: self.tree().Cut},
+		{Key: 'p', Description: "Paste under selected", Handler: self.tree().Paste},
+		{Key: gocui.KeyEsc, Description: "Close", Handler: self.tree().Escape},
+	}
+}
+
+func (self *ParentTreeController) next() error { return self.tree().Move(1) }
+func (self *ParentTreeController) prev() error { return self.tree().Move(-1) }
@@ -71,6 +71,10 @@ func (self *PickResultsController) GetKeybindings(opts types.KeybindingsOpts) []
 			Description:       "Clear Filter", Category: "Preview",
 			DisplayOnScreen: true, StatusBarLabel: "Clear",
 		},
+		&types.Binding{
+			ID: "pickResults.export", Key: 'w',
+			Handler: self.c.Helpers().Export().Export, Description: "Export Results", Category: "Preview",
+		},
 	)
 }
 
@@ -30,6 +30,28 @@ func (t *PreviewNavTrait) NavBindings() []*types.Binding {
 		{Key: '{', Handler: t.nav().PrevHeader},
 		{Key: 'l', Handler: t.links().HighlightNextLink, KeyDisplay: "l/L", Description: "Next/prev link", Category: "Navigation"},
 		{Key: 'L', Handler: t.links().HighlightPrevLink},
+		// Find and match stepping
+		{
+			ID:          "preview.find",
+			Key:         '/',
+			Handler:     t.c.Helpers().PreviewFind().Open,
+			Description: "Find in Preview",
+			Category:    "Navigation",
+		},
+		{
+			ID:          "preview.next_match",
+			Key:         'n',
+			Handler:     t.nextMatch,
+			Description: "Next Match",
+			Category:    "Navigation",
+		},
+		{
+			ID:          "preview.prev_match",
+			Key:         'N',
+			Handler:     t.prevMatch,
+			Description: "Previous Match",
+			Category:    "Navigation",
+		},
 		// Link actions
 		{
 			ID:          "preview.open_link",
@@ -38,6 +60,30 @@ func (t *PreviewNavTrait) NavBindings() []*types.Binding {
 			Description: "Open Link",
 			Category:    "Preview",
 		},
+		{
+			ID:                "preview.backlinks",
+			Key:               'B',
+			Handler:           t.c.Helpers().Backlinks().ShowBacklinks,
+			GetDisabledReason: requireNote(t.preview().CurrentPreviewCard),
+			Description:       "Backlinks",
+			Category:          "Preview",
+		},
+		{
+			ID:                "preview.parent_tree",
+			Key:               'H',
+			Handler:           t.c.Helpers().ParentTree().OpenForCurrentNote,
+			GetDisabledReason: requireNote(t.preview().CurrentPreviewCard),
+			Description:       "Parent Tree From Note",
+			Category:          "Preview",
+		},
+		{
+			ID:                "preview.note_history",
+			Key:               'h',
+			Handler:           t.c.Helpers().Git().OpenNoteHistory,
+			GetDisabledReason: requireNote(t.preview().CurrentPreviewCard),
+			Description:       "Note History (git)",
+			Category:          "Preview",
+		},
 		// Info
 		{
 			ID:          "preview.show_info",
@@ -100,6 +146,24 @@ func (t *PreviewNavTrait) NavBindings() []*types.Binding {
 	}
 }
 
+// nextMatch and prevMatch step through find or search-term matches.
+// Without any they leave the key unhandled, so gocui falls through to
+// whatever the global binding for it is (New Note and New from Template
+// unless remapped).
+func (t *PreviewNavTrait) nextMatch() error {
+	if !t.nav().HasMatches() {
+		return gocui.ErrKeybindingNotHandled
+	}
+	return t.nav().NextMatch()
+}
+
+func (t *PreviewNavTrait) prevMatch() error {
+	if !t.nav().HasMatches() {
+		return gocui.ErrKeybindingNotHandled
+	}
+	return t.nav().PrevMatch()
+}
+
 // BuildPreviewBindings assembles the standard preview binding set: shared nav
 // bindings, line-ops (parameterized by prefix), and any custom bindings.
 func (t *PreviewNavTrait) BuildPreviewBindings(lineOpsPrefix string, custom ...*types.Binding) []*types.Binding {
@@ -0,0 +1,100 @@
+package controllers
+
+import (
+	"github.com/donnellyk/lazyruin/pkg/gui/context"
+	"github.com/donnellyk/lazyruin/pkg/gui/types"
+
+	"github.com/jesseduffield/gocui"
+)
+
+type TodosController struct {
+	baseController
+	c          *ControllerCommon
+	getContext func() *context.TodosContext
+}
+
+var _ types.IController = &TodosController{}
+
+func NewTodosController(
+	c *ControllerCommon,
+	getContext func() *context.TodosContext,
+) *TodosController {
+	return &TodosController{
+		c:          c,
+		getContext: getContext,
+	}
+}
+
+func (self *TodosController) Context() types.Context {
+	return self.getContext()
+}
+
+func (self *TodosController) GetMouseKeybindings(opts types.KeybindingsOpts) []*gocui.ViewMouseBinding {
+	return WheelScrollBindings("todos", func() IGuiCommon { return self.c.GuiCommon() })
+}
+
+func (self *TodosController) GetKeybindings(opts types.KeybindingsOpts) []*types.Binding {
+	return []*types.Binding{
+		{Key: 'j', Handler: self.nextItem},
+		{Key: 'k', Handler: self.prevItem},
+		{Key: gocui.KeyArrowDown, Handler: self.nextItem},
+		{Key: gocui.KeyArrowUp, Handler: self.prevItem},
+		{Key: gocui.KeyEnter, Description: "Go to Source", Handler: self.openSource},
+		{Key: 'x', Description: "Toggle Todo", Handler: self.toggleTodo},
+		{Key: gocui.KeyCtrlD, Description: "Set Date", Handler: self.setDate},
+		{Key: 'g', Description: "Group By", Handler: self.cycleGroupBy},
+		{Key: 'd', Description: "Has Date", Handler: self.toggleHasDate},
+		{Key: 'n', Description: "No Date", Handler: self.toggleNoDate},
+		{Key: 'r', Description: "Recently Done", Handler: self.toggleRecentDone},
+		{Key: gocui.KeyEsc, Description: "Close", Handler: self.close},
+	}
+}
+
+func (self *TodosController) nextItem() error {
+	ctx := self.getContext()
+	ctx.SelectedIdx = ctx.NextItem(ctx.SelectedIdx)
+	return nil
+}
+
+func (self *TodosController) prevItem() error {
+	ctx := self.getContext()
+	ctx.SelectedIdx = ctx.PrevItem(ctx.SelectedIdx)
+	return nil
+}
+
+func (self *TodosController) openSource() error {
+	return self.c.Helpers().Todos().OpenSelected()
+}
+
+func (self *TodosController) toggleTodo() error {
+	return self.c.Helpers().PreviewLineOps().ToggleTodo()
+}
+
+func (self *TodosController) setDate() error {
+	return self.c.Helpers().PreviewLineOps().SetInlineDate()
+}
+
+func (self *TodosController) cycleGroupBy() error {
+	self.c.Helpers().Todos().CycleGroupBy()
+	return nil
+}
+
+func (self *TodosController) toggleHasDate() error {
+	self.c.Helpers().Todos().ToggleHasDate()
+	return nil
+}
+
+func (self *TodosController) toggleNoDate() error {
+	self.c.Helpers().Todos().ToggleNoDate()
+	return nil
+}
+
+func (self *TodosController) toggleRecentDone() error {
+	self.c.Helpers().Todos().ToggleRecentDone()
+	return nil
+}
+
+func (self *TodosController) close() error {
+	self.c.Helpers().Todos().Close()
+	return nil
+}
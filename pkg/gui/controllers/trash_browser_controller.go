@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"github.com/donnellyk/lazyruin/pkg/gui/context"
+	"github.com/donnellyk/lazyruin/pkg/gui/types"
+
+	"github.com/jesseduffield/gocui"
+)
+
+type TrashBrowserController struct {
+	baseController
+	c          *ControllerCommon
+	getContext func() *context.TrashBrowserContext
+}
+
+var _ types.IController = &TrashBrowserController{}
+
+func NewTrashBrowserController(
+	c *ControllerCommon,
+	getContext func() *context.TrashBrowserContext,
+) *TrashBrowserController {
+	return &TrashBrowserController{
+		c:          c,
+		getContext: getContext,
+	}
+}
+
+func (self *TrashBrowserController) Context() types.Context {
+	return self.getContext()
+}
+
+func (self *TrashBrowserController) GetMouseKeybindings(opts types.KeybindingsOpts) []*gocui.ViewMouseBinding {
+	return WheelScrollBindings("trashBrowser", func() IGuiCommon { return self.c.GuiCommon() })
+}
+
+func (self *TrashBrowserController) GetKeybindings(opts types.KeybindingsOpts) []*types.Binding {
+	return []*types.Binding{
+		{Key: 'j', Handler: self.nextItem},
+		{Key: 'k', Handler: self.prevItem},
+		{Key: gocui.KeyArrowDown, Handler: self.nextItem},
+		{Key: gocui.KeyArrowUp, Handler: self.prevItem},
+		{Key: gocui.KeyEnter, Description: "Restore", Handler: self.restoreItem},
+		{Key: 'd', Description: "Purge", Handler: self.purgeItem},
+		{Key: gocui.KeyEsc, Description: "Close", Handler: self.close},
+	}
+}
+
+func (self *TrashBrowserController) nextItem() error {
+	ctx := self.getContext()
+	if ctx.SelectedIdx < len(ctx.Items)-1 {
+		ctx.SelectedIdx++
+	}
+	return nil
+}
+
+func (self *TrashBrowserController) prevItem() error {
+	ctx := self.getContext()
+	if ctx.SelectedIdx > 0 {
+		ctx.SelectedIdx--
+	}
+	return nil
+}
+
+func (self *TrashBrowserController) restoreItem() error {
+	ctx := self.getContext()
+	if len(ctx.Items) == 0 {
+		return nil
+	}
+	return self.c.Helpers().Trash().RestoreItem(ctx.Items[ctx.SelectedIdx])
+}
+
+func (self *TrashBrowserController) purgeItem() error {
+	ctx := self.getContext()
+	if len(ctx.Items) == 0 {
+		return nil
+	}
+	item := ctx.Items[ctx.SelectedIdx]
+	self.c.GuiCommon().ShowConfirm("Purge from trash?", item.Title+" will be deleted permanently.", func() error {
+		self.c.Helpers().Trash().PurgeItem(item.ID)
+		return nil
+	})
+	return nil
+}
+
+func (self *TrashBrowserController) close() error {
+	self.c.GuiCommon().PopContext()
+	return nil
+}
@@ -0,0 +1,109 @@
+package gui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/donnellyk/lazyruin/pkg/config"
+	"github.com/donnellyk/lazyruin/pkg/gui/helpers"
+	"github.com/donnellyk/lazyruin/pkg/gui/types"
+)
+
+// customCommandCategory groups custom commands in the palette and help.
+const customCommandCategory = "Custom Commands"
+
+// customCommandBindings returns the bindings for the `custom_commands:`
+// entries that belong to the context key. Entries without a key are
+// palette-only. contextBindings appends them after the controller's own,
+// so every binding consumer picks them up.
+func (gui *Gui) customCommandBindings(key types.ContextKey) []*types.Binding {
+	if gui.config == nil {
+		return nil
+	}
+	var bindings []*types.Binding
+	for _, cc := range gui.config.CustomCommands {
+		if cc.Context != string(key) || cc.Command == "" {
+			continue
+		}
+		var k any
+		if cc.Key != "" {
+			parsed, _, err := parseKeySpec(cc.Key)
+			if err != nil {
+				continue
+			}
+			k = parsed
+		}
+		bindings = append(bindings, &types.Binding{
+			ID:          string(key) + ".custom." + customCommandSlug(cc),
+			Key:         k,
+			Description: customCommandName(cc),
+			Category:    customCommandCategory,
+			Handler:     func() error { return gui.helpers.CustomCommands().Run(cc) },
+		})
+	}
+	return bindings
+}
+
+func customCommandName(cc config.CustomCommand) string {
+	if cc.Name != "" {
+		return cc.Name
+	}
+	return cc.Command
+}
+
+// customCommandSlug turns a command's name into a binding ID suffix.
+func customCommandSlug(cc config.CustomCommand) string {
+	return strings.Join(strings.Fields(strings.ToLower(customCommandName(cc))), "_")
+}
+
+// customCommandProblems validates the `custom_commands:` section: unknown
+// contexts, unparseable keys and templates, unknown output modes, and
+// keys already taken by a built-in binding in the same context.
+func (gui *Gui) customCommandProblems() []string {
+	if gui.config == nil {
+		return nil
+	}
+	contexts := map[string]types.Context{}
+	for _, ctx := range gui.contexts.All() {
+		contexts[string(ctx.GetKey())] = ctx
+	}
+
+	var problems []string
+	for i, cc := range gui.config.CustomCommands {
+		where := fmt.Sprintf("custom_commands[%d]", i)
+		if cc.Name != "" {
+			where = fmt.Sprintf("custom_commands %q", cc.Name)
+		}
+		if cc.Command == "" {
+			problems = append(problems, where+": no command")
+		} else if _, err := helpers.ParseCustomCommand(cc.Command); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", where, err))
+		}
+		switch cc.Output {
+		case "", config.CustomCommandOutputSuspend, config.CustomCommandOutputBackground, config.CustomCommandOutputPopup:
+		default:
+			problems = append(problems, fmt.Sprintf("%s: unknown output %q", where, cc.Output))
+		}
+		ctx, ok := contexts[cc.Context]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s: unknown context %q", where, cc.Context))
+			continue
+		}
+		if cc.Key == "" {
+			continue
+		}
+		key, mod, err := parseKeySpec(cc.Key)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", where, err))
+			continue
+		}
+		builtin := applyOverrides(ctx.GetKeybindings(types.KeybindingsOpts{}), gui.keybindingOverrides(ctx.GetKey()))
+		for _, b := range builtin {
+			if b.Key == key && b.Mod == mod && b.ViewName == "" {
+				problems = append(problems, fmt.Sprintf("%s: %s is already bound in %s", where, keyDisplayString(key), cc.Context))
+				break
+			}
+		}
+	}
+	return problems
+}
@@ -0,0 +1,94 @@
+package gui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/donnellyk/lazyruin/pkg/config"
+)
+
+func TestCustomCommands_BindingsAndPalette(t *testing.T) {
+	tg := newTestGuiWithOpts(t, defaultMock(), testGuiOpts{CustomCommands: []config.CustomCommand{
+		{Key: "X", Context: "notes", Name: "Publish Note", Command: "publish {{quote .Note.Path}}"},
+		{Context: "tags", Name: "Count Tag", Command: "grep -c {{.Tag.Name}}"},
+	}})
+	defer tg.Close()
+
+	b := bindingByID(tg.gui.contextBindings(tg.gui.contexts.Notes), "notes.custom.publish_note")
+	if b == nil || b.Key != 'X' || b.Category != customCommandCategory {
+		t.Fatalf("notes custom binding = %+v", b)
+	}
+	if b := bindingByID(tg.gui.contextBindings(tg.gui.contexts.Tags), "tags.custom.count_tag"); b == nil || b.Key != nil {
+		t.Errorf("keyless custom command should be palette-only, got %+v", b)
+	}
+
+	var found bool
+	for _, cmd := range tg.gui.paletteCommands() {
+		if cmd.Name == "Publish Note" && cmd.Key == "X" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("custom command should be listed in the palette")
+	}
+	if tg.gui.state.StartupWarning != "" {
+		t.Errorf("unexpected startup warning: %q", tg.gui.state.StartupWarning)
+	}
+}
+
+func TestCustomCommands_ReportsProblems(t *testing.T) {
+	tg := newTestGuiWithOpts(t, defaultMock(), testGuiOpts{CustomCommands: []config.CustomCommand{
+		{Key: "d", Context: "notes", Name: "Clash", Command: "true"},
+		{Context: "nowhere", Name: "Lost", Command: "{{.Note.Path", Output: "printer"},
+	}})
+	defer tg.Close()
+
+	problems := strings.Join(tg.gui.KeybindingProblems(), "\n")
+	for _, want := range []string{
+		`custom_commands "Clash": d is already bound in notes`,
+		`custom_commands "Lost": unknown context "nowhere"`,
+		`custom_commands "Lost": unknown output "printer"`,
+		`custom_commands "Lost": template:`,
+	} {
+		if !strings.Contains(problems, want) {
+			t.Errorf("problems missing %q:\n%s", want, problems)
+		}
+	}
+}
+
+func TestCustomCommands_PromptThenPopupOutput(t *testing.T) {
+	mock := defaultMock().WithVaultPath(t.TempDir())
+	tg := newTestGuiWithOpts(t, mock, testGuiOpts{CustomCommands: []config.CustomCommand{{
+		Key:     "X",
+		Context: "notes",
+		Name:    "Greet",
+		Command: "echo {{quote .Note.Title}} {{quote .Form.Greeting}}",
+		Output:  config.CustomCommandOutputPopup,
+		Prompts: []config.CustomCommandPrompt{{Key: "Greeting", Title: "Say"}},
+	}}})
+	defer tg.Close()
+
+	tg.gui.globalController.FocusNotes()
+	note := tg.gui.contexts.Notes.Selected()
+	if note == nil {
+		t.Fatal("no note selected")
+	}
+	b := bindingByID(tg.gui.contextBindings(tg.gui.contexts.Notes), "notes.custom.greet")
+	if err := b.Handler(); err != nil {
+		t.Fatal(err)
+	}
+	if tg.gui.contextMgr.Current() != "inputPopup" || tg.gui.contexts.InputPopup.Config.Title != "Say" {
+		t.Fatalf("expected the prompt popup, current context %v", tg.gui.contextMgr.Current())
+	}
+	if err := tg.gui.helpers.InputPopup().HandleEnter("hello", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	d := tg.gui.state.Dialog
+	if d == nil || d.Type != "menu" || d.Title != "Greet" {
+		t.Fatalf("expected an output popup, got %+v", d)
+	}
+	if len(d.MenuItems) != 1 || d.MenuItems[0].Label != note.Title+" hello" {
+		t.Errorf("output = %+v, want %q", d.MenuItems, note.Title+" hello")
+	}
+}
@@ -59,12 +59,12 @@ func renderDateNoteList(v *gocui.View, notes []models.Note, selectedIndex int, f
 		selected := focused && i == selectedIndex
 		if selected {
 			for _, line := range lines {
-				fmt.Fprintf(v, "%s%s%s\n", AnsiBlueBgWhite, pad(line), AnsiReset)
+				fmt.Fprintf(v, "%s%s%s\n", theme.Selected, pad(line), AnsiReset)
 			}
 		} else {
 			fmt.Fprintln(v, lines[0])
 			for _, line := range lines[1:] {
-				fmt.Fprintf(v, "%s%s%s\n", AnsiDim, line, AnsiReset)
+				fmt.Fprintf(v, "%s%s%s\n", theme.Muted, line, AnsiReset)
 			}
 		}
 	}
@@ -189,8 +189,8 @@ func (gui *Gui) createConfirmDialog(g *gocui.Gui, maxX, maxY int) error {
 		v.Footer = " [y] Yes · [n/Esc] No "
 	}
 	setRoundedCorners(v)
-	v.FrameColor = gocui.ColorGreen
-	v.TitleColor = gocui.ColorGreen
+	v.FrameColor = theme.FrameActive
+	v.TitleColor = theme.FrameActive
 	v.Clear()
 
 	if gui.state.Dialog.Hero {
@@ -242,8 +242,8 @@ func (gui *Gui) createInputDialog(g *gocui.Gui, maxX, maxY int) error {
 	v.Title = " " + gui.state.Dialog.Title + " "
 	v.Editable = true
 	setRoundedCorners(v)
-	v.FrameColor = gocui.ColorGreen
-	v.TitleColor = gocui.ColorGreen
+	v.FrameColor = theme.FrameActive
+	v.TitleColor = theme.FrameActive
 	v.Clear()
 
 	fmt.Fprintln(v, "")
@@ -373,8 +373,8 @@ func (gui *Gui) createMenuDialog(g *gocui.Gui, maxX, maxY int) error {
 	}
 	v.Highlight = false
 	setRoundedCorners(v)
-	v.FrameColor = gocui.ColorGreen
-	v.TitleColor = gocui.ColorGreen
+	v.FrameColor = theme.FrameActive
+	v.TitleColor = theme.FrameActive
 	v.Clear()
 
 	innerWidth, _ := v.InnerSize()
@@ -385,11 +385,11 @@ func (gui *Gui) createMenuDialog(g *gocui.Gui, maxX, maxY int) error {
 	for i, item := range items {
 		// Header items
 		if item.IsHeader {
-			header := fmt.Sprintf(" %s--- %s ---%s", AnsiCyan, item.Label, AnsiReset)
+			header := fmt.Sprintf(" %s--- %s ---%s", theme.Accent, item.Label, AnsiReset)
 			if item.Hint != "" {
 				visible := len(item.Label) + 10 // " --- Label --- "
 				pad := max(innerWidth-visible-len(item.Hint), 2)
-				header += fmt.Sprintf("%s%s%s%s", strings.Repeat(" ", pad), AnsiDim, item.Hint, AnsiReset)
+				header += fmt.Sprintf("%s%s%s%s", strings.Repeat(" ", pad), theme.Muted, item.Hint, AnsiReset)
 			}
 			fmt.Fprintln(v, header)
 			continue
@@ -411,10 +411,10 @@ func (gui *Gui) createMenuDialog(g *gocui.Gui, maxX, maxY int) error {
 			if pad > 0 {
 				line += strings.Repeat(" ", pad)
 			}
-			fmt.Fprintf(v, "%s%s%s\n", AnsiBlueBgWhite, line, AnsiReset)
+			fmt.Fprintf(v, "%s%s%s\n", theme.Selected, line, AnsiReset)
 		} else {
 			if item.Key != "" {
-				fmt.Fprintf(v, " %s%-*s%s  %s\n", AnsiGreen, maxKeyLen, item.Key, AnsiReset, item.Label)
+				fmt.Fprintf(v, " %s%-*s%s  %s\n", theme.Key, maxKeyLen, item.Key, AnsiReset, item.Label)
 			} else {
 				fmt.Fprintf(v, " %s  %s\n", strings.Repeat(" ", maxKeyLen), item.Label)
 			}
@@ -459,8 +459,8 @@ func (gui *Gui) createAboutDialog(g *gocui.Gui, maxX, maxY int) error {
 	}
 
 	setRoundedCorners(v)
-	v.FrameColor = gocui.ColorGreen
-	v.TitleColor = gocui.ColorGreen
+	v.FrameColor = theme.FrameActive
+	v.TitleColor = theme.FrameActive
 	v.Clear()
 
 	innerW, _ := v.InnerSize()
@@ -504,6 +504,9 @@ func (gui *Gui) aboutInfoLines() []string {
 		if gui.config.ChromaTheme != "" {
 			cfgLines = append(cfgLines, "chroma_theme: "+gui.config.ChromaTheme)
 		}
+		if gui.config.Theme.Preset != "" {
+			cfgLines = append(cfgLines, "theme: "+gui.config.Theme.Preset)
+		}
 		if gui.config.ViewOptions.HideDone {
 			cfgLines = append(cfgLines, "hide_done: true")
 		}
@@ -22,11 +22,20 @@ type completionEditor struct {
 	state      func() *types.CompletionState
 	triggers   func() []types.CompletionTrigger
 	drillFlags DrillFlags
+	history    func() string // search history kind Up/Down recall from; nil or "" = none
 }
 
 func (e *completionEditor) Edit(v *gocui.View, key gocui.Key, ch rune, mod gocui.Modifier) bool {
 	state := e.state()
 
+	// With no suggestions open, Up/Down step through earlier queries
+	if !state.Active && e.history != nil && (key == gocui.KeyArrowUp || key == gocui.KeyArrowDown) {
+		if kind := e.history(); kind != "" {
+			e.gui.recallHistory(v, kind, key == gocui.KeyArrowUp)
+			return true
+		}
+	}
+
 	triggers := e.triggers()
 	if triggers == nil {
 		// No triggers configured — fall back to raw text editing
@@ -59,3 +68,15 @@ func (e *completionEditor) Edit(v *gocui.View, key gocui.Key, ch rune, mod gocui
 
 	return handled
 }
+
+// recallHistory replaces v's text with the next older (or newer) history
+// entry of kind.
+func (gui *Gui) recallHistory(v *gocui.View, kind string, older bool) {
+	text, ok := gui.helpers.SearchHistory().Recall(kind, v.TextArea.GetUnwrappedContent(), older)
+	if !ok {
+		return
+	}
+	v.TextArea.Clear()
+	v.TextArea.TypeString(text)
+	v.RenderTextArea()
+}
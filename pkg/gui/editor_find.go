@@ -0,0 +1,20 @@
+package gui
+
+import (
+	"github.com/jesseduffield/gocui"
+)
+
+// findEditor delegates to SimpleEditor for text input and re-runs the
+// preview find whenever the text changes, so matches follow the typing.
+type findEditor struct {
+	gui *Gui
+}
+
+func (e *findEditor) Edit(v *gocui.View, key gocui.Key, ch rune, mod gocui.Modifier) bool {
+	before := v.TextArea.GetUnwrappedContent()
+	handled := gocui.SimpleEditor(v, key, ch, mod)
+	if after := v.TextArea.GetUnwrappedContent(); after != before {
+		e.gui.helpers.PreviewFind().Update(after)
+	}
+	return handled
+}
@@ -0,0 +1,106 @@
+package gui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/donnellyk/lazyruin/pkg/models"
+)
+
+// exportTo runs the preview's export binding, picks menu item idx and
+// accepts path in the file prompt.
+func exportTo(t *testing.T, tg *testGui, bindingID string, idx int, path string) {
+	t.Helper()
+	b := bindingByID(tg.gui.contextBindings(tg.gui.contexts.ActivePreview()), bindingID)
+	if b == nil {
+		t.Fatalf("%s not bound", bindingID)
+	}
+	if err := b.Handler(); err != nil {
+		t.Fatal(err)
+	}
+	d := tg.gui.state.Dialog
+	if d == nil || d.Type != "menu" || len(d.MenuItems) != 4 {
+		t.Fatalf("dialog = %+v, want the four-format export menu", d)
+	}
+	tg.gui.state.Dialog = nil
+	if err := d.MenuItems[idx].OnRun(); err != nil {
+		t.Fatal(err)
+	}
+	if tg.gui.contextMgr.Current() != "inputPopup" {
+		t.Fatalf("current context = %v, want the file prompt", tg.gui.contextMgr.Current())
+	}
+	if err := tg.gui.helpers.InputPopup().HandleEnter(path, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExport_ComposeKeepsSourceAnchors(t *testing.T) {
+	fx := newComposeFixture(t)
+	tg := newTestGuiWithOpts(t, fx.mock, testGuiOpts{OpenRef: "journal"})
+	defer tg.Close()
+	if tg.gui.contextMgr.Current() != "compose" {
+		t.Fatalf("precondition: CurrentContext = %v, want compose", tg.gui.contextMgr.Current())
+	}
+
+	path := filepath.Join(t.TempDir(), "journal.md")
+	exportTo(t, tg, "compose.export", 1, path)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(data)
+	for _, want := range []string{
+		"---\ntitle: Daily Journal\n",
+		"sources:\n    - parent-1\n    - child-a-uuid\n    - child-b-uuid\n---\n",
+		"# Daily Journal\n",
+		"<!-- source: child-a-uuid " + fx.childAPath + " -->\nContent line A1\n- Task A #todo\nContent line A3\n",
+		"<!-- source: child-b-uuid " + fx.childBPath + " -->\n## Child B Title\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("export missing %q:\n%s", want, got)
+		}
+	}
+
+	exportTo(t, tg, "compose.export", 2, path)
+	d := tg.gui.state.Dialog
+	if d == nil || d.OnConfirm == nil {
+		t.Fatal("exporting over an existing file should ask first")
+	}
+	if err := d.OnConfirm(); err != nil {
+		t.Fatal(err)
+	}
+	data, _ = os.ReadFile(path)
+	if !strings.HasPrefix(string(data), "<!DOCTYPE html>") {
+		t.Errorf("confirming should overwrite with the HTML export, got %.40q", data)
+	}
+}
+
+func TestExport_PickResultsAsListsPerNote(t *testing.T) {
+	mock := defaultMock().WithPickResults(
+		models.PickResult{UUID: "1", Title: "Note One", File: "one.md", Matches: []models.PickMatch{
+			{Line: 3, Content: "plain line #work"},
+			{Line: 4, Content: "- [ ] todo #work"},
+		}},
+	)
+	tg := newTestGui(t, mock)
+	defer tg.Close()
+	if err := tg.gui.helpers.Pick().OpenPick(); err != nil {
+		t.Fatal(err)
+	}
+	if err := tg.gui.helpers.Pick().ExecutePick("#work"); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "work.md")
+	exportTo(t, tg, "pickResults.export", 0, path)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "<!-- source: 1 one.md -->\n## Note One\n\n- plain line #work\n- [ ] todo #work\n"
+	if !strings.Contains(string(data), want) || strings.HasPrefix(string(data), "---") {
+		t.Errorf("export =\n%s\nwant it to contain\n%s", data, want)
+	}
+}
@@ -5,11 +5,15 @@ import (
 
 	"github.com/donnellyk/lazyruin/pkg/commands"
 	"github.com/donnellyk/lazyruin/pkg/config"
+	"github.com/donnellyk/lazyruin/pkg/frecency"
 	"github.com/donnellyk/lazyruin/pkg/gui/context"
 	"github.com/donnellyk/lazyruin/pkg/gui/controllers"
 	helperspkg "github.com/donnellyk/lazyruin/pkg/gui/helpers"
 	"github.com/donnellyk/lazyruin/pkg/gui/types"
+	"github.com/donnellyk/lazyruin/pkg/remote"
+	"github.com/donnellyk/lazyruin/pkg/vaultwatch"
 
+	"github.com/alecthomas/chroma/v2"
 	"github.com/jesseduffield/gocui"
 	"github.com/muesli/termenv"
 )
@@ -22,12 +26,17 @@ type Gui struct {
 	config         *config.Config
 	ruinCmd        *commands.RuinCommand
 	stopBg         chan struct{}
-	QuickCapture   bool   // when true, open capture on start and quit on save
-	QuickLink      bool   // when true, open link input on start and quit on save
-	QuickLinkURL   string // when set with QuickLink, skip input popup and resolve directly
-	OpenRef        string // note path/title or parent bookmark to open on launch
-	VaultSource    string // human-readable label for how the vault path was resolved
+	stopWatch      chan struct{}
+	remoteServer   *remote.Server
+	frecency       *frecency.Store // loaded when the palette opens; nil without a vault
+	QuickCapture   bool            // when true, open capture on start and quit on save
+	QuickLink      bool            // when true, open link input on start and quit on save
+	QuickLinkURL   string          // when set with QuickLink, skip input popup and resolve directly
+	OpenRef        string          // note path/title or parent bookmark to open on launch
+	RemoteRequest  *remote.Request // --remote command to run on launch when no instance was listening
+	VaultSource    string          // human-readable label for how the vault path was resolved
 	darkBackground bool
+	chromaStyle    *chroma.Style // preview highlight style; built by highlightStyle
 
 	// New controller/context architecture (Phase 2+)
 	contexts          *context.ContextTree
@@ -45,6 +54,10 @@ type Gui struct {
 	// applicable (first launch, dev build, no pending entries). Set
 	// by app.Run before gui.Run().
 	migrations *helperspkg.MigrationsHelper
+
+	// OnVaultSwitch is called after a runtime vault switch so the app
+	// can recompute migrations for the new vault. Optional.
+	OnVaultSwitch func(cmd *commands.RuinCommand)
 }
 
 // NewGui creates a new Gui instance.
@@ -86,6 +99,12 @@ func NewGui(cfg *config.Config, ruinCmd *commands.RuinCommand) *Gui {
 	gui.setupContribContext()
 	gui.setupPickDialogContext()
 	gui.setupScratchpadBrowserContext()
+	gui.setupTrashBrowserContext()
+	gui.setupTodosContext()
+	gui.setupParentTreeContext()
+	gui.setupNoteHistoryContext()
+	gui.setupMergeConflictContext()
+	gui.setupPreviewFindContext()
 	gui.helpers.Scratchpad().SetTriggers(gui.scratchpadTriggers)
 	return gui
 }
@@ -107,6 +126,7 @@ func (gui *Gui) SetMigrationsHelper(h *helperspkg.MigrationsHelper) {
 func (gui *Gui) Run() error {
 	// Detect terminal background before gocui takes over the terminal.
 	gui.darkBackground = termenv.HasDarkBackground()
+	gui.applyTheme()
 
 	err := gui.runMainLoop()
 	if err != nil && err != gocui.ErrQuit {
@@ -144,23 +164,91 @@ func (gui *Gui) runMainLoop() error {
 		return err
 	}
 
+	// From here on gocui can deliver Update callbacks, so async tasks may
+	// leave the main goroutine.
+	gui.helpers.Async().SetBackground(true)
+	defer gui.helpers.Async().SetBackground(false)
+
 	gui.stopBg = make(chan struct{})
-	go gui.backgroundRefresh()
+	gui.startWatcher()
+	gui.startRemote()
 	go gui.startupWarningTimer()
 
 	err = g.MainLoop()
+	gui.saveSession()
+	gui.helpers.Git().WaitCommits()
+	gui.stopRemote()
+	gui.stopWatcher()
 	close(gui.stopBg)
 	return err
 }
 
-// backgroundRefresh polls for external changes every 30 seconds.
-func (gui *Gui) backgroundRefresh() {
+// startWatcher starts watching the active vault. It runs until quit, or
+// until a vault switch stops it and starts one for the new vault.
+func (gui *Gui) startWatcher() {
+	gui.stopWatch = make(chan struct{})
+	go gui.watchVault(gui.ruinCmd, gui.stopWatch)
+}
+
+// stopWatcher stops the watcher started by startWatcher, if any.
+func (gui *Gui) stopWatcher() {
+	if gui.stopWatch != nil {
+		close(gui.stopWatch)
+		gui.stopWatch = nil
+	}
+}
+
+// watchVault refreshes when note files in the vault change on disk, e.g.
+// edits from another editor or a sync client. Each debounced batch is
+// reindexed with `ruin doctor <path>` on this goroutine, then the sidebars
+// and any preview showing a touched file are refreshed on the main loop.
+// Falls back to the 30-second poll when the watcher cannot start (most
+// often an exhausted inotify watch limit on a large vault).
+func (gui *Gui) watchVault(cmd *commands.RuinCommand, stop <-chan struct{}) {
+	w, err := vaultwatch.New(cmd.VaultPath(), vaultwatch.DefaultDebounce)
+	if err != nil {
+		gui.backgroundRefresh(stop)
+		return
+	}
+	defer w.Close()
+
+	w.Run(stop, func(batch vaultwatch.Batch) {
+		for _, path := range batch.Changed {
+			_ = cmd.Doctor(path)
+		}
+		// `doctor <path>` needs the file on disk; a full scan is the
+		// only way to drop index entries for deleted notes.
+		if len(batch.Removed) > 0 {
+			_ = cmd.DoctorFullScan()
+		}
+		paths := append(batch.Changed, batch.Removed...)
+		gui.g.Update(func(g *gocui.Gui) error {
+			gui.refreshChangedFiles(paths)
+			return nil
+		})
+	})
+}
+
+// refreshChangedFiles applies a watcher batch: sidebar lists are reloaded
+// with selection preserved, and the preview is reloaded only when it shows
+// one of paths. ReloadActivePreview keeps the cursor on the same source
+// line.
+func (gui *Gui) refreshChangedFiles(paths []string) {
+	gui.backgroundRefreshData()
+	if gui.helpers.Preview().ShowsAnyPath(paths) {
+		gui.helpers.Preview().ReloadActivePreview()
+	}
+}
+
+// backgroundRefresh polls for external changes every 30 seconds. Only used
+// when the vault watcher is unavailable.
+func (gui *Gui) backgroundRefresh(stop <-chan struct{}) {
 	ticker := time.NewTicker(30 * time.Second)
 	defer ticker.Stop()
 
 	for {
 		select {
-		case <-gui.stopBg:
+		case <-stop:
 			return
 		case <-ticker.C:
 			gui.g.Update(func(g *gocui.Gui) error {
@@ -292,6 +380,15 @@ func (gui *Gui) SetStartupWarning(msg string) {
 	gui.state.StartupWarning = msg
 }
 
+// appendStartupWarning adds msg to any startup warning already recorded,
+// so config problems don't hide the ruin version warning.
+func (gui *Gui) appendStartupWarning(msg string) {
+	if gui.state.StartupWarning != "" {
+		msg = gui.state.StartupWarning + "; " + msg
+	}
+	gui.state.StartupWarning = msg
+}
+
 // DismissStartupWarning clears any startup warning so it stops rendering.
 func (gui *Gui) DismissStartupWarning() {
 	if gui.state.StartupWarning == "" {
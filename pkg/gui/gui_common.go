@@ -131,7 +131,10 @@ func (gui *Gui) PreviousContextKey() types.ContextKey {
 // isPreviewActive returns true if any preview context (cardList, pickResults,
 // compose) is the active context.
 func (gui *Gui) isPreviewActive() bool {
-	return context.IsPreviewContextKey(gui.contextMgr.Current())
+	// The find bar works on the preview in place, so the preview stays
+	// active underneath it.
+	key := gui.contextMgr.Current()
+	return context.IsPreviewContextKey(key) || key == "previewFind"
 }
 
 // RenderPickDialog renders the pick dialog overlay contents.
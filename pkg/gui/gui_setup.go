@@ -163,6 +163,19 @@ func (gui *Gui) setupPreviewContext() {
 	)
 
 	gui.seedPreviewDisplayStateFromConfig()
+	for _, ctx := range gui.previewContexts() {
+		ctx.SetSourceResolver(gui.helpers.Preview())
+	}
+}
+
+// previewContexts returns the four preview contexts.
+func (gui *Gui) previewContexts() []context.IPreviewContext {
+	return []context.IPreviewContext{
+		gui.contexts.CardList,
+		gui.contexts.PickResults,
+		gui.contexts.Compose,
+		gui.contexts.DatePreview,
+	}
 }
 
 // seedPreviewDisplayStateFromConfig applies persisted view options to every
@@ -173,12 +186,7 @@ func (gui *Gui) seedPreviewDisplayStateFromConfig() {
 		return
 	}
 	hide := gui.config.ViewOptions.HideDone
-	for _, ctx := range []context.IPreviewContext{
-		gui.contexts.CardList,
-		gui.contexts.PickResults,
-		gui.contexts.Compose,
-		gui.contexts.DatePreview,
-	} {
+	for _, ctx := range gui.previewContexts() {
 		if ctx == nil {
 			continue
 		}
@@ -237,6 +245,29 @@ func (gui *Gui) setupSearchContext() {
 				raw := strings.TrimSpace(gui.views.Search.TextArea.GetUnwrappedContent())
 				return searchHelper().PromptSaveQuery(raw)
 			}},
+			{Key: gocui.KeyCtrlR, Description: "History", Handler: func() error {
+				if gui.contexts.Search.InFilterMode() {
+					return nil
+				}
+				searchHelper().CancelSearch()
+				return gui.helpers.SearchHistory().OpenBrowser()
+			}},
+		},
+	)
+}
+
+// setupPreviewFindContext initializes the "previewFind" bar and its popup
+// controller.
+func (gui *Gui) setupPreviewFindContext() {
+	findHelper := func() *helperspkg.PreviewFindHelper { return gui.helpers.PreviewFind() }
+
+	registerPopupContext(gui, context.NewPreviewFindContext(),
+		func(ctx *context.PreviewFindContext) { gui.contexts.PreviewFind = ctx },
+		[]*types.Binding{
+			{Key: gocui.KeyEnter, Description: "Done", Handler: func() error { return findHelper().Confirm() }},
+			{Key: gocui.KeyEsc, Description: "Clear", Handler: func() error { return findHelper().Cancel() }},
+			{Key: gocui.KeyCtrlR, Description: "Regex", Handler: func() error { return findHelper().ToggleRegex() }},
+			{Key: gocui.KeyCtrlA, Description: "Match case", Handler: func() error { return findHelper().ToggleCaseSensitive() }},
 		},
 	)
 }
@@ -315,6 +346,12 @@ func (gui *Gui) setupPickContext() {
 				}
 				return nil
 			}},
+			{Key: gocui.KeyCtrlR, Description: "History", Handler: func() error {
+				if err := gui.helpers.Pick().CancelPick(); err != nil {
+					return err
+				}
+				return gui.helpers.SearchHistory().OpenBrowser()
+			}},
 		},
 	)
 }
@@ -421,6 +458,7 @@ func (gui *Gui) setupPaletteContext() {
 		GetContext:  func() *context.PaletteContext { return gui.contexts.Palette },
 		OnEnter:     func() error { return gui.paletteEnter(gui.g, nil) },
 		OnEsc:       func() error { return gui.paletteEsc(gui.g, nil) },
+		OnSave:      gui.savePaletteEntry,
 		OnListClick: func() error { return gui.paletteListClick(gui.g, nil) },
 		GuiCommon:   func() controllers.IGuiCommon { return gui },
 	})
@@ -465,6 +503,45 @@ func (gui *Gui) setupScratchpadBrowserContext() {
 	controllers.AttachController(ctrl)
 }
 
+// setupTrashBrowserContext initializes the trash browser context and controller.
+func (gui *Gui) setupTrashBrowserContext() {
+	trashCtx := context.NewTrashBrowserContext()
+	gui.contexts.TrashBrowser = trashCtx
+	gui.contextMgr.Register(trashCtx)
+
+	ctrl := controllers.NewTrashBrowserController(
+		gui.controllerCommon,
+		func() *context.TrashBrowserContext { return gui.contexts.TrashBrowser },
+	)
+	controllers.AttachController(ctrl)
+}
+
+// setupTodosContext initializes the Todos dashboard context and controller.
+func (gui *Gui) setupTodosContext() {
+	todosCtx := context.NewTodosContext()
+	gui.contexts.Todos = todosCtx
+	gui.contextMgr.Register(todosCtx)
+
+	ctrl := controllers.NewTodosController(
+		gui.controllerCommon,
+		func() *context.TodosContext { return gui.contexts.Todos },
+	)
+	controllers.AttachController(ctrl)
+}
+
+// setupParentTreeContext initializes the parent tree navigator context and controller.
+func (gui *Gui) setupParentTreeContext() {
+	treeCtx := context.NewParentTreeContext()
+	gui.contexts.ParentTree = treeCtx
+	gui.contextMgr.Register(treeCtx)
+
+	ctrl := controllers.NewParentTreeController(
+		gui.controllerCommon,
+		func() *context.ParentTreeContext { return gui.contexts.ParentTree },
+	)
+	controllers.AttachController(ctrl)
+}
+
 // setupContribContext initializes the ContribContext and ContribController.
 func (gui *Gui) setupContribContext() {
 	contribCtx := context.NewContribContext()
@@ -477,3 +554,29 @@ func (gui *Gui) setupContribContext() {
 	})
 	controllers.AttachController(ctrl)
 }
+
+// setupNoteHistoryContext initializes the note history context and controller.
+func (gui *Gui) setupNoteHistoryContext() {
+	historyCtx := context.NewNoteHistoryContext()
+	gui.contexts.NoteHistory = historyCtx
+	gui.contextMgr.Register(historyCtx)
+
+	ctrl := controllers.NewNoteHistoryController(
+		gui.controllerCommon,
+		func() *context.NoteHistoryContext { return gui.contexts.NoteHistory },
+	)
+	controllers.AttachController(ctrl)
+}
+
+// setupMergeConflictContext initializes the merge conflict context and controller.
+func (gui *Gui) setupMergeConflictContext() {
+	mergeCtx := context.NewMergeConflictContext()
+	gui.contexts.MergeConflict = mergeCtx
+	gui.contextMgr.Register(mergeCtx)
+
+	ctrl := controllers.NewMergeConflictController(
+		gui.controllerCommon,
+		func() *context.MergeConflictContext { return gui.contexts.MergeConflict },
+	)
+	controllers.AttachController(ctrl)
+}
@@ -0,0 +1,189 @@
+package helpers
+
+import (
+	stdctx "context"
+	"sync"
+	"time"
+
+	"github.com/donnellyk/lazyruin/pkg/commands"
+)
+
+// PreviewTaskKey is the AsyncHelper key shared by every hover preview load.
+// Hovers replace each other, so one key is enough: starting a new hover
+// cancels whatever the previous one was still fetching.
+const PreviewTaskKey = "preview"
+
+// spinnerFrames is the braille spinner shown next to titles while a task
+// is in flight.
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// AsyncWork is the background half of an async task. It runs off the gocui
+// main goroutine with a RuinCommand bound to the task's context, so
+// cancelling the task kills its ruin subprocess. The returned func is the
+// apply half: it runs on the main goroutine, and only if the task is still
+// current. Returning nil skips the apply step.
+type AsyncWork func(cmd *commands.RuinCommand) func() error
+
+// AsyncHelper runs ruin calls off the gocui main goroutine. Tasks are keyed:
+// starting a task under a key that already has one in flight cancels the
+// older task and discards its result, so only the latest request for a
+// given slot (e.g. the hover preview) ever lands.
+//
+// While any task is pending a ticker advances the spinner frame and forces
+// a redraw; layout code reads Spinner/Pending to decorate titles.
+type AsyncHelper struct {
+	c *HelperCommon
+
+	mu         sync.Mutex
+	tasks      map[string]*asyncTask
+	seq        uint64
+	ticking    bool
+	background bool
+
+	// frame is only touched on the main goroutine (ticker Update callbacks
+	// and layout), so it needs no locking.
+	frame int
+}
+
+type asyncTask struct {
+	id     uint64
+	cancel stdctx.CancelFunc
+}
+
+// NewAsyncHelper creates a new AsyncHelper.
+func NewAsyncHelper(c *HelperCommon) *AsyncHelper {
+	return &AsyncHelper{c: c, tasks: make(map[string]*asyncTask)}
+}
+
+// SetBackground controls where Run executes its work. It starts false so
+// headless tests and anything that runs before the event loop exists see
+// results synchronously; runMainLoop turns it on once gocui can deliver
+// Update callbacks.
+func (self *AsyncHelper) SetBackground(on bool) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	self.background = on
+}
+
+// Run executes work under key, cancelling any task already pending for the
+// same key. Work goes to a background goroutine when the event loop is
+// running and runs inline otherwise.
+func (self *AsyncHelper) Run(key string, work AsyncWork) {
+	self.mu.Lock()
+	background := self.background
+	self.mu.Unlock()
+
+	if !background {
+		self.Cancel(key)
+		if apply := work(self.c.RuinCmd()); apply != nil {
+			_ = apply()
+		}
+		return
+	}
+	self.Go(key, work)
+}
+
+// Go is Run without the inline fallback: work always runs on a background
+// goroutine. Used by flows whose waiting UI (e.g. a locked spinner popup)
+// must appear before the result does.
+func (self *AsyncHelper) Go(key string, work AsyncWork) {
+	ctx, cancel := stdctx.WithCancel(stdctx.Background())
+
+	self.mu.Lock()
+	if prev, ok := self.tasks[key]; ok {
+		prev.cancel()
+	}
+	self.seq++
+	task := &asyncTask{id: self.seq, cancel: cancel}
+	self.tasks[key] = task
+	startTicker := !self.ticking
+	self.ticking = true
+	self.mu.Unlock()
+
+	if startTicker {
+		go self.tick()
+	}
+
+	// Bind the command here, on the main goroutine: a vault switch swaps
+	// the RuinCommand while the task may still be starting.
+	cmd := self.c.RuinCmd().WithContext(ctx)
+	go func() {
+		defer cancel()
+		apply := work(cmd)
+		self.c.GuiCommon().Update(func() error {
+			if !self.finish(key, task.id) || apply == nil {
+				return nil
+			}
+			// Errors from the apply step are the caller's to surface;
+			// returning them here would abort the gocui main loop.
+			_ = apply()
+			return nil
+		})
+	}()
+}
+
+// Cancel aborts the task pending under key, if any. Its result is dropped.
+func (self *AsyncHelper) Cancel(key string) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	if task, ok := self.tasks[key]; ok {
+		task.cancel()
+		delete(self.tasks, key)
+	}
+}
+
+// CancelAll aborts every pending task. Their results are dropped.
+func (self *AsyncHelper) CancelAll() {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	for key, task := range self.tasks {
+		task.cancel()
+		delete(self.tasks, key)
+	}
+}
+
+// Pending reports whether a task is in flight under key.
+func (self *AsyncHelper) Pending(key string) bool {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	_, ok := self.tasks[key]
+	return ok
+}
+
+// Spinner returns the current spinner frame. Call from the main goroutine.
+func (self *AsyncHelper) Spinner() string {
+	return spinnerFrames[self.frame%len(spinnerFrames)]
+}
+
+// finish clears key if id is still its current task, reporting whether the
+// result should be applied.
+func (self *AsyncHelper) finish(key string, id uint64) bool {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	task, ok := self.tasks[key]
+	if !ok || task.id != id {
+		return false
+	}
+	delete(self.tasks, key)
+	return true
+}
+
+// tick advances the spinner every 80ms while any task is pending. It exits
+// once the task map drains; Go restarts it on the next task.
+func (self *AsyncHelper) tick() {
+	t := time.NewTicker(80 * time.Millisecond)
+	defer t.Stop()
+	for range t.C {
+		self.mu.Lock()
+		if len(self.tasks) == 0 {
+			self.ticking = false
+			self.mu.Unlock()
+			return
+		}
+		self.mu.Unlock()
+		self.c.GuiCommon().Update(func() error {
+			self.frame++
+			return nil
+		})
+	}
+}
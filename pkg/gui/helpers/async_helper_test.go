@@ -0,0 +1,158 @@
+package helpers
+
+import (
+	stdctx "context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/donnellyk/lazyruin/pkg/commands"
+)
+
+// updateQueueGui is a mockGuiCommon whose Update queues callbacks so a test
+// can stand in for the gocui main loop.
+type updateQueueGui struct {
+	mockGuiCommon
+	updates chan func() error
+}
+
+func (g *updateQueueGui) Update(fn func() error) { g.updates <- fn }
+
+// blockingExecutor holds every call until its context is cancelled or
+// release is closed.
+type blockingExecutor struct {
+	release chan struct{}
+}
+
+func (b *blockingExecutor) Execute(ctx stdctx.Context, _ ...string) ([]byte, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-b.release:
+		return []byte("[]"), nil
+	}
+}
+
+func newTestAsyncHelper(exec commands.Executor) (*AsyncHelper, *updateQueueGui) {
+	gui := &updateQueueGui{updates: make(chan func() error, 64)}
+	ruinCmd := commands.NewRuinCommandWithExecutor(exec, "/mock")
+	return NewAsyncHelper(NewHelperCommon(ruinCmd, nil, gui)), gui
+}
+
+// drainUntil runs queued Update callbacks until done reports true.
+func drainUntil(t *testing.T, gui *updateQueueGui, done func() bool) {
+	t.Helper()
+	deadline := time.After(2 * time.Second)
+	for !done() {
+		select {
+		case fn := <-gui.updates:
+			_ = fn()
+		case <-deadline:
+			t.Fatal("timed out waiting for async task")
+		}
+	}
+}
+
+// drainQueued runs whatever Update callbacks are already queued.
+func drainQueued(gui *updateQueueGui) {
+	for {
+		select {
+		case fn := <-gui.updates:
+			_ = fn()
+		default:
+			return
+		}
+	}
+}
+
+func TestAsyncRun_InlineWithoutBackground(t *testing.T) {
+	h, _ := newTestAsyncHelper(&blockingExecutor{release: closedChan()})
+
+	applied := false
+	h.Run(PreviewTaskKey, func(cmd *commands.RuinCommand) func() error {
+		if _, err := cmd.Execute("search", "x"); err != nil {
+			t.Errorf("inline Execute: %v", err)
+		}
+		return func() error { applied = true; return nil }
+	})
+
+	if !applied {
+		t.Error("Run should apply synchronously when background is off")
+	}
+	if h.Pending(PreviewTaskKey) {
+		t.Error("nothing should be pending after an inline run")
+	}
+}
+
+func TestAsyncGo_NewTaskCancelsSuperseded(t *testing.T) {
+	h, gui := newTestAsyncHelper(&blockingExecutor{release: make(chan struct{})})
+
+	var applied []string
+	firstErr := make(chan error, 1)
+	h.Go("k", func(cmd *commands.RuinCommand) func() error {
+		_, err := cmd.Execute("search", "slow")
+		firstErr <- err
+		return func() error { applied = append(applied, "first"); return nil }
+	})
+	if !h.Pending("k") {
+		t.Fatal("first task should be pending")
+	}
+	h.Go("k", func(cmd *commands.RuinCommand) func() error {
+		return func() error { applied = append(applied, "second"); return nil }
+	})
+
+	select {
+	case err := <-firstErr:
+		if !errors.Is(err, stdctx.Canceled) {
+			t.Errorf("superseded task error = %v, want context.Canceled", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("superseded task was not cancelled")
+	}
+
+	drainUntil(t, gui, func() bool { return len(applied) > 0 })
+	time.Sleep(10 * time.Millisecond)
+	drainQueued(gui)
+
+	if len(applied) != 1 || applied[0] != "second" {
+		t.Errorf("applied = %v, want [second]", applied)
+	}
+	if h.Pending("k") {
+		t.Error("key should be clear once the latest task lands")
+	}
+}
+
+func TestAsyncCancel_DropsResult(t *testing.T) {
+	h, gui := newTestAsyncHelper(&blockingExecutor{release: make(chan struct{})})
+
+	applied := false
+	workDone := make(chan struct{})
+	h.Go("k", func(cmd *commands.RuinCommand) func() error {
+		defer close(workDone)
+		_, _ = cmd.Execute("link", "resolve", "https://example.com")
+		return func() error { applied = true; return nil }
+	})
+
+	h.Cancel("k")
+	if h.Pending("k") {
+		t.Error("Cancel should clear the pending task")
+	}
+
+	select {
+	case <-workDone:
+	case <-time.After(2 * time.Second):
+		t.Fatal("cancelled task's command did not return")
+	}
+	time.Sleep(10 * time.Millisecond)
+	drainQueued(gui)
+
+	if applied {
+		t.Error("a cancelled task's result should be dropped")
+	}
+}
+
+func closedChan() chan struct{} {
+	c := make(chan struct{})
+	close(c)
+	return c
+}
@@ -0,0 +1,190 @@
+package helpers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/donnellyk/lazyruin/pkg/commands"
+	"github.com/donnellyk/lazyruin/pkg/gui/context"
+	"github.com/donnellyk/lazyruin/pkg/models"
+	"gopkg.in/yaml.v3"
+)
+
+// BacklinksHelper finds the notes that reference a note — by [[title]],
+// by an alias from its frontmatter, or by UUID — and shows them as pick
+// results, one match per referencing line.
+type BacklinksHelper struct {
+	c *HelperCommon
+}
+
+func NewBacklinksHelper(c *HelperCommon) *BacklinksHelper {
+	return &BacklinksHelper{c: c}
+}
+
+// ShowBacklinks navigates to the backlinks of the note under the preview
+// cursor. Enter on a result opens the referencing note at the line. Find
+// reads every note in the vault, so it runs as a background preview task;
+// moving on to another preview before it finishes cancels it.
+func (self *BacklinksHelper) ShowBacklinks() error {
+	gui := self.c.GuiCommon()
+	card := self.c.Helpers().Preview().CurrentPreviewCard()
+	if card == nil {
+		return nil
+	}
+	uuid := card.UUID
+	name := displayTitleForNote(card.Title)
+	title := "Backlinks: " + name
+
+	self.c.Helpers().Async().Run(PreviewTaskKey, func(cmd *commands.RuinCommand) func() error {
+		results, err := self.Find(cmd, uuid)
+		return func() error {
+			if err != nil {
+				gui.ShowError(err)
+				return nil
+			}
+			return self.c.Helpers().Navigator().NavigateTo("pickResults", title, func() error {
+				source := self.c.Helpers().Preview().PickResultsSource(context.SourceDescriptor{Kind: context.SourceBacklinks, Query: uuid})
+				self.c.Helpers().Preview().ShowPickResults(title, results, source)
+				if len(results) == 0 {
+					gui.ShowStatus("No notes link to " + name)
+				}
+				return nil
+			})
+		}
+	})
+	return nil
+}
+
+// Find returns one result per note that references the note with uuid.
+// Note files are read from disk so match lines are content line numbers,
+// the same numbering pick results use.
+func (self *BacklinksHelper) Find(cmd *commands.RuinCommand, uuid string) ([]models.PickResult, error) {
+	target, err := cmd.Search.Get(uuid, commands.SearchOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if target == nil {
+		return nil, fmt.Errorf("note %s not found", uuid)
+	}
+	names := []string{target.Title}
+	if data, err := os.ReadFile(vaultPath(cmd, target.Path)); err == nil {
+		names = append(names, parseAliases(string(data))...)
+	}
+
+	notes, err := cmd.Search.Search("", commands.SearchOptions{Everything: true})
+	if err != nil {
+		return nil, err
+	}
+	var results []models.PickResult
+	for _, n := range notes {
+		if n.UUID == uuid {
+			continue
+		}
+		data, err := os.ReadFile(vaultPath(cmd, n.Path))
+		if err != nil {
+			continue
+		}
+		matches := scanBacklinks(string(data), names, uuid)
+		if len(matches) == 0 {
+			continue
+		}
+		results = append(results, models.PickResult{UUID: n.UUID, Title: n.Title, File: n.Path, Matches: matches})
+	}
+	return results, nil
+}
+
+// vaultPath resolves a note path that may be relative to the vault.
+func vaultPath(cmd *commands.RuinCommand, path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(cmd.VaultPath(), path)
+}
+
+var wikiLinkRe = regexp.MustCompile(`\[\[([^\]]+)\]\]`)
+
+// scanBacklinks returns the content lines of file that reference the note:
+// a [[wiki-link]] whose target (before any #heading or |label) matches one
+// of names case-insensitively or is the UUID, or the bare UUID anywhere in
+// the line. Line numbers count from the first line after the frontmatter.
+func scanBacklinks(file string, names []string, uuid string) []models.PickMatch {
+	lines := strings.Split(file, "\n")
+	start := contentStart(lines)
+	var matches []models.PickMatch
+	for i, line := range lines[start:] {
+		if !referencesNote(line, names, uuid) {
+			continue
+		}
+		matches = append(matches, models.PickMatch{
+			Line:    i + 1,
+			Content: strings.TrimSpace(line),
+			Done:    models.HasDoneTag(line),
+		})
+	}
+	return matches
+}
+
+func referencesNote(line string, names []string, uuid string) bool {
+	if uuid != "" && strings.Contains(line, uuid) {
+		return true
+	}
+	for _, m := range wikiLinkRe.FindAllStringSubmatch(line, -1) {
+		target := m[1]
+		if i := strings.IndexAny(target, "#|"); i >= 0 {
+			target = target[:i]
+		}
+		target = strings.TrimSpace(target)
+		for _, name := range names {
+			if name != "" && strings.EqualFold(target, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// contentStart returns the index of the first line after a leading
+// frontmatter block, or 0 when there is none.
+func contentStart(lines []string) int {
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return 0
+	}
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "---" {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// parseAliases reads `aliases:` (a list or a single string) from the
+// file's frontmatter.
+func parseAliases(file string) []string {
+	lines := strings.Split(file, "\n")
+	end := contentStart(lines)
+	if end < 2 {
+		return nil
+	}
+	var fm struct {
+		Aliases any `yaml:"aliases"`
+	}
+	if err := yaml.Unmarshal([]byte(strings.Join(lines[1:end-1], "\n")), &fm); err != nil {
+		return nil
+	}
+	switch v := fm.Aliases.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		var aliases []string
+		for _, a := range v {
+			if s, ok := a.(string); ok {
+				aliases = append(aliases, s)
+			}
+		}
+		return aliases
+	}
+	return nil
+}
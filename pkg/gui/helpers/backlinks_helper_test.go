@@ -0,0 +1,49 @@
+package helpers
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestScanBacklinks(t *testing.T) {
+	file := "---\nuuid: other\ntitle: Other\n---\n" +
+		"Intro line\n" +
+		"See [[Project Plan]] for details\n" +
+		"Heading link [[project plan#Goals]]\n" +
+		"Labelled [[Plan|the plan]]\n" +
+		"Unrelated [[Project Planning]]\n" +
+		"Raw id abc-123 mention\n"
+
+	matches := scanBacklinks(file, []string{"Project Plan", "Plan"}, "abc-123")
+	var lines []int
+	for _, m := range matches {
+		lines = append(lines, m.Line)
+	}
+	if want := []int{2, 3, 4, 6}; !slices.Equal(lines, want) {
+		t.Fatalf("matched lines = %v, want %v", lines, want)
+	}
+	if matches[0].Content != "See [[Project Plan]] for details" {
+		t.Errorf("snippet = %q", matches[0].Content)
+	}
+}
+
+func TestParseAliases(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		want []string
+	}{
+		{"list", "---\ntitle: A\naliases:\n  - Alpha\n  - First\n---\nbody", []string{"Alpha", "First"}},
+		{"inline list", "---\naliases: [Alpha, First]\n---\n", []string{"Alpha", "First"}},
+		{"single string", "---\naliases: Alpha\n---\n", []string{"Alpha"}},
+		{"none", "---\ntitle: A\n---\n", nil},
+		{"no frontmatter", "aliases: Alpha\n", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseAliases(tt.file); !slices.Equal(got, tt.want) {
+				t.Errorf("parseAliases = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package helpers
+
+import (
+	"fmt"
+
+	"github.com/donnellyk/lazyruin/pkg/gui/types"
+	"github.com/donnellyk/lazyruin/pkg/models"
+)
+
+// BreadcrumbHelper exposes the ancestor chain of the single note open in
+// the card list. The preview frame draws the chain as clickable title
+// tabs; the ancestors menu reaches the same crumbs by key.
+type BreadcrumbHelper struct {
+	c *HelperCommon
+}
+
+func NewBreadcrumbHelper(c *HelperCommon) *BreadcrumbHelper {
+	return &BreadcrumbHelper{c: c}
+}
+
+// Crumbs returns the ancestors of the note shown in the preview, root
+// first. It is empty unless the card list holds exactly one note that has
+// a parent.
+func (self *BreadcrumbHelper) Crumbs() []Ancestor {
+	contexts := self.c.GuiCommon().Contexts()
+	if contexts.ActivePreviewKey != "cardList" || len(contexts.CardList.Cards) != 1 {
+		return nil
+	}
+	note := contexts.CardList.Cards[0]
+	if note.Parent == "" {
+		return nil
+	}
+	return self.c.Helpers().TitleCache().Ancestors(note)
+}
+
+// ShowAncestorsMenu lists the crumbs, nearest parent first, each opening
+// that ancestor in Compose mode.
+func (self *BreadcrumbHelper) ShowAncestorsMenu() error {
+	crumbs := self.Crumbs()
+	if len(crumbs) == 0 {
+		self.c.GuiCommon().ShowStatus("No ancestors")
+		return nil
+	}
+	var items []types.MenuItem
+	for i := len(crumbs) - 1; i >= 0; i-- {
+		crumb := crumbs[i]
+		item := types.MenuItem{
+			Label: crumb.Title,
+			OnRun: func() error { return self.OpenAncestor(crumb) },
+		}
+		if n := len(crumbs) - i; n <= 9 {
+			item.Key = fmt.Sprint(n)
+		}
+		items = append(items, item)
+	}
+	self.c.GuiCommon().ShowMenuDialog("Ancestors", items)
+	return nil
+}
+
+// ClickCrumb handles a click on the preview title tabs. The last tab is
+// the open note itself, so clicking it does nothing.
+func (self *BreadcrumbHelper) ClickCrumb(idx int) error {
+	crumbs := self.Crumbs()
+	if idx < 0 || idx >= len(crumbs) {
+		return nil
+	}
+	return self.OpenAncestor(crumbs[idx])
+}
+
+// OpenAncestor composes the ancestor and its children into the preview,
+// recording a history entry.
+func (self *BreadcrumbHelper) OpenAncestor(crumb Ancestor) error {
+	gui := self.c.GuiCommon()
+	parent := models.ParentBookmark{Name: crumb.Title, UUID: crumb.UUID, Title: crumb.Title}
+	title := "Parent: " + crumb.Title
+	return self.c.Helpers().Navigator().NavigateTo("compose", title, func() error {
+		composed, sourceMap, err := self.c.RuinCmd().Parent.Compose(parent)
+		if err != nil {
+			gui.ShowError(err)
+			return err
+		}
+		self.c.Helpers().Preview().ShowCompose(title, composed, sourceMap, parent)
+		gui.Contexts().Compose.Requery = self.c.Helpers().Preview().ComposeRequery(parent)
+		return nil
+	})
+}
@@ -18,7 +18,8 @@ import (
 
 // errEditConflict signals that the note's file was modified externally
 // between OpenCaptureForEdit and the save attempt. The caller should keep
-// the popup open so the user can recover their in-progress text.
+// the popup open so the user can recover their in-progress text; the
+// capture popup first tries to merge the two versions.
 var errEditConflict = errors.New("note was modified externally since edit began")
 
 // CaptureHelper encapsulates the capture popup logic.
@@ -47,6 +48,7 @@ func resetCaptureState(ctx *context.CaptureContext) {
 	ctx.EditingUUID = ""
 	ctx.EditingTitle = ""
 	ctx.EditingMtime = time.Time{}
+	ctx.EditingBase = ""
 	ctx.ResolveState = context.ResolveIdle
 	ctx.ResolveResult = nil
 	ctx.ResolveDone = nil
@@ -86,6 +88,23 @@ func (self *CaptureHelper) OpenCaptureWithContent(text string) error {
 	return nil
 }
 
+// OpenCaptureFromTemplate opens the capture popup for a new note filled in
+// from a template, with the cursor on cursorLine (0 = end) and an optional
+// pre-set parent.
+func (self *CaptureHelper) OpenCaptureFromTemplate(content string, cursorLine int, parent *context.CaptureParentInfo) error {
+	gui := self.c.GuiCommon()
+	if gui.PopupActive() {
+		return nil
+	}
+	ctx := gui.Contexts().Capture
+	resetCaptureState(ctx)
+	ctx.PrefillContent = content
+	ctx.CursorLine = cursorLine
+	ctx.Parent = parent
+	gui.PushContextByKey("capture")
+	return nil
+}
+
 // OpenCaptureForEdit opens the capture popup populated with the note's
 // current content (excluding frontmatter). The popup title becomes the
 // note's title instead of "New Note". On Ctrl+S the file is rewritten and
@@ -118,6 +137,7 @@ func (self *CaptureHelper) OpenCaptureForEditAtLine(note *models.Note, rawLineNu
 	ctx.EditingUUID = note.UUID
 	ctx.EditingTitle = note.Title
 	ctx.EditingMtime = mtime
+	ctx.EditingBase = content
 	if rawLineNum > 0 {
 		ctx.CursorLine = bodyLineForRaw(note.Path, rawLineNum)
 	}
@@ -193,36 +213,69 @@ func (self *CaptureHelper) saveEdit(path string, expectedMtime time.Time, newCon
 	merged := append([]byte{}, frontmatter...)
 	merged = append(merged, []byte(body)...)
 
-	// Atomic write: temp file in the same directory, then rename. Rename
-	// within a filesystem is atomic on POSIX — either the old file or the
-	// fully-written new file is visible, never a half-truncated version.
+	if err := writeFileAtomic(path, merged); err != nil {
+		return false, err
+	}
+
+	// File is committed. Reindex errors are surfaced but don't undo the
+	// write — the caller distinguishes via the written return.
+	reindexErr := self.c.RuinCmd().Doctor(path)
+	self.c.Helpers().Hooks().Fire(HookEvent{
+		Name:    HookEdit,
+		Path:    path,
+		UUID:    frontmatterField(frontmatter, "uuid"),
+		Title:   frontmatterField(frontmatter, "title"),
+		Changed: []string{"body"},
+	})
+	if reindexErr != nil {
+		return true, fmt.Errorf("reindex: %w", reindexErr)
+	}
+	return true, nil
+}
+
+// journaledSaveEdit runs saveEdit inside an undo snapshot of path labelled
+// label. Only the first write checks mtime for external edits; a redo
+// starts from the restored snapshot. A written file is journaled even when
+// the reindex fails; that failure comes back as reindexErr.
+func (self *CaptureHelper) journaledSaveEdit(label, path string, mtime time.Time, body string) (reindexErr, err error) {
+	err = self.c.Helpers().Undo().RecordSnapshot(label, []string{path}, func() error {
+		written, err := self.saveEdit(path, mtime, body)
+		mtime = time.Time{}
+		if written {
+			reindexErr = err
+			return nil
+		}
+		return err
+	})
+	return reindexErr, err
+}
+
+// writeFileAtomic writes data to path via a temp file in the same
+// directory, then rename. Rename within a filesystem is atomic on POSIX —
+// either the old file or the fully-written new file is visible, never a
+// half-truncated version.
+func writeFileAtomic(path string, data []byte) error {
 	dir := filepath.Dir(path)
 	base := filepath.Base(path)
 	tmp, err := os.CreateTemp(dir, base+".tmp-*")
 	if err != nil {
-		return false, fmt.Errorf("create temp file: %w", err)
+		return fmt.Errorf("create temp file: %w", err)
 	}
 	tmpPath := tmp.Name()
-	if _, err := tmp.Write(merged); err != nil {
+	if _, err := tmp.Write(data); err != nil {
 		tmp.Close()
 		os.Remove(tmpPath)
-		return false, fmt.Errorf("write temp: %w", err)
+		return fmt.Errorf("write temp: %w", err)
 	}
 	if err := tmp.Close(); err != nil {
 		os.Remove(tmpPath)
-		return false, fmt.Errorf("close temp: %w", err)
+		return fmt.Errorf("close temp: %w", err)
 	}
 	if err := os.Rename(tmpPath, path); err != nil {
 		os.Remove(tmpPath)
-		return false, fmt.Errorf("rename %s: %w", path, err)
-	}
-
-	// File is committed. Reindex errors are surfaced but don't undo the
-	// write — the caller distinguishes via the written return.
-	if err := self.c.RuinCmd().Doctor(path); err != nil {
-		return true, fmt.Errorf("reindex: %w", err)
+		return fmt.Errorf("rename %s: %w", path, err)
 	}
-	return true, nil
+	return nil
 }
 
 // readNoteBodyAndMtime reads a note file and returns the body (after the
@@ -237,8 +290,13 @@ func readNoteBodyAndMtime(path string) (string, time.Time, error) {
 	if err != nil {
 		return "", time.Time{}, err
 	}
-	frontmatter := extractFrontmatter(data)
-	body := data[len(frontmatter):]
+	return noteBody(data), info.ModTime(), nil
+}
+
+// noteBody returns the part of a note file after its frontmatter, with
+// leading blank lines trimmed.
+func noteBody(data []byte) string {
+	body := data[len(extractFrontmatter(data)):]
 	// Strip leading blank lines whether LF- or CRLF-encoded.
 	for len(body) > 0 {
 		if body[0] == '\n' {
@@ -249,7 +307,7 @@ func readNoteBodyAndMtime(path string) (string, time.Time, error) {
 			break
 		}
 	}
-	return string(body), info.ModTime(), nil
+	return string(body)
 }
 
 // readNoteBodyContent reads a note file and returns the body (everything
@@ -344,31 +402,56 @@ func (self *CaptureHelper) SubmitCapture(content string, quickCapture bool) erro
 		}
 	}
 
-	args := []string{"log", content}
+	parentUUID := ""
 	if ctx.Parent != nil {
-		args = append(args, "--parent", ctx.Parent.UUID)
+		parentUUID = ctx.Parent.UUID
 	}
-	_, err := self.c.RuinCmd().Execute(args...)
+	note, err := self.c.RuinCmd().Note.Create(content, parentUUID)
 	if err != nil {
 		if quickCapture {
 			return gocui.ErrQuit
 		}
 		return self.CloseCapture()
 	}
+	if note != nil {
+		self.c.Helpers().Hooks().Fire(HookEvent{Name: HookCreate, Path: note.Path, UUID: note.UUID, Title: note.Title})
+	}
 
 	if quickCapture {
 		return gocui.ErrQuit
 	}
 
+	self.c.Helpers().Git().AutoCommit("New note")
 	self.CloseCapture()
 	self.c.Helpers().Preview().ReloadActivePreview()
 	self.c.Helpers().Tags().RefreshTags(false)
 	return nil
 }
 
+// CaptureText saves content as a new note without opening the capture
+// popup, for the remote `capture` command.
+func (self *CaptureHelper) CaptureText(content string) error {
+	note, err := self.c.RuinCmd().Note.Create(content, "")
+	if err != nil {
+		return err
+	}
+	title := "note"
+	if note != nil {
+		self.c.Helpers().Hooks().Fire(HookEvent{Name: HookCreate, Path: note.Path, UUID: note.UUID, Title: note.Title})
+		title = fmt.Sprintf("%q", note.Title)
+	}
+	self.c.Helpers().Git().AutoCommit("New note")
+	self.c.Helpers().Preview().ReloadActivePreview()
+	self.c.Helpers().Tags().RefreshTags(false)
+	self.c.GuiCommon().ShowStatus("Captured " + title)
+	return nil
+}
+
 // submitEdit handles the Ctrl+S path for edit mode. Branches on saveEdit
 // outcome to distinguish recoverable failures (keep popup open) from
-// successful-write-but-reindex-failed (refresh UI, surface warning).
+// successful-write-but-reindex-failed (refresh UI, surface warning). When
+// the note changed on disk since the popup opened, the edit is merged
+// with the external change instead.
 func (self *CaptureHelper) submitEdit(ctx *context.CaptureContext, content string) error {
 	gui := self.c.GuiCommon()
 	// Guard against accidental body wipe: empty/whitespace-only content in
@@ -378,20 +461,28 @@ func (self *CaptureHelper) submitEdit(ctx *context.CaptureContext, content strin
 		return self.CloseCapture()
 	}
 	written, err := self.saveEdit(ctx.EditingPath, ctx.EditingMtime, content)
+	if errors.Is(err, errEditConflict) {
+		return self.c.Helpers().MergeConflict().MergeExternalEdit(content)
+	}
 	if err != nil && !written {
 		// Write failed — file is untouched. Keep the popup open so the
 		// user doesn't lose their edits; surface the specific error.
-		if errors.Is(err, errEditConflict) {
-			gui.ShowError(fmt.Errorf("%q was modified externally; your edits are preserved in the popup — close and reopen to see the new version", filepath.Base(ctx.EditingPath)))
-		} else {
-			gui.ShowError(err)
-		}
+		gui.ShowError(err)
 		return nil
 	}
-	if err != nil {
+	self.finishEdit(err)
+	return nil
+}
+
+// finishEdit closes the edit popup once its content has been written.
+// reindexErr is the Doctor failure from saveEdit, if any.
+func (self *CaptureHelper) finishEdit(reindexErr error) {
+	gui := self.c.GuiCommon()
+	ctx := gui.Contexts().Capture
+	if reindexErr != nil {
 		// File was written but Doctor reindex failed. Close + refresh so
 		// the UI matches the on-disk state, but warn about index staleness.
-		gui.ShowError(fmt.Errorf("saved but reindex failed (%w); run `ruin doctor` to refresh", err))
+		gui.ShowError(fmt.Errorf("saved but reindex failed (%w); run `ruin doctor` to refresh", reindexErr))
 	}
 	// Apply the parent selection from the > completion (saveEdit only
 	// rewrites the body and preserves frontmatter byte-for-byte, so a
@@ -405,10 +496,10 @@ func (self *CaptureHelper) submitEdit(ctx *context.CaptureContext, content strin
 			gui.ShowError(fmt.Errorf("set parent failed: %w", perr))
 		}
 	}
+	self.c.Helpers().Git().AutoCommit("Edit " + ctx.EditingTitle)
 	self.CloseCapture()
 	self.c.Helpers().Preview().ReloadActivePreview()
 	self.c.Helpers().Tags().RefreshTags(false)
-	return nil
 }
 
 // bareURL reports whether content (after trimming) is nothing but a URL,
@@ -6,6 +6,7 @@ import (
 
 	"github.com/donnellyk/lazyruin/pkg/commands"
 	"github.com/donnellyk/lazyruin/pkg/gui/types"
+	"github.com/donnellyk/lazyruin/pkg/models"
 	"github.com/donnellyk/ruin-note-cli/pkg/notetext"
 )
 
@@ -48,24 +49,41 @@ func (self *CompletionHelper) CurrentCardTagCandidates(filter string) []types.Co
 	if card == nil {
 		return nil
 	}
-	filter = strings.ToLower(filter)
-	var items []types.CompletionItem
-	allTags := append(card.Tags, card.InlineTags...)
-	for _, tag := range allTags {
-		name := tag
-		if !strings.HasPrefix(name, "#") {
-			name = "#" + name
-		}
-		nameWithoutHash := strings.TrimPrefix(name, "#")
-		if filter != "" && !strings.Contains(strings.ToLower(nameWithoutHash), filter) {
-			continue
+	return self.NoteTagCandidates([]models.Note{*card})(filter)
+}
+
+// NoteTagCandidates returns a candidate provider limited to the tags on
+// notes, listing each tag once.
+func (self *CompletionHelper) NoteTagCandidates(notes []models.Note) func(string) []types.CompletionItem {
+	var allTags []string
+	for _, note := range notes {
+		allTags = append(allTags, note.Tags...)
+		allTags = append(allTags, note.InlineTags...)
+	}
+	return func(filter string) []types.CompletionItem {
+		filter = strings.ToLower(filter)
+		var items []types.CompletionItem
+		seen := make(map[string]bool)
+		for _, tag := range allTags {
+			name := tag
+			if !strings.HasPrefix(name, "#") {
+				name = "#" + name
+			}
+			if seen[strings.ToLower(name)] {
+				continue
+			}
+			seen[strings.ToLower(name)] = true
+			nameWithoutHash := strings.TrimPrefix(name, "#")
+			if filter != "" && !strings.Contains(strings.ToLower(nameWithoutHash), filter) {
+				continue
+			}
+			items = append(items, types.CompletionItem{
+				Label:      name,
+				InsertText: name,
+			})
 		}
-		items = append(items, types.CompletionItem{
-			Label:      name,
-			InsertText: name,
-		})
+		return items
 	}
-	return items
 }
 
 // ScopedInlineTags returns the unique inline tags found in the underlying
@@ -0,0 +1,483 @@
+package helpers
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/donnellyk/lazyruin/pkg/models"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ComposeEditHelper edits a composed document as a single file in $EDITOR
+// and splits the result back onto the parent's child notes.
+type ComposeEditHelper struct {
+	c *HelperCommon
+}
+
+func NewComposeEditHelper(c *HelperCommon) *ComposeEditHelper {
+	return &ComposeEditHelper{c: c}
+}
+
+// docSection is one note's slice of the editable document. Sections the
+// user adds have no UUID. ReadOnly sections are notes compose pulled in
+// that aren't direct children of the parent: embeds and grandchildren.
+type docSection struct {
+	UUID     string
+	Title    string
+	Path     string
+	Body     string
+	Mtime    time.Time
+	Order    *int
+	ReadOnly bool
+}
+
+// documentPlan is the set of writes that turn the original sections into
+// the edited document.
+type documentPlan struct {
+	Edits   []docSection // existing notes whose body changed; Body holds the new text
+	Creates []string     // bodies of new child notes, in document order
+	Deletes []docSection // notes whose section was removed
+	Order   []string     // direct-child order after the edit ("" marks a new note); nil when unchanged
+
+	orders map[string]*int // order fields before the edit, for undo
+}
+
+func (p documentPlan) empty() bool {
+	return len(p.Edits) == 0 && len(p.Creates) == 0 && len(p.Deletes) == 0 && p.Order == nil
+}
+
+const documentHeader = `<!-- Each "child:" marker starts one note. Edit the text under a marker to -->
+<!-- change that note, move a section (marker included) to reorder it, or -->
+<!-- delete a section to move its note to the trash. A "child:new" marker -->
+<!-- starts a new child note. "readonly:" sections are embeds and -->
+<!-- grandchildren, shown for context; edit them from their own note. -->
+<!-- Save and quit to review the changes. -->`
+
+var sectionMarkerRe = regexp.MustCompile(`^<!--\s*(child|readonly):(\S+)\s*(.*?)\s*-->\s*$`)
+
+// EditWholeDocument opens the composed parent in $EDITOR, one section per
+// child note, and applies the edits after a confirmation summary.
+//
+// The SourceMap picks which notes make up the document and in what order.
+// Each section holds the note's raw body rather than its composed text,
+// since compose strips titles and expands embeds and writing that back
+// would be lossy. Compose also recurses and expands embeds, so only the
+// parent and its direct children are editable; the rest is read-only.
+func (self *ComposeEditHelper) EditWholeDocument() error {
+	gui := self.c.GuiCommon()
+	comp := gui.Contexts().Compose
+	parentUUID := comp.Note.UUID
+	if parentUUID == "" {
+		gui.ShowError(errors.New("the composed document has no parent note"))
+		return nil
+	}
+
+	tree, err := self.c.RuinCmd().Parent.Tree(parentUUID)
+	if err != nil {
+		gui.ShowError(err)
+		return nil
+	}
+	children := make(map[string]bool)
+	if tree != nil {
+		for _, child := range tree.Children {
+			children[child.UUID] = true
+		}
+	}
+
+	sections, err := self.loadSections(comp.SourceMap, parentUUID, children)
+	if err != nil {
+		gui.ShowError(err)
+		return nil
+	}
+	if len(sections) == 0 {
+		gui.ShowStatus("Nothing to edit")
+		return nil
+	}
+
+	tmp, err := os.CreateTemp("", "lazyruin-compose-*.md")
+	if err != nil {
+		gui.ShowError(err)
+		return nil
+	}
+	path := tmp.Name()
+	original := buildDocument(sections)
+	_, err = tmp.WriteString(original)
+	tmp.Close()
+	if err != nil {
+		os.Remove(path)
+		gui.ShowError(err)
+		return nil
+	}
+
+	if err := self.c.Helpers().Editor().RunEditor(path); err != nil {
+		os.Remove(path)
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		gui.ShowError(err)
+		return nil
+	}
+
+	plan, err := planDocument(sections, string(data), parentUUID)
+	if err != nil {
+		// Keep the scratch file so the edits aren't lost.
+		gui.ShowError(fmt.Errorf("%w (your edits are in %s)", err, path))
+		return nil
+	}
+	os.Remove(path)
+	if plan.empty() {
+		gui.ShowStatus("No changes")
+		return nil
+	}
+
+	gui.ShowConfirm("Apply Document Edits", plan.summary(), func() error {
+		self.apply(plan, parentUUID)
+		return nil
+	})
+	return nil
+}
+
+// loadSections reads the body of each note in the source map, skipping
+// repeats (a child can appear more than once through embeds). Notes other
+// than the parent and its children are marked read-only.
+func (self *ComposeEditHelper) loadSections(sourceMap []models.SourceMapEntry, parentUUID string, children map[string]bool) ([]docSection, error) {
+	cmd := self.c.RuinCmd()
+	seen := make(map[string]bool)
+	var sections []docSection
+	for _, e := range sourceMap {
+		if e.UUID == "" || seen[e.UUID] {
+			continue
+		}
+		seen[e.UUID] = true
+		path := vaultPath(cmd, e.Path)
+		body, mtime, err := readNoteBodyAndMtime(path)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", e.Title, err)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", e.Title, err)
+		}
+		sections = append(sections, docSection{
+			UUID:     e.UUID,
+			Title:    e.Title,
+			Path:     path,
+			Body:     body,
+			Mtime:    mtime,
+			Order:    frontmatterOrder(string(data)),
+			ReadOnly: e.UUID != parentUUID && !children[e.UUID],
+		})
+	}
+	return sections, nil
+}
+
+// apply writes the plan as one undo batch. Failures are collected and
+// reported together; the rest of the plan still runs. Created notes are
+// journaled too, so undoing the batch moves them to the trash.
+func (self *ComposeEditHelper) apply(plan documentPlan, parentUUID string) {
+	h := self.c.Helpers()
+	cmd := self.c.RuinCmd()
+	errs := bulkErrors{total: len(plan.Edits) + len(plan.Creates) + len(plan.Deletes)}
+
+	h.Undo().Batch("Edit document", func() {
+		for _, s := range plan.Edits {
+			reindexErr, err := h.Capture().journaledSaveEdit("Edit "+s.Title, s.Path, s.Mtime, s.Body)
+			if errors.Is(err, errEditConflict) {
+				err = errors.New("modified externally; not saved")
+			}
+			if err == nil && reindexErr != nil {
+				err = fmt.Errorf("saved but reindex failed (%w); run `ruin doctor` to refresh", reindexErr)
+			}
+			if err != nil {
+				errs.add(models.Note{Title: s.Title, Path: s.Path}, err)
+			}
+		}
+
+		created := make([]string, 0, len(plan.Creates))
+		for _, body := range plan.Creates {
+			note, err := cmd.Note.Create(body, parentUUID)
+			uuid := ""
+			if err != nil {
+				errs.add(models.Note{Title: firstLine(body)}, err)
+			} else if note != nil && note.UUID != "" {
+				uuid = note.UUID
+				h.Trash().RecordCreate(note.UUID, note.Path, note.Title)
+				h.Hooks().Fire(HookEvent{Name: HookCreate, Path: note.Path, UUID: note.UUID, Title: note.Title})
+			}
+			created = append(created, uuid)
+		}
+
+		for _, s := range plan.Deletes {
+			if err := h.Trash().TrashNote(s.UUID, s.Path, s.Title); err != nil {
+				errs.add(models.Note{Title: s.Title, Path: s.Path}, err)
+				continue
+			}
+			h.Navigator().NoteDeleted(s.UUID)
+		}
+
+		if plan.Order != nil {
+			self.applyOrder(plan, created, &errs)
+		}
+	})
+
+	if err := errs.err(); err != nil {
+		self.c.GuiCommon().ShowError(err)
+	}
+	h.Preview().ReloadActivePreview()
+	h.Notes().FetchNotesForCurrentTab(false)
+	h.Tags().RefreshTags(false)
+	h.Queries().RefreshParents(false)
+}
+
+// applyOrder numbers the direct children in document order. New notes
+// whose UUID is unknown (their create failed) are skipped.
+func (self *ComposeEditHelper) applyOrder(plan documentPlan, created []string, errs *bulkErrors) {
+	var changes []OrderChange
+	for i, uuid := range plan.Order {
+		if uuid == "" {
+			uuid, created = created[0], created[1:]
+			if uuid == "" {
+				continue
+			}
+		}
+		if err := self.c.RuinCmd().Note.SetOrder(uuid, i+1); err != nil {
+			errs.add(models.Note{Title: uuid}, err)
+			continue
+		}
+		changes = append(changes, OrderChange{UUID: uuid, Prev: plan.orders[uuid], Next: i + 1})
+	}
+	if len(changes) > 0 {
+		self.c.Helpers().Undo().RecordOrder(changes)
+	}
+}
+
+// buildDocument renders sections into the editable document.
+func buildDocument(sections []docSection) string {
+	var b strings.Builder
+	b.WriteString(documentHeader)
+	b.WriteString("\n")
+	for _, s := range sections {
+		kind := "child"
+		if s.ReadOnly {
+			kind = "readonly"
+		}
+		fmt.Fprintf(&b, "\n<!-- %s:%s %s -->\n\n", kind, s.UUID, strings.ReplaceAll(s.Title, "-->", ""))
+		if body := trimBlankLines(s.Body); body != "" {
+			b.WriteString(body)
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// parsedSection is a section read back from the edited document; UUID is
+// empty for a "child:new" marker.
+type parsedSection struct {
+	UUID string
+	Body string
+}
+
+// parseDocument splits an edited document at its section markers. Only
+// blank lines and comments may precede the first marker.
+func parseDocument(text string) ([]parsedSection, error) {
+	var sections []parsedSection
+	var body []string
+	inComment := false
+	flush := func() {
+		if len(sections) > 0 {
+			sections[len(sections)-1].Body = trimBlankLines(strings.Join(body, "\n"))
+		}
+		body = nil
+	}
+	for i, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		if m := sectionMarkerRe.FindStringSubmatch(line); m != nil {
+			flush()
+			uuid := m[2]
+			if uuid == "new" {
+				uuid = ""
+			}
+			sections = append(sections, parsedSection{UUID: uuid})
+			continue
+		}
+		if len(sections) > 0 {
+			body = append(body, line)
+			continue
+		}
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case inComment:
+			inComment = !strings.Contains(trimmed, "-->")
+		case strings.HasPrefix(trimmed, "<!--"):
+			inComment = !strings.Contains(trimmed, "-->")
+		case trimmed != "":
+			return nil, fmt.Errorf("line %d: text before the first section marker", i+1)
+		}
+	}
+	flush()
+	return sections, nil
+}
+
+// planDocument diffs the edited document against the original sections.
+// A section emptied of text is left alone rather than blanking the note;
+// deleting the whole section is how a note is removed. The parent's own
+// section can be edited but not deleted, and isn't part of the ordering.
+// Read-only sections can't be edited or deleted and don't take part in
+// the ordering either, so moving one is ignored.
+func planDocument(original []docSection, edited, parentUUID string) (documentPlan, error) {
+	parsed, err := parseDocument(edited)
+	if err != nil {
+		return documentPlan{}, err
+	}
+
+	byUUID := make(map[string]docSection, len(original))
+	for _, s := range original {
+		byUUID[s.UUID] = s
+	}
+
+	var plan documentPlan
+	seen := make(map[string]bool)
+	var order []string
+	for _, p := range parsed {
+		if p.UUID == "" {
+			if p.Body == "" {
+				continue
+			}
+			plan.Creates = append(plan.Creates, p.Body)
+			order = append(order, "")
+			continue
+		}
+		s, ok := byUUID[p.UUID]
+		if !ok {
+			return documentPlan{}, fmt.Errorf("unknown section marker child:%s", p.UUID)
+		}
+		if seen[p.UUID] {
+			return documentPlan{}, fmt.Errorf("section %q appears more than once", s.Title)
+		}
+		seen[p.UUID] = true
+		if p.Body != "" && p.Body != trimBlankLines(s.Body) {
+			if s.ReadOnly {
+				return documentPlan{}, fmt.Errorf("%q isn't a child of this note; edit it from its own note", s.Title)
+			}
+			s.Body = p.Body
+			plan.Edits = append(plan.Edits, s)
+		}
+		if p.UUID != parentUUID && !s.ReadOnly {
+			order = append(order, p.UUID)
+		}
+	}
+
+	var kept []string
+	for _, s := range original {
+		if seen[s.UUID] {
+			if s.UUID != parentUUID && !s.ReadOnly {
+				kept = append(kept, s.UUID)
+			}
+			continue
+		}
+		if s.UUID == parentUUID {
+			return documentPlan{}, fmt.Errorf("the parent note %q can't be deleted here", s.Title)
+		}
+		if s.ReadOnly {
+			return documentPlan{}, fmt.Errorf("%q isn't a child of this note and can't be deleted here", s.Title)
+		}
+		plan.Deletes = append(plan.Deletes, s)
+	}
+
+	if orderChanged(kept, order) {
+		plan.Order = order
+		plan.orders = make(map[string]*int, len(original))
+		for _, s := range original {
+			plan.orders[s.UUID] = s.Order
+		}
+	}
+	return plan, nil
+}
+
+// orderChanged reports whether the edited order differs from the original
+// order of the surviving children. New notes only count when they land
+// before an existing child; appending doesn't need explicit ordering.
+func orderChanged(kept, edited []string) bool {
+	i := 0
+	for _, uuid := range edited {
+		if uuid == "" {
+			if i < len(kept) {
+				return true
+			}
+			continue
+		}
+		if i >= len(kept) || kept[i] != uuid {
+			return true
+		}
+		i++
+	}
+	return false
+}
+
+// summary describes the plan for the confirmation dialog.
+func (p documentPlan) summary() string {
+	var lines []string
+	titles := func(sections []docSection) string {
+		names := make([]string, len(sections))
+		for i, s := range sections {
+			names[i] = s.Title
+		}
+		return strings.Join(names, ", ")
+	}
+	if len(p.Edits) > 0 {
+		lines = append(lines, "Update: "+titles(p.Edits))
+	}
+	switch n := len(p.Creates); {
+	case n == 1:
+		lines = append(lines, "Create 1 new child note")
+	case n > 1:
+		lines = append(lines, fmt.Sprintf("Create %d new child notes", n))
+	}
+	if len(p.Deletes) > 0 {
+		lines = append(lines, "Delete (to the trash): "+titles(p.Deletes))
+	}
+	if p.Order != nil {
+		lines = append(lines, "Reorder children")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// trimBlankLines drops leading and trailing blank lines and trailing
+// whitespace, so sections compare equal regardless of the spacing around
+// their markers.
+func trimBlankLines(s string) string {
+	lines := strings.Split(s, "\n")
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), " \t\r")
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
+
+// frontmatterOrder returns the note's `order` field, or nil when unset.
+func frontmatterOrder(file string) *int {
+	lines := strings.Split(file, "\n")
+	end := contentStart(lines)
+	if end < 2 {
+		return nil
+	}
+	var fm struct {
+		Order *int `yaml:"order"`
+	}
+	if err := yaml.Unmarshal([]byte(strings.Join(lines[1:end-1], "\n")), &fm); err != nil {
+		return nil
+	}
+	return fm.Order
+}
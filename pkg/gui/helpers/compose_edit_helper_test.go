@@ -0,0 +1,153 @@
+package helpers
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func composeEditSections() []docSection {
+	return []docSection{
+		{UUID: "p", Title: "Parent", Body: "Parent intro\n"},
+		{UUID: "a", Title: "Alpha", Body: "Alpha line 1\nAlpha line 2\n"},
+		{UUID: "b", Title: "Beta", Body: "\nBeta line\n"},
+		{UUID: "c", Title: "Gamma", Body: "Gamma line\n"},
+	}
+}
+
+func TestPlanDocument_UnchangedRoundTrip(t *testing.T) {
+	sections := composeEditSections()
+	plan, err := planDocument(sections, buildDocument(sections), "p")
+	if err != nil {
+		t.Fatalf("planDocument: %v", err)
+	}
+	if !plan.empty() {
+		t.Errorf("plan = %+v, want empty", plan)
+	}
+}
+
+func TestPlanDocument_EditsCreatesDeletesAndReorders(t *testing.T) {
+	sections := composeEditSections()
+	edited := buildDocument(sections)
+	edited = strings.Replace(edited, "Alpha line 2", "Alpha line 2 (edited)", 1)
+	// Drop Gamma, move Beta ahead of Alpha, and add a new section at the end.
+	edited = edited[:strings.Index(edited, "<!-- child:c")]
+	alpha := edited[strings.Index(edited, "<!-- child:a"):strings.Index(edited, "<!-- child:b")]
+	edited = strings.Replace(edited, alpha, "", 1) + alpha
+	edited += "<!-- child:new -->\nA brand new child\n"
+
+	plan, err := planDocument(sections, edited, "p")
+	if err != nil {
+		t.Fatalf("planDocument: %v", err)
+	}
+	if len(plan.Edits) != 1 || plan.Edits[0].UUID != "a" || plan.Edits[0].Body != "Alpha line 1\nAlpha line 2 (edited)" {
+		t.Errorf("Edits = %+v", plan.Edits)
+	}
+	if !slices.Equal(plan.Creates, []string{"A brand new child"}) {
+		t.Errorf("Creates = %q", plan.Creates)
+	}
+	if len(plan.Deletes) != 1 || plan.Deletes[0].UUID != "c" {
+		t.Errorf("Deletes = %+v", plan.Deletes)
+	}
+	if want := []string{"b", "a", ""}; !slices.Equal(plan.Order, want) {
+		t.Errorf("Order = %q, want %q", plan.Order, want)
+	}
+}
+
+func TestPlanDocument_AppendingNewChildKeepsOrder(t *testing.T) {
+	sections := composeEditSections()
+	edited := buildDocument(sections) + "\n<!-- child:new -->\n\nLast child\n\n<!-- child:new -->\n\n"
+
+	plan, err := planDocument(sections, edited, "p")
+	if err != nil {
+		t.Fatalf("planDocument: %v", err)
+	}
+	if !slices.Equal(plan.Creates, []string{"Last child"}) {
+		t.Errorf("Creates = %q (empty new sections should be skipped)", plan.Creates)
+	}
+	if plan.Order != nil {
+		t.Errorf("Order = %q, want nil when new notes are only appended", plan.Order)
+	}
+}
+
+func TestPlanDocument_EmptiedSectionIsLeftAlone(t *testing.T) {
+	sections := composeEditSections()
+	edited := strings.Replace(buildDocument(sections), "Gamma line\n", "", 1)
+
+	plan, err := planDocument(sections, edited, "p")
+	if err != nil {
+		t.Fatalf("planDocument: %v", err)
+	}
+	if !plan.empty() {
+		t.Errorf("plan = %+v, want empty", plan)
+	}
+}
+
+func TestPlanDocument_Errors(t *testing.T) {
+	sections := composeEditSections()
+	doc := buildDocument(sections)
+	tests := []struct {
+		name   string
+		edited string
+		want   string
+	}{
+		{"unknown marker", doc + "<!-- child:zzz -->\ntext\n", "unknown section marker"},
+		{"duplicate marker", doc + "<!-- child:a -->\ntext\n", "more than once"},
+		{"parent deleted", strings.Replace(doc, "<!-- child:p Parent -->\n\nParent intro\n", "", 1), "can't be deleted"},
+		{"stray preamble", "stray text\n" + doc, "before the first section marker"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := planDocument(sections, tt.edited, "p")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFrontmatterOrder(t *testing.T) {
+	if got := frontmatterOrder("---\nuuid: a\norder: 3\n---\nbody\n"); got == nil || *got != 3 {
+		t.Errorf("order = %v, want 3", got)
+	}
+	if got := frontmatterOrder("---\nuuid: a\n---\nbody\n"); got != nil {
+		t.Errorf("order = %v, want nil", *got)
+	}
+}
+
+func TestPlanDocument_ReadOnlySections(t *testing.T) {
+	sections := composeEditSections()
+	// An embed between Alpha and Beta.
+	sections = slices.Insert(sections, 2, docSection{UUID: "e", Title: "Embedded", Body: "Embedded line\n", ReadOnly: true})
+	doc := buildDocument(sections)
+	if !strings.Contains(doc, "<!-- readonly:e Embedded -->") {
+		t.Fatalf("document should mark the embed read-only:\n%s", doc)
+	}
+
+	embed := doc[strings.Index(doc, "<!-- readonly:e"):strings.Index(doc, "<!-- child:b")]
+	moved := strings.Replace(doc, embed, "", 1) + embed
+	plan, err := planDocument(sections, moved, "p")
+	if err != nil {
+		t.Fatalf("planDocument: %v", err)
+	}
+	if !plan.empty() {
+		t.Errorf("moving a read-only section should change nothing, plan = %+v", plan)
+	}
+
+	tests := []struct {
+		name   string
+		edited string
+		want   string
+	}{
+		{"edited", strings.Replace(doc, "Embedded line", "Embedded line (edited)", 1), "isn't a child"},
+		{"deleted", strings.Replace(doc, embed, "", 1), "can't be deleted"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := planDocument(sections, tt.edited, "p")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,271 @@
+package helpers
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/donnellyk/lazyruin/pkg/commands"
+	"github.com/donnellyk/lazyruin/pkg/config"
+	"github.com/donnellyk/lazyruin/pkg/gui/context"
+	"github.com/donnellyk/lazyruin/pkg/gui/types"
+	"github.com/donnellyk/lazyruin/pkg/models"
+)
+
+// CustomCommandData is what a custom command's template sees. Note is the
+// note selected in the Notes pane or under the preview cursor, Tag the tag
+// selected in the Tags pane; each is nil elsewhere.
+type CustomCommandData struct {
+	Note         *models.Note
+	Tag          *models.Tag
+	SelectedLine string // raw source line under the preview cursor
+	VaultPath    string
+	Form         map[string]string // prompt answers, by prompt key
+}
+
+// CustomCommandsHelper runs the shell commands from `custom_commands:`.
+type CustomCommandsHelper struct {
+	c *HelperCommon
+}
+
+func NewCustomCommandsHelper(c *HelperCommon) *CustomCommandsHelper {
+	return &CustomCommandsHelper{c: c}
+}
+
+// ParseCustomCommand parses a command template. Every value a template
+// prints is shell-quoted, so note titles, lines and prompt answers reach
+// the command as single words and can't inject shell syntax. `raw` prints
+// a value as is, for authors who want it word-split or expanded;
+// `quote` is kept for templates written before quoting was automatic.
+func ParseCustomCommand(command string) (*template.Template, error) {
+	tmpl, err := template.New("command").
+		Funcs(template.FuncMap{
+			"quote":             func(v any) shellWord { return shellWord(shellQuote(fmt.Sprint(v))) },
+			"raw":               func(v any) shellWord { return shellWord(fmt.Sprint(v)) },
+			shellEscapeFuncName: shellEscape,
+		}).
+		Option("missingkey=error").
+		Parse(command)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tmpl.Templates() {
+		if t.Tree != nil {
+			escapeActions(t.Tree.Root)
+		}
+	}
+	return tmpl, nil
+}
+
+// shellWord is template output that is already safe to hand to the shell.
+type shellWord string
+
+const shellEscapeFuncName = "_shell_escape"
+
+// shellEscape quotes a printed value unless quote or raw produced it.
+func shellEscape(v any) string {
+	if w, ok := v.(shellWord); ok {
+		return string(w)
+	}
+	return shellQuote(fmt.Sprint(v))
+}
+
+// escapeActions pipes every printing action under node through
+// shellEscape, the way html/template adds its escapers. Actions that only
+// declare or assign a variable print nothing and are left alone.
+func escapeActions(node parse.Node) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			escapeActions(child)
+		}
+	case *parse.ActionNode:
+		if len(n.Pipe.Decl) > 0 {
+			return
+		}
+		n.Pipe.Cmds = append(n.Pipe.Cmds, &parse.CommandNode{
+			NodeType: parse.NodeCommand,
+			Pos:      n.Pos,
+			Args:     []parse.Node{parse.NewIdentifier(shellEscapeFuncName).SetPos(n.Pos)},
+		})
+	case *parse.IfNode:
+		escapeActions(n.List)
+		escapeActions(n.ElseList)
+	case *parse.RangeNode:
+		escapeActions(n.List)
+		escapeActions(n.ElseList)
+	case *parse.WithNode:
+		escapeActions(n.List)
+		escapeActions(n.ElseList)
+	}
+}
+
+// shellQuote quotes s for a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// Run collects the focused note, tag and line, asks the command's prompts
+// in order and runs it. An empty answer cancels.
+func (self *CustomCommandsHelper) Run(cc config.CustomCommand) error {
+	data := self.data()
+	self.prompt(cc, data, 0)
+	return nil
+}
+
+func (self *CustomCommandsHelper) prompt(cc config.CustomCommand, data CustomCommandData, i int) {
+	if i == len(cc.Prompts) {
+		self.execute(cc, data)
+		return
+	}
+	p := cc.Prompts[i]
+	title := p.Title
+	if title == "" {
+		title = p.Key
+	}
+	self.c.Helpers().InputPopup().OpenInputPopup(&types.InputPopupConfig{
+		Title:  title,
+		Footer: " Enter: Continue | Esc: Cancel ",
+		Seed:   p.InitialValue,
+		OnAccept: func(raw string, _ *types.CompletionItem) error {
+			data.Form[p.Key] = raw
+			self.prompt(cc, data, i+1)
+			return nil
+		},
+	})
+}
+
+// data snapshots the state a command template can refer to.
+func (self *CustomCommandsHelper) data() CustomCommandData {
+	gui := self.c.GuiCommon()
+	contexts := gui.Contexts()
+	cmd := self.c.RuinCmd()
+	d := CustomCommandData{VaultPath: cmd.VaultPath(), Form: map[string]string{}}
+
+	var note *models.Note
+	switch key := gui.CurrentContextKey(); {
+	case key == "notes":
+		note = contexts.Notes.Selected()
+	case key == "tags":
+		if tag := contexts.Tags.Selected(); tag != nil {
+			t := *tag
+			d.Tag = &t
+		}
+	case context.IsPreviewContextKey(key):
+		note = self.c.Helpers().Preview().CurrentPreviewCard()
+		if target := self.c.Helpers().PreviewLineOps().ResolveTarget(); target != nil {
+			d.SelectedLine, _, _ = readSourceLine(vaultPath(cmd, target.Path), target.LineNum)
+		}
+	}
+	if note != nil {
+		n := *note
+		n.Path = vaultPath(cmd, n.Path)
+		d.Note = &n
+	}
+	return d
+}
+
+func (self *CustomCommandsHelper) execute(cc config.CustomCommand, data CustomCommandData) {
+	gui := self.c.GuiCommon()
+	tmpl, err := ParseCustomCommand(cc.Command)
+	if err != nil {
+		gui.ShowError(fmt.Errorf("custom command %q: %w", cc.Name, err))
+		return
+	}
+	var script bytes.Buffer
+	if err := tmpl.Execute(&script, data); err != nil {
+		gui.ShowError(fmt.Errorf("custom command %q: %w", cc.Name, err))
+		return
+	}
+
+	switch cc.Output {
+	case config.CustomCommandOutputSuspend:
+		self.runSuspended(cc, script.String())
+	default:
+		self.runCaptured(cc, script.String())
+	}
+}
+
+// shellCommand runs script with sh from the vault directory.
+func (self *CustomCommandsHelper) shellCommand(script string) *exec.Cmd {
+	cmd := exec.Command("sh", "-c", script)
+	cmd.Dir = self.c.RuinCmd().VaultPath()
+	return cmd
+}
+
+// runSuspended hands the terminal to the command and waits for Enter
+// before resuming, so its output can be read.
+func (self *CustomCommandsHelper) runSuspended(cc config.CustomCommand, script string) {
+	gui := self.c.GuiCommon()
+	if err := gui.Suspend(); err != nil {
+		gui.ShowError(err)
+		return
+	}
+	cmd := self.shellCommand(script)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	runErr := cmd.Run()
+	fmt.Print("\nPress Enter to return to lazyruin...")
+	bufio.NewReader(os.Stdin).ReadString('\n')
+	if err := gui.Resume(); err != nil {
+		gui.ShowError(err)
+		return
+	}
+	if runErr != nil {
+		gui.ShowError(fmt.Errorf("%s: %w", cc.Name, runErr))
+	}
+	self.refresh()
+}
+
+// runCaptured runs the command off the main goroutine and reports its
+// output in the status bar, or in a popup for the popup output mode.
+func (self *CustomCommandsHelper) runCaptured(cc config.CustomCommand, script string) {
+	self.c.Helpers().Async().Run("customCommand:"+cc.Name, func(_ *commands.RuinCommand) func() error {
+		out, err := self.shellCommand(script).CombinedOutput()
+		return func() error {
+			gui := self.c.GuiCommon()
+			output := strings.TrimRight(string(out), "\n")
+			switch {
+			case cc.Output == config.CustomCommandOutputPopup && output != "":
+				var items []types.MenuItem
+				for _, line := range strings.Split(output, "\n") {
+					items = append(items, types.MenuItem{Label: strings.ReplaceAll(line, "\t", "    ")})
+				}
+				gui.ShowMenuDialog(cc.Name, items)
+				if err != nil {
+					gui.ShowError(fmt.Errorf("%s: %w", cc.Name, err))
+				}
+			case err != nil:
+				if output != "" {
+					err = fmt.Errorf("%w: %s", err, lastLine(output))
+				}
+				gui.ShowError(fmt.Errorf("%s: %w", cc.Name, err))
+			default:
+				gui.ShowStatus(fmt.Sprintf("Ran %q", cc.Name))
+			}
+			self.refresh()
+			return nil
+		}
+	})
+}
+
+// refresh reloads what a command may have changed in the vault.
+func (self *CustomCommandsHelper) refresh() {
+	self.c.Helpers().Tags().RefreshTags(false)
+	self.c.Helpers().Queries().RefreshParents(false)
+	self.c.Helpers().Preview().ReloadActivePreview()
+	self.c.GuiCommon().RenderAll()
+}
+
+func lastLine(s string) string {
+	return s[strings.LastIndex(s, "\n")+1:]
+}
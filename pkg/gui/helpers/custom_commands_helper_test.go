@@ -0,0 +1,49 @@
+package helpers
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/donnellyk/lazyruin/pkg/models"
+)
+
+func TestParseCustomCommand_QuotesEveryValue(t *testing.T) {
+	data := CustomCommandData{
+		Note:         &models.Note{Title: "$(touch pwned) it's", Path: "/v/a b.md"},
+		SelectedLine: "- run `id` now",
+		Form:         map[string]string{"Flags": "-n 3"},
+	}
+	tests := []struct {
+		command string
+		want    string
+	}{
+		{`echo {{.Note.Title}}`, `echo '$(touch pwned) it'\''s'`},
+		{`echo {{quote .Note.Path}}`, `echo '/v/a b.md'`},
+		{`grep "#"{{.SelectedLine}}`, `grep "#"'- run ` + "`id`" + ` now'`},
+		{`head {{raw .Form.Flags}}`, `head -n 3`},
+		{`{{with .Note}}open {{.Path}}{{end}}`, `open '/v/a b.md'`},
+		{`{{$p := .Note.Path}}cat {{$p}}`, `cat '/v/a b.md'`},
+	}
+	for _, tt := range tests {
+		tmpl, err := ParseCustomCommand(tt.command)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.command, err)
+		}
+		var sb strings.Builder
+		if err := tmpl.Execute(&sb, data); err != nil {
+			t.Fatalf("%s: %v", tt.command, err)
+		}
+		if sb.String() != tt.want {
+			t.Errorf("%s = %q, want %q", tt.command, sb.String(), tt.want)
+		}
+	}
+
+	tmpl, _ := ParseCustomCommand(`printf %s {{.Note.Title}}`)
+	var sb strings.Builder
+	_ = tmpl.Execute(&sb, data)
+	out, err := exec.Command("sh", "-c", sb.String()).Output()
+	if err != nil || string(out) != data.Note.Title {
+		t.Errorf("shell saw %q (%v), want the title verbatim", out, err)
+	}
+}
@@ -20,10 +20,7 @@ func NewDatePreviewHelper(c *HelperCommon) *DatePreviewHelper {
 // LoadDatePreview loads the date preview for the given date as a committed
 // navigation: capture-on-departure, fetch data, record a new history entry.
 func (self *DatePreviewHelper) LoadDatePreview(date string) error {
-	t, _ := time.Parse("2006-01-02", date)
-	title := t.Format("Monday, January 2 2006")
-
-	return self.c.Helpers().Navigator().NavigateTo("datePreview", title, func() error {
+	return self.c.Helpers().Navigator().NavigateTo("datePreview", dateTitle(date), func() error {
 		self.loadDatePreviewState(date)
 		return nil
 	})
@@ -31,15 +28,12 @@ func (self *DatePreviewHelper) LoadDatePreview(date string) error {
 
 // HoverDatePreview shows the same date-preview content as
 // LoadDatePreview but as a hover (no nav-history entry). Used by
-// callers that want preview-on-cursor-move without committing.
-func (self *DatePreviewHelper) HoverDatePreview(date string) error {
-	t, _ := time.Parse("2006-01-02", date)
-	title := t.Format("Monday, January 2 2006")
-
-	return self.c.Helpers().Navigator().ShowHover("datePreview", title, func() error {
-		self.loadDatePreviewState(date)
-		return nil
-	})
+// callers that want preview-on-cursor-move without committing. The
+// fetch runs in the background; see Navigator.ShowHoverAsync.
+func (self *DatePreviewHelper) HoverDatePreview(date string) {
+	self.hoverDateState(date, dateTitle(date), func(cmd *commands.RuinCommand) datePreviewData {
+		return fetchDate(cmd, date)
+	}, self.dateRequery(date))
 }
 
 // LoadDateRangePreview loads a date-preview window over the half-open
@@ -56,53 +50,34 @@ func (self *DatePreviewHelper) LoadDateRangePreview(title, start, end string) er
 }
 
 // HoverDateRangePreview is the hover counterpart to LoadDateRangePreview.
-func (self *DatePreviewHelper) HoverDateRangePreview(title, start, end string) error {
-	return self.c.Helpers().Navigator().ShowHover("datePreview", title, func() error {
-		self.loadDateRangeState(title, start, end)
-		return nil
-	})
+func (self *DatePreviewHelper) HoverDateRangePreview(title, start, end string) {
+	self.hoverDateState(start+".."+end, title, func(cmd *commands.RuinCommand) datePreviewData {
+		return fetchDateRange(cmd, start, end)
+	}, self.dateRangeRequery(start, end))
+}
+
+// datePreviewData holds the three date-preview sections, fetched together
+// so a hover can load them off the main goroutine.
+type datePreviewData struct {
+	tagPicks  []models.PickResult
+	todoPicks []models.PickResult
+	notes     []models.Note
+}
+
+// dateTitle formats an ISO date as the date preview's title.
+func dateTitle(date string) string {
+	t, _ := time.Parse("2006-01-02", date)
+	return t.Format("Monday, January 2 2006")
 }
 
 // loadDatePreviewState populates DatePreview context state for the given
 // date without touching history or context focus. Used as the load closure
 // for Navigator.NavigateTo.
 func (self *DatePreviewHelper) loadDatePreviewState(date string) {
-	tagPicks, _ := self.c.RuinCmd().Pick.Pick(nil, commands.PickOpts{Date: "@" + date, All: true})
-	tagPicks = filterOutTodoLines(tagPicks)
-	tagPicks = sortDonePicksLast(tagPicks)
-
-	todoPicks, _ := self.c.RuinCmd().Pick.Pick(nil, commands.PickOpts{
-		Date: "@" + date,
-		Todo: true,
-		All:  true,
-	})
-
-	opts := commands.SearchOptions{
-		Sort: "created", Limit: 100, IncludeContent: true, StripTitle: true,
-	}
-	created, _ := self.c.RuinCmd().Search.Search("created:"+date, opts)
-	updated, _ := self.c.RuinCmd().Search.Search("updated:"+date, opts)
-	notes := DeduplicateNotes(created, updated)
-
-	gui := self.c.GuiCommon()
-	dp := gui.Contexts().DatePreview
-	dp.TargetDate = date
-	dp.TagPicks = tagPicks
-	dp.TodoPicks = todoPicks
-	dp.Notes = notes
-	self.c.Helpers().TitleCache().PutNotes(notes)
-	self.c.Helpers().TitleCache().ResolveUnknownParents(notes)
-	dp.SelectedCardIdx = 0
-	ns := dp.NavState()
-	ns.CursorLine = 1
-	ns.ScrollOffset = 0
-	gui.Contexts().ActivePreviewKey = "datePreview"
-	dp.Requery = self.dateRequery(date)
-
-	t, _ := time.Parse("2006-01-02", date)
-	dp.SetTitle(t.Format("Monday, January 2 2006"))
-
-	gui.RenderPreview()
+	data := fetchDate(self.c.RuinCmd(), date)
+	self.c.Helpers().TitleCache().PutNotes(data.notes)
+	self.c.Helpers().TitleCache().ResolveUnknownParents(data.notes)
+	self.applyDateState(date, dateTitle(date), data, self.dateRequery(date))
 }
 
 // loadDateRangeState fills DatePreview state from a [start, end] range
@@ -110,33 +85,74 @@ func (self *DatePreviewHelper) loadDatePreviewState(date string) {
 // as a sentinel — it's only used for snapshot restore, and the
 // Requery closure handles the actual re-fetch.
 func (self *DatePreviewHelper) loadDateRangeState(title, start, end string) {
-	tagPicks, todoPicks, notes := self.fetchDateRange(start, end)
+	data := fetchDateRange(self.c.RuinCmd(), start, end)
+	self.c.Helpers().TitleCache().PutNotes(data.notes)
+	self.c.Helpers().TitleCache().ResolveUnknownParents(data.notes)
+	self.applyDateState(start+".."+end, title, data, self.dateRangeRequery(start, end))
+}
+
+// hoverDateState is the async counterpart to the load*State methods:
+// fetch and parent-title resolution run in the background, and only
+// applyDateState runs on the main goroutine.
+func (self *DatePreviewHelper) hoverDateState(target, title string, fetch func(cmd *commands.RuinCommand) datePreviewData, requery context.DatePreviewRequery) {
+	titles := self.c.Helpers().TitleCache()
+	resolveParents := titles.ParentResolver()
+	self.c.Helpers().Navigator().ShowHoverAsync("datePreview", title, func(cmd *commands.RuinCommand) (func() error, error) {
+		data := fetch(cmd)
+		titles.PutNotes(data.notes)
+		resolveParents(cmd, data.notes)
+		return func() error {
+			self.applyDateState(target, title, data, requery)
+			return nil
+		}, nil
+	})
+}
 
+// applyDateState assigns fetched sections to the DatePreview context,
+// resets the cursor, and renders. It does no I/O.
+func (self *DatePreviewHelper) applyDateState(target, title string, data datePreviewData, requery context.DatePreviewRequery) {
 	gui := self.c.GuiCommon()
 	dp := gui.Contexts().DatePreview
-	dp.TargetDate = start + ".." + end
-	dp.TagPicks = tagPicks
-	dp.TodoPicks = todoPicks
-	dp.Notes = notes
-	self.c.Helpers().TitleCache().PutNotes(notes)
-	self.c.Helpers().TitleCache().ResolveUnknownParents(notes)
+	dp.TargetDate = target
+	dp.TagPicks = data.tagPicks
+	dp.TodoPicks = data.todoPicks
+	dp.Notes = data.notes
 	dp.SelectedCardIdx = 0
 	ns := dp.NavState()
 	ns.CursorLine = 1
 	ns.ScrollOffset = 0
 	gui.Contexts().ActivePreviewKey = "datePreview"
-	dp.Requery = self.dateRangeRequery(start, end)
+	dp.Requery = requery
 	dp.SetTitle(title)
 
 	gui.RenderPreview()
 }
 
-func (self *DatePreviewHelper) fetchDateRange(start, end string) ([]models.PickResult, []models.PickResult, []models.Note) {
+func fetchDate(cmd *commands.RuinCommand, date string) datePreviewData {
+	tagPicks, _ := cmd.Pick.Pick(nil, commands.PickOpts{Date: "@" + date, All: true})
+	tagPicks = filterOutTodoLines(tagPicks)
+	tagPicks = sortDonePicksLast(tagPicks)
+
+	todoPicks, _ := cmd.Pick.Pick(nil, commands.PickOpts{
+		Date: "@" + date,
+		Todo: true,
+		All:  true,
+	})
+
+	opts := commands.SearchOptions{
+		Sort: "created", Limit: 100, IncludeContent: true, StripTitle: true,
+	}
+	created, _ := cmd.Search.Search("created:"+date, opts)
+	updated, _ := cmd.Search.Search("updated:"+date, opts)
+	return datePreviewData{tagPicks: tagPicks, todoPicks: todoPicks, notes: DeduplicateNotes(created, updated)}
+}
+
+func fetchDateRange(cmd *commands.RuinCommand, start, end string) datePreviewData {
 	between := "@between:" + start + "," + end
-	tagPicks, _ := self.c.RuinCmd().Pick.Pick(nil, commands.PickOpts{Date: between, All: true})
+	tagPicks, _ := cmd.Pick.Pick(nil, commands.PickOpts{Date: between, All: true})
 	tagPicks = sortDonePicksLast(filterOutTodoLines(tagPicks))
 
-	todoPicks, _ := self.c.RuinCmd().Pick.Pick(nil, commands.PickOpts{
+	todoPicks, _ := cmd.Pick.Pick(nil, commands.PickOpts{
 		Date: between,
 		Todo: true,
 		All:  true,
@@ -145,14 +161,14 @@ func (self *DatePreviewHelper) fetchDateRange(start, end string) ([]models.PickR
 	opts := commands.SearchOptions{
 		Sort: "created", Limit: 100, IncludeContent: true, StripTitle: true,
 	}
-	notes, _ := self.c.RuinCmd().Search.Search("between:"+start+","+end, opts)
-	return tagPicks, todoPicks, notes
+	notes, _ := cmd.Search.Search("between:"+start+","+end, opts)
+	return datePreviewData{tagPicks: tagPicks, todoPicks: todoPicks, notes: notes}
 }
 
 func (self *DatePreviewHelper) dateRangeRequery(start, end string) context.DatePreviewRequery {
 	return func() ([]models.PickResult, []models.PickResult, []models.Note, error) {
-		tag, todo, notes := self.fetchDateRange(start, end)
-		return tag, todo, notes, nil
+		data := fetchDateRange(self.c.RuinCmd(), start, end)
+		return data.tagPicks, data.todoPicks, data.notes, nil
 	}
 }
 
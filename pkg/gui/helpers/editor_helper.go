@@ -20,34 +20,28 @@ func NewEditorHelper(c *HelperCommon) *EditorHelper {
 // resumes the TUI, and runs ruin doctor on the file. The caller is
 // responsible for refreshing the appropriate preview afterward.
 func (self *EditorHelper) OpenFileInEditor(path string) error {
-	gui := self.c.GuiCommon()
-
-	if err := gui.Suspend(); err != nil {
-		return err
-	}
-
-	editor := self.c.Config().Editor
-	if editor == "" {
-		editor = os.Getenv("EDITOR")
-	}
-	if editor == "" {
-		editor = "vim"
-	}
-
-	parts := strings.Fields(editor)
-	cmd := exec.Command(parts[0], append(parts[1:], path)...)
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	cmd.Run()
-
-	if err := gui.Resume(); err != nil {
+	before, _ := os.Stat(path)
+	if err := self.RunEditor(path); err != nil {
 		return err
 	}
 
 	// Reindex the edited file so ruin's frontmatter/tags/index are up to date.
 	self.c.RuinCmd().Doctor(path)
 
+	if after, err := os.Stat(path); err == nil && (before == nil || !after.ModTime().Equal(before.ModTime())) {
+		var frontmatter []byte
+		if data, err := os.ReadFile(path); err == nil {
+			frontmatter = extractFrontmatter(data)
+		}
+		self.c.Helpers().Hooks().Fire(HookEvent{
+			Name:    HookEdit,
+			Path:    path,
+			UUID:    frontmatterField(frontmatter, "uuid"),
+			Title:   frontmatterField(frontmatter, "title"),
+			Changed: []string{"file"},
+		})
+	}
+
 	self.c.Helpers().Tags().RefreshTags(false)
 	self.c.Helpers().Queries().RefreshQueries(false)
 	self.c.Helpers().Queries().RefreshParents(false)
@@ -64,3 +58,31 @@ func (self *EditorHelper) OpenInEditor(path string) error {
 	self.c.GuiCommon().RenderAll()
 	return nil
 }
+
+// RunEditor suspends the TUI, opens path in $EDITOR and resumes once the
+// editor exits. Unlike OpenFileInEditor it doesn't reindex or refresh, so
+// it suits scratch files outside the vault.
+func (self *EditorHelper) RunEditor(path string) error {
+	gui := self.c.GuiCommon()
+
+	if err := gui.Suspend(); err != nil {
+		return err
+	}
+
+	editor := self.c.Config().Editor
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vim"
+	}
+
+	parts := strings.Fields(editor)
+	cmd := exec.Command(parts[0], append(parts[1:], path)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Run()
+
+	return gui.Resume()
+}
@@ -0,0 +1,203 @@
+package helpers
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/donnellyk/lazyruin/pkg/config"
+	"github.com/donnellyk/lazyruin/pkg/export"
+	"github.com/donnellyk/lazyruin/pkg/gui/types"
+	"github.com/donnellyk/lazyruin/pkg/models"
+)
+
+// ExportHelper writes the composed document, card list or pick results in
+// the preview out to a Markdown or HTML file.
+type ExportHelper struct {
+	c   *HelperCommon
+	now func() time.Time
+}
+
+func NewExportHelper(c *HelperCommon) *ExportHelper {
+	return &ExportHelper{c: c, now: time.Now}
+}
+
+// Export offers the export formats for the active preview, then asks
+// where to write the file.
+func (self *ExportHelper) Export() error {
+	gui := self.c.GuiCommon()
+	doc := self.document()
+	if len(doc.Sections) == 0 {
+		gui.ShowStatus("Nothing to export")
+		return nil
+	}
+	choose := func(format export.Format, frontMatter bool) func() error {
+		return func() error {
+			self.promptPath(doc, format, frontMatter)
+			return nil
+		}
+	}
+	gui.ShowMenuDialog("Export", []types.MenuItem{
+		{Label: "Markdown", Key: "m", OnRun: choose(export.FormatMarkdown, false)},
+		{Label: "Markdown with front matter", Key: "M", OnRun: choose(export.FormatMarkdown, true)},
+		{Label: "HTML", Key: "h", OnRun: choose(export.FormatHTML, false)},
+		{Label: "HTML with front matter", Key: "H", OnRun: choose(export.FormatHTML, true)},
+	})
+	return nil
+}
+
+// promptPath asks for the file to write, seeded with a name from the
+// document's title. Relative paths resolve against the working directory.
+func (self *ExportHelper) promptPath(doc export.Document, format export.Format, frontMatter bool) {
+	gui := self.c.GuiCommon()
+	self.c.Helpers().InputPopup().OpenInputPopup(&types.InputPopupConfig{
+		Title:  "Export To",
+		Footer: " Enter: export | Esc: cancel ",
+		Seed:   export.FileName(doc.Title, format),
+		OnAccept: func(raw string, _ *types.CompletionItem) error {
+			raw = strings.TrimSpace(raw)
+			if raw == "" {
+				return nil
+			}
+			path := config.ExpandPath(raw)
+			write := func() error {
+				self.write(doc, format, frontMatter, path)
+				return nil
+			}
+			if _, err := os.Stat(path); err == nil {
+				gui.ShowConfirm("Overwrite File", path+" already exists. Overwrite it?", write)
+				return nil
+			}
+			return write()
+		},
+	})
+}
+
+// write renders doc and writes it to path, reporting the outcome.
+func (self *ExportHelper) write(doc export.Document, format export.Format, frontMatter bool, path string) {
+	gui := self.c.GuiCommon()
+	out, err := export.Render(doc, format, export.Options{FrontMatter: frontMatter, Now: self.now()})
+	if err == nil {
+		err = os.WriteFile(path, []byte(out), 0o644)
+	}
+	if err != nil {
+		gui.ShowError(err)
+		return
+	}
+	gui.ShowStatus("Exported to " + path)
+}
+
+// document builds the export document for the active preview: the
+// composed note split along its source map, one section per card, or one
+// per pick result holding its matched lines. The date preview has none.
+func (self *ExportHelper) document() export.Document {
+	contexts := self.c.GuiCommon().Contexts()
+	switch contexts.ActivePreviewKey {
+	case "compose":
+		comp := contexts.Compose
+		title := comp.Note.Title
+		if title == "" {
+			title = comp.Title()
+		}
+		head := export.Section{UUID: comp.Note.UUID, Path: self.relPath(comp.Note.Path)}
+		return export.Document{Title: title, Sections: self.splitComposed(head, self.content(comp.Note), comp.SourceMap)}
+	case "pickResults":
+		pr := contexts.PickResults
+		doc := export.Document{Title: pr.Title()}
+		for _, r := range pr.Results {
+			lines := make([]string, 0, len(r.Matches))
+			for _, m := range r.Matches {
+				lines = append(lines, asListItem(m.Content))
+			}
+			doc.Sections = append(doc.Sections, export.Section{
+				Heading: r.Title, UUID: r.UUID, Path: self.relPath(r.File), Body: strings.Join(lines, "\n"),
+			})
+		}
+		return doc
+	case "cardList":
+		cl := contexts.CardList
+		composed := cl.DisplayState().ShowCompose && len(cl.ComposedCards) == len(cl.Cards) && len(cl.ComposedSourceMaps) == len(cl.Cards)
+		doc := export.Document{Title: cl.Title()}
+		for i, card := range cl.Cards {
+			var sm []models.SourceMapEntry
+			if composed && cl.ComposedCards[i] != nil {
+				card = *cl.ComposedCards[i]
+				sm = cl.ComposedSourceMaps[i]
+			}
+			head := export.Section{Heading: card.Title, UUID: card.UUID, Path: self.relPath(card.Path)}
+			doc.Sections = append(doc.Sections, self.splitComposed(head, self.content(card), sm)...)
+		}
+		return doc
+	}
+	return export.Document{}
+}
+
+func (self *ExportHelper) content(note models.Note) string {
+	if note.Content != "" {
+		return note.Content
+	}
+	body, _ := readNoteBodyContent(vaultPath(self.c.RuinCmd(), note.Path))
+	return body
+}
+
+// splitComposed cuts composed content into sections along its source map.
+// Text before the first mapped range stays with head, each range becomes
+// a section anchored to its child note, and text between ranges stays
+// with the section before it.
+func (self *ExportHelper) splitComposed(head export.Section, content string, sourceMap []models.SourceMapEntry) []export.Section {
+	lines := strings.Split(content, "\n")
+	sections := []export.Section{head}
+	next := 0
+	for _, e := range sourceMap {
+		start := max(e.StartLine-1, next)
+		end := min(e.EndLine, len(lines))
+		if start >= end {
+			continue
+		}
+		last := &sections[len(sections)-1]
+		last.Body = appendLines(last.Body, lines[next:start])
+		sections = append(sections, export.Section{
+			UUID: e.UUID, Path: self.relPath(e.Path), Body: strings.Join(lines[start:end], "\n"),
+		})
+		next = end
+	}
+	last := &sections[len(sections)-1]
+	last.Body = appendLines(last.Body, lines[next:])
+	return sections
+}
+
+func appendLines(body string, lines []string) string {
+	if len(lines) == 0 {
+		return body
+	}
+	if body == "" {
+		return strings.Join(lines, "\n")
+	}
+	return body + "\n" + strings.Join(lines, "\n")
+}
+
+// asListItem makes a pick line a list item, so separate lines don't run
+// together into one paragraph.
+func asListItem(line string) string {
+	trimmed := strings.TrimSpace(line)
+	for _, marker := range []string{"- ", "* ", "+ "} {
+		if strings.HasPrefix(trimmed, marker) {
+			return trimmed
+		}
+	}
+	return "- " + trimmed
+}
+
+// relPath shortens a note path to be relative to the vault when it lies
+// inside it.
+func (self *ExportHelper) relPath(path string) string {
+	if path == "" {
+		return ""
+	}
+	vault := self.c.RuinCmd().VaultPath()
+	if rel, err := filepath.Rel(vault, vaultPath(self.c.RuinCmd(), path)); err == nil && !strings.HasPrefix(rel, "..") {
+		return rel
+	}
+	return path
+}
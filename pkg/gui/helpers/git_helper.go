@@ -0,0 +1,295 @@
+package helpers
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/donnellyk/lazyruin/pkg/commands"
+	"github.com/donnellyk/lazyruin/pkg/config"
+	"github.com/donnellyk/lazyruin/pkg/git"
+	"github.com/donnellyk/lazyruin/pkg/gui/context"
+)
+
+// NoteDiffTaskKey is the AsyncHelper key for loading the diff of the
+// revision selected in the note history. Moving the cursor replaces the
+// load, so holding j or k only waits on the last revision's diff.
+const NoteDiffTaskKey = "noteDiff"
+
+// autoCommitTaskKey is the AsyncHelper key of the auto-commit worker.
+const autoCommitTaskKey = "autoCommit"
+
+// GitHelper drives the note history popup and auto-commits changes made
+// from lazyruin when the vault is in a git repository.
+type GitHelper struct {
+	c *HelperCommon
+
+	// mu guards the auto-commit queue. One worker drains it in order, so
+	// commits never race for the repository's index lock, and git (with
+	// the user's commit hooks) never runs on the main goroutine.
+	mu        sync.Mutex
+	queue     []pendingCommit
+	draining  chan struct{} // open while the worker runs
+	commitErr error
+}
+
+// pendingCommit is an auto-commit waiting for the worker.
+type pendingCommit struct {
+	vault   string
+	message string
+}
+
+func NewGitHelper(c *HelperCommon) *GitHelper {
+	return &GitHelper{c: c}
+}
+
+func (self *GitHelper) ctx() *context.NoteHistoryContext {
+	return self.c.GuiCommon().Contexts().NoteHistory
+}
+
+// OpenNoteHistory lists the commits that touched the note under the
+// preview cursor. The log is read off the main goroutine, like a hover
+// load; the preview shows the selected commit's diff until the popup
+// closes.
+func (self *GitHelper) OpenNoteHistory() error {
+	gui := self.c.GuiCommon()
+	if gui.PopupActive() {
+		return nil
+	}
+	card := self.c.Helpers().Preview().CurrentPreviewCard()
+	if card == nil || card.Path == "" {
+		return nil
+	}
+	note := *card
+	note.Path = vaultPath(self.c.RuinCmd(), card.Path)
+
+	self.c.Helpers().Async().Run(PreviewTaskKey, func(cmd *commands.RuinCommand) func() error {
+		revs, err := git.Log(cmd.Context(), note.Path)
+		var mtime time.Time
+		if err == nil && len(revs) > 0 {
+			var info os.FileInfo
+			if info, err = os.Stat(note.Path); err == nil {
+				mtime = info.ModTime()
+			}
+		}
+		return func() error {
+			if gui.PopupActive() {
+				return nil
+			}
+			if errors.Is(err, git.ErrNotRepo) {
+				gui.ShowStatus("Vault is not in a git repository")
+				return nil
+			}
+			if err != nil {
+				gui.ShowError(err)
+				return nil
+			}
+			if len(revs) == 0 {
+				gui.ShowStatus(fmt.Sprintf("No commits touch %q", note.Title))
+				return nil
+			}
+
+			ctx := self.ctx()
+			ctx.Note = note
+			ctx.Mtime = mtime
+			ctx.Revisions = revs
+			ctx.SelectedIdx = 0
+			gui.PushContextByKey("noteHistory")
+			self.loadDiff()
+			gui.RenderPreview()
+			return nil
+		}
+	})
+	return nil
+}
+
+// loadDiff fetches the diff of the selected revision in the background.
+// A result that arrives after the cursor moved on is dropped.
+func (self *GitHelper) loadDiff() {
+	ctx := self.ctx()
+	ctx.Diff, ctx.DiffErr, ctx.DiffScroll = "", nil, 0
+	rev := ctx.Selected()
+	if rev == nil {
+		self.c.Helpers().Async().Cancel(NoteDiffTaskKey)
+		return
+	}
+	selected := *rev
+	self.c.Helpers().Async().Run(NoteDiffTaskKey, func(cmd *commands.RuinCommand) func() error {
+		diff, err := git.Diff(cmd.Context(), selected)
+		return func() error {
+			if rev := ctx.Selected(); rev == nil || rev.Hash != selected.Hash {
+				return nil
+			}
+			ctx.Diff, ctx.DiffErr = diff, err
+			self.c.GuiCommon().RenderPreview()
+			return nil
+		}
+	})
+}
+
+// Move moves the cursor by delta revisions and shows the new diff.
+func (self *GitHelper) Move(delta int) error {
+	ctx := self.ctx()
+	idx := ctx.SelectedIdx + delta
+	if idx < 0 || idx >= len(ctx.Revisions) {
+		return nil
+	}
+	ctx.SelectedIdx = idx
+	self.loadDiff()
+	self.c.GuiCommon().RenderPreview()
+	return nil
+}
+
+// ScrollDiff scrolls the diff in the preview by delta lines.
+func (self *GitHelper) ScrollDiff(delta int) error {
+	ctx := self.ctx()
+	ctx.DiffScroll = max(0, min(ctx.DiffScroll+delta, strings.Count(ctx.Diff, "\n")-1))
+	self.c.GuiCommon().RenderPreview()
+	return nil
+}
+
+// Restore asks to replace the note's body with the selected revision's.
+// The current frontmatter is kept, so the note's identity and metadata
+// don't roll back with it.
+func (self *GitHelper) Restore() error {
+	ctx := self.ctx()
+	rev := ctx.Selected()
+	if rev == nil {
+		return nil
+	}
+	revCopy := *rev
+	msg := fmt.Sprintf("Replace the body of %q with the version from %s (%s)?",
+		ctx.Note.Title, revCopy.Date.Format("Jan 2 2006 15:04"), revCopy.ShortHash())
+	self.c.GuiCommon().ShowConfirm("Restore Revision", msg, func() error {
+		self.restore(revCopy)
+		return nil
+	})
+	return nil
+}
+
+func (self *GitHelper) restore(rev git.Revision) {
+	gui := self.c.GuiCommon()
+	ctx := self.ctx()
+	data, err := git.Show(rev)
+	if err != nil {
+		gui.ShowError(err)
+		return
+	}
+	note := ctx.Note
+	body := noteBody(data)
+	reindexErr, err := self.c.Helpers().Capture().journaledSaveEdit("Restore "+note.Title, note.Path, ctx.Mtime, body)
+	if errors.Is(err, errEditConflict) {
+		gui.ShowError(fmt.Errorf("%q was modified since its history was opened; not restored", filepath.Base(note.Path)))
+		return
+	}
+	if err != nil {
+		gui.ShowError(err)
+		return
+	}
+	if reindexErr != nil {
+		gui.ShowError(fmt.Errorf("restored but reindex failed (%w); run `ruin doctor` to refresh", reindexErr))
+	}
+	self.Close()
+	self.c.Helpers().Preview().ReloadActivePreview()
+	self.c.Helpers().Tags().RefreshTags(false)
+	gui.ShowStatus(fmt.Sprintf("Restored %q to %s", note.Title, rev.ShortHash()))
+}
+
+// Close closes the popup and puts the note back in the preview.
+func (self *GitHelper) Close() error {
+	self.c.Helpers().Async().Cancel(NoteDiffTaskKey)
+	self.ctx().Revisions = nil
+	self.c.GuiCommon().PopContext()
+	self.c.GuiCommon().RenderPreview()
+	return nil
+}
+
+// AutoCommit commits the vault after a change when git.auto_commit is on.
+// action describes the change, as in the undo history. The commit is
+// queued for a background worker; since it stages the whole vault, a
+// commit that runs late also picks up the changes made in the meantime.
+// A vault outside a repository is skipped silently.
+func (self *GitHelper) AutoCommit(action string) {
+	cfg := self.c.Config()
+	if cfg == nil || !cfg.Git.AutoCommit {
+		return
+	}
+	vault := self.c.RuinCmd().VaultPath()
+
+	self.mu.Lock()
+	self.queue = append(self.queue, pendingCommit{vault: vault, message: commitMessage(cfg.Git.CommitMessage, action, vault)})
+	if self.draining != nil {
+		// The running worker picks it up.
+		self.mu.Unlock()
+		return
+	}
+	self.draining = make(chan struct{})
+	self.mu.Unlock()
+
+	self.c.Helpers().Async().Run(autoCommitTaskKey, func(_ *commands.RuinCommand) func() error {
+		self.drainCommits()
+		return self.reportCommitErr
+	})
+}
+
+// drainCommits runs the queued commits in order until the queue is empty.
+// Commits aren't bound to the task's context: a vault switch cancels
+// pending tasks, but the old vault's changes should still be committed.
+func (self *GitHelper) drainCommits() {
+	for {
+		self.mu.Lock()
+		if len(self.queue) == 0 {
+			close(self.draining)
+			self.draining = nil
+			self.mu.Unlock()
+			return
+		}
+		next := self.queue[0]
+		self.queue = self.queue[1:]
+		self.mu.Unlock()
+
+		_, err := git.CommitAll(next.vault, next.message)
+		if err != nil && !errors.Is(err, git.ErrNotRepo) {
+			self.mu.Lock()
+			self.commitErr = err
+			self.mu.Unlock()
+		}
+	}
+}
+
+// reportCommitErr shows the last auto-commit failure on the main
+// goroutine. A failure whose report was dropped (a newer worker replaced
+// the task) is shown by the next report instead.
+func (self *GitHelper) reportCommitErr() error {
+	self.mu.Lock()
+	err := self.commitErr
+	self.commitErr = nil
+	self.mu.Unlock()
+	if err != nil {
+		self.c.GuiCommon().ShowError(fmt.Errorf("auto-commit: %w", err))
+	}
+	return nil
+}
+
+// WaitCommits blocks until queued auto-commits have run. Called on quit so
+// the last changes aren't left uncommitted.
+func (self *GitHelper) WaitCommits() {
+	self.mu.Lock()
+	draining := self.draining
+	self.mu.Unlock()
+	if draining != nil {
+		<-draining
+	}
+}
+
+// commitMessage fills in the auto-commit template.
+func commitMessage(template, action, vault string) string {
+	if template == "" {
+		template = config.DefaultCommitMessage
+	}
+	return strings.NewReplacer("{action}", action, "{vault}", filepath.Base(vault)).Replace(template)
+}
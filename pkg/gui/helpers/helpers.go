@@ -32,9 +32,28 @@ type Helpers struct {
 	link             *LinkHelper
 	cardListFilter   *CardListFilterHelper
 	scratchpad       *ScratchpadHelper
+	trash            *TrashHelper
+	session          *SessionHelper
+	backlinks        *BacklinksHelper
+	composeEdit      *ComposeEditHelper
+	parentTree       *ParentTreeHelper
+	breadcrumb       *BreadcrumbHelper
+	git              *GitHelper
+	mergeConflict    *MergeConflictHelper
+	customCommands   *CustomCommandsHelper
+	hooks            *HooksHelper
+	templates        *TemplatesHelper
+	todos            *TodosHelper
+	searchHistory    *SearchHistoryHelper
+	previewFind      *PreviewFindHelper
+	export           *ExportHelper
 	notesHome        *NotesHomeHelper
 	titleCache       *TitleCacheHelper
 	navigator        *Navigator
+	async            *AsyncHelper
+	undo             *UndoHelper
+	selection        *SelectionHelper
+	vault            *VaultHelper
 }
 
 // NewHelpersOpts configures helper construction. NavigationManager is
@@ -83,9 +102,28 @@ func NewHelpersWithOpts(common *HelperCommon, opts NewHelpersOpts) *Helpers {
 		link:             NewLinkHelper(common),
 		cardListFilter:   NewCardListFilterHelper(common),
 		scratchpad:       NewScratchpadHelper(common),
+		trash:            NewTrashHelper(common),
+		session:          NewSessionHelper(common),
+		backlinks:        NewBacklinksHelper(common),
+		composeEdit:      NewComposeEditHelper(common),
+		parentTree:       NewParentTreeHelper(common),
+		breadcrumb:       NewBreadcrumbHelper(common),
+		git:              NewGitHelper(common),
+		mergeConflict:    NewMergeConflictHelper(common),
+		customCommands:   NewCustomCommandsHelper(common),
+		hooks:            NewHooksHelper(common),
+		templates:        NewTemplatesHelper(common),
+		todos:            NewTodosHelper(common),
+		searchHistory:    NewSearchHistoryHelper(common),
+		previewFind:      NewPreviewFindHelper(common),
+		export:           NewExportHelper(common),
 		notesHome:        NewNotesHomeHelper(common, opts.CustomSections),
 		titleCache:       NewTitleCacheHelper(common),
 		navigator:        NewNavigator(common, mgr),
+		async:            NewAsyncHelper(common),
+		undo:             NewUndoHelper(common),
+		selection:        NewSelectionHelper(common),
+		vault:            NewVaultHelper(common),
 	}
 	common.SetHelpers(h)
 	return h
@@ -118,6 +156,25 @@ func (h *Helpers) DatePreview() *DatePreviewHelper           { return h.datePrev
 func (h *Helpers) Link() *LinkHelper                         { return h.link }
 func (h *Helpers) CardListFilter() *CardListFilterHelper     { return h.cardListFilter }
 func (h *Helpers) Scratchpad() *ScratchpadHelper             { return h.scratchpad }
+func (h *Helpers) Trash() *TrashHelper                       { return h.trash }
+func (h *Helpers) Session() *SessionHelper                   { return h.session }
+func (h *Helpers) Backlinks() *BacklinksHelper               { return h.backlinks }
+func (h *Helpers) ComposeEdit() *ComposeEditHelper           { return h.composeEdit }
+func (h *Helpers) ParentTree() *ParentTreeHelper             { return h.parentTree }
+func (h *Helpers) Breadcrumb() *BreadcrumbHelper             { return h.breadcrumb }
+func (h *Helpers) Git() *GitHelper                           { return h.git }
+func (h *Helpers) MergeConflict() *MergeConflictHelper       { return h.mergeConflict }
+func (h *Helpers) CustomCommands() *CustomCommandsHelper     { return h.customCommands }
+func (h *Helpers) Hooks() *HooksHelper                       { return h.hooks }
+func (h *Helpers) Templates() *TemplatesHelper               { return h.templates }
+func (h *Helpers) Todos() *TodosHelper                       { return h.todos }
+func (h *Helpers) SearchHistory() *SearchHistoryHelper       { return h.searchHistory }
+func (h *Helpers) PreviewFind() *PreviewFindHelper           { return h.previewFind }
+func (h *Helpers) Export() *ExportHelper                     { return h.export }
 func (h *Helpers) NotesHome() *NotesHomeHelper               { return h.notesHome }
 func (h *Helpers) TitleCache() *TitleCacheHelper             { return h.titleCache }
 func (h *Helpers) Navigator() *Navigator                     { return h.navigator }
+func (h *Helpers) Async() *AsyncHelper                       { return h.async }
+func (h *Helpers) Undo() *UndoHelper                         { return h.undo }
+func (h *Helpers) Selection() *SelectionHelper               { return h.selection }
+func (h *Helpers) Vault() *VaultHelper                       { return h.vault }
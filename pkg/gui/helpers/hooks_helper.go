@@ -0,0 +1,119 @@
+package helpers
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync/atomic"
+
+	"github.com/donnellyk/lazyruin/pkg/commands"
+)
+
+// Hook events, as passed to hooks in LAZYRUIN_EVENT.
+const (
+	HookCreate    = "create"
+	HookEdit      = "edit"
+	HookDelete    = "delete"
+	HookTagChange = "tag_change"
+)
+
+// HookEvent describes one note change for the lifecycle hooks. Fields that
+// don't apply to the change (e.g. the note for a vault-wide tag rename)
+// are left empty.
+type HookEvent struct {
+	Name        string // one of the Hook* constants
+	Path        string
+	UUID        string
+	Title       string
+	Changed     []string // changed fields, e.g. "body", "tags"
+	TagsAdded   []string
+	TagsRemoved []string
+}
+
+// HooksHelper runs the `hooks:` commands after notes change. Hooks run in
+// the background; a failing hook is reported in the status bar and never
+// blocks or undoes the change.
+type HooksHelper struct {
+	c   *HelperCommon
+	seq atomic.Uint64
+}
+
+func NewHooksHelper(c *HelperCommon) *HooksHelper {
+	return &HooksHelper{c: c}
+}
+
+// command returns the configured hook for event, or "".
+func (self *HooksHelper) command(event string) string {
+	cfg := self.c.Config()
+	if cfg == nil {
+		return ""
+	}
+	switch event {
+	case HookCreate:
+		return cfg.Hooks.OnCreate
+	case HookEdit:
+		return cfg.Hooks.OnEdit
+	case HookDelete:
+		return cfg.Hooks.OnDelete
+	case HookTagChange:
+		return cfg.Hooks.OnTagChange
+	}
+	return ""
+}
+
+// Fire runs the hook for ev, if one is configured.
+func (self *HooksHelper) Fire(ev HookEvent) {
+	script := self.command(ev.Name)
+	if script == "" {
+		return
+	}
+	vault := self.c.RuinCmd().VaultPath()
+	cmd := exec.Command("sh", "-c", script)
+	cmd.Dir = vault
+	cmd.Env = append(os.Environ(), hookEnv(ev, vaultPath(self.c.RuinCmd(), ev.Path), vault)...)
+
+	// Each run gets its own task key so concurrent hooks don't drop each
+	// other's results.
+	key := fmt.Sprintf("hook:%d", self.seq.Add(1))
+	self.c.Helpers().Async().Run(key, func(_ *commands.RuinCommand) func() error {
+		out, err := cmd.CombinedOutput()
+		if err == nil {
+			return nil
+		}
+		return func() error {
+			if output := strings.TrimRight(string(out), "\n"); output != "" {
+				err = fmt.Errorf("%w: %s", err, lastLine(output))
+			}
+			self.c.GuiCommon().ShowError(fmt.Errorf("%s hook: %w", ev.Name, err))
+			return nil
+		}
+	})
+}
+
+func hookEnv(ev HookEvent, path, vault string) []string {
+	return []string{
+		"LAZYRUIN_EVENT=" + ev.Name,
+		"LAZYRUIN_VAULT=" + vault,
+		"LAZYRUIN_NOTE_PATH=" + path,
+		"LAZYRUIN_NOTE_UUID=" + ev.UUID,
+		"LAZYRUIN_NOTE_TITLE=" + ev.Title,
+		"LAZYRUIN_CHANGED=" + strings.Join(ev.Changed, ","),
+		"LAZYRUIN_TAGS_ADDED=" + strings.Join(ev.TagsAdded, ","),
+		"LAZYRUIN_TAGS_REMOVED=" + strings.Join(ev.TagsRemoved, ","),
+	}
+}
+
+// frontmatterField returns a top-level scalar from a frontmatter block,
+// unquoted, or "" when absent.
+func frontmatterField(frontmatter []byte, key string) string {
+	sc := bufio.NewScanner(bytes.NewReader(frontmatter))
+	for sc.Scan() {
+		if value, ok := strings.CutPrefix(sc.Text(), key+":"); ok {
+			return strings.Trim(strings.TrimSpace(value), `"'`)
+		}
+	}
+	return ""
+}
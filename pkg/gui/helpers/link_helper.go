@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"os/exec"
 	"strings"
-	"time"
 
 	"github.com/donnellyk/lazyruin/pkg/commands"
 	"github.com/donnellyk/lazyruin/pkg/gui/context"
@@ -68,25 +67,18 @@ func (self *LinkHelper) CreateLinkFromURL(url string, quickExit bool) error {
 		return nil
 	}
 
-	cancelled := make(chan struct{})
-	done := make(chan struct{})
-
 	self.c.Helpers().InputPopup().OpenInputPopup(&types.InputPopupConfig{
 		Title:      "Resolving",
 		Locked:     true,
 		DeferClose: true,
-		OnCancel:   func() { close(cancelled) },
+		SpinnerKey: linkResolveTaskKey,
+		OnCancel:   self.cancelResolve,
 	})
 
-	opts := linkResolveOpts{
+	self.startResolve(linkResolveOpts{
 		url:       url,
 		quickExit: quickExit,
-		done:      done,
-		cancelled: cancelled,
-	}
-
-	go self.spinInputTitle(url, done)
-	go self.doResolve(opts)
+	})
 
 	return nil
 }
@@ -115,11 +107,12 @@ func (self *LinkHelper) saveImmediate(url string, tags []string, quickExit bool)
 		opts.Tags = strings.Join(tags, ",")
 	}
 
-	_, err := self.c.RuinCmd().Link.New(url, opts)
+	created, err := self.c.RuinCmd().Link.New(url, opts)
 	if err != nil {
 		self.c.GuiCommon().ShowError(err)
 		return nil
 	}
+	self.fireCreate(created)
 
 	self.c.Helpers().InputPopup().CloseInputPopup()
 	if quickExit {
@@ -146,27 +139,20 @@ func (self *LinkHelper) ReResolveLink(note *models.Note) error {
 		}
 	}
 
-	cancelled := make(chan struct{})
-	done := make(chan struct{})
-
 	self.c.Helpers().InputPopup().OpenInputPopup(&types.InputPopupConfig{
-		Title:      "Re-resolve Link",
+		Title:      "Resolving",
 		DeferClose: true,
 		Locked:     true,
-		OnCancel:   func() { close(cancelled) },
+		SpinnerKey: linkResolveTaskKey,
+		OnCancel:   self.cancelResolve,
 	})
 
-	opts := linkResolveOpts{
+	self.startResolve(linkResolveOpts{
 		url:          url,
 		tags:         tags,
 		existingUUID: note.UUID,
 		parent:       note.Parent,
-		done:         done,
-		cancelled:    cancelled,
-	}
-
-	go self.spinInputTitle(url, done)
-	go self.doResolve(opts)
+	})
 
 	return nil
 }
@@ -176,26 +162,20 @@ func (self *LinkHelper) resolveAndCapture(url string, tags []string, quickExit b
 	gui := self.c.GuiCommon()
 	ctx := gui.Contexts().InputPopup
 
-	cancelled := make(chan struct{})
-	done := make(chan struct{})
-
 	// Lock the input popup and wire up Esc to cancel
 	if ctx.Config != nil {
+		ctx.Config.Title = "Resolving"
 		ctx.Config.Locked = true
 		ctx.Config.Footer = ""
-		ctx.Config.OnCancel = func() { close(cancelled) }
+		ctx.Config.SpinnerKey = linkResolveTaskKey
+		ctx.Config.OnCancel = self.cancelResolve
 	}
 
-	opts := linkResolveOpts{
+	self.startResolve(linkResolveOpts{
 		url:       url,
 		tags:      tags,
 		quickExit: quickExit,
-		done:      done,
-		cancelled: cancelled,
-	}
-
-	go self.spinInputTitle(url, done)
-	go self.doResolve(opts)
+	})
 
 	return nil
 }
@@ -207,40 +187,42 @@ type linkResolveOpts struct {
 	existingUUID string // non-empty when re-resolving
 	parent       string // parent UUID to preserve
 	quickExit    bool   // when true, downstream actions should terminate the app
-	done         chan struct{}
-	cancelled    chan struct{}
 }
 
-func (self *LinkHelper) doResolve(opts linkResolveOpts) {
-	result, err := self.c.RuinCmd().Link.Resolve(opts.url)
-	close(opts.done)
+// linkResolveTaskKey is the AsyncHelper key for the in-flight resolve. The
+// locked input popup shows its spinner and cancels it on Esc.
+const linkResolveTaskKey = "link-resolve"
 
+// startResolve runs `ruin link resolve` in the background. The result is
+// dropped if Esc cancelled the task (which also kills the subprocess) or
+// the spinner popup has otherwise gone away.
+func (self *LinkHelper) startResolve(opts linkResolveOpts) {
 	gui := self.c.GuiCommon()
-	gui.Update(func() error {
-		// If Esc was pressed while resolving, discard the result.
-		select {
-		case <-opts.cancelled:
-			return nil
-		default:
-		}
+	self.c.Helpers().Async().Go(linkResolveTaskKey, func(cmd *commands.RuinCommand) func() error {
+		result, err := cmd.Link.Resolve(opts.url)
+		return func() error {
+			ctx := gui.Contexts().InputPopup
+			if ctx.Config == nil || !ctx.Config.Locked {
+				return nil
+			}
 
-		ctx := gui.Contexts().InputPopup
-		if ctx.Config == nil || !ctx.Config.Locked {
-			return nil
-		}
+			self.c.Helpers().InputPopup().CloseInputPopup()
 
-		self.c.Helpers().InputPopup().CloseInputPopup()
+			if err != nil {
+				gui.ShowError(fmt.Errorf("link resolve failed: %w", err))
+				return nil
+			}
 
-		if err != nil {
-			gui.ShowError(fmt.Errorf("link resolve failed: %w", err))
+			self.openCaptureWithResolved(opts, result)
 			return nil
 		}
-
-		self.openCaptureWithResolved(opts, result)
-		return nil
 	})
 }
 
+func (self *LinkHelper) cancelResolve() {
+	self.c.Helpers().Async().Cancel(linkResolveTaskKey)
+}
+
 func (self *LinkHelper) openCaptureWithResolved(opts linkResolveOpts, result *commands.LinkResolveResult) {
 	gui := self.c.GuiCommon()
 	ctx := gui.Contexts().Capture
@@ -289,31 +271,6 @@ func (self *LinkHelper) openCaptureWithResolved(opts linkResolveOpts, result *co
 	})
 }
 
-func (self *LinkHelper) spinInputTitle(_ string, done chan struct{}) {
-	frames := []string{"\u280b", "\u2819", "\u2839", "\u2838", "\u283c", "\u2834", "\u2826", "\u2827", "\u2807", "\u280f"}
-	tick := time.NewTicker(80 * time.Millisecond)
-	defer tick.Stop()
-	i := 0
-	gui := self.c.GuiCommon()
-	for {
-		select {
-		case <-tick.C:
-			frame := frames[i%len(frames)]
-			gui.Update(func() error {
-				ctx := gui.Contexts().InputPopup
-				if ctx.Config == nil || !ctx.Config.Locked {
-					return nil
-				}
-				ctx.Config.Title = fmt.Sprintf("Resolving %s", frame)
-				return nil
-			})
-			i++
-		case <-done:
-			return
-		}
-	}
-}
-
 // SubmitLinkCapture finalizes the link capture and creates the note.
 // When quickExit is true, returns gocui.ErrQuit on success to terminate the app.
 func (self *LinkHelper) SubmitLinkCapture(content string, quickExit bool) error {
@@ -347,13 +304,15 @@ func (self *LinkHelper) SubmitLinkCapture(content string, quickExit bool) error
 			gui.ShowError(fmt.Errorf("failed to delete old link note: %w", err))
 			return nil
 		}
+		self.c.Helpers().Hooks().Fire(HookEvent{Name: HookDelete, UUID: ctx.LinkExistingUUID})
 	}
 
-	_, err := self.c.RuinCmd().Link.New(url, opts)
+	created, err := self.c.RuinCmd().Link.New(url, opts)
 	if err != nil {
 		gui.ShowError(err)
 		return nil
 	}
+	self.fireCreate(created)
 
 	self.c.Helpers().Capture().CloseCapture()
 	if quickExit {
@@ -364,6 +323,14 @@ func (self *LinkHelper) SubmitLinkCapture(content string, quickExit bool) error
 	return nil
 }
 
+// fireCreate runs the on_create hook for a new link note, including the
+// replacement made by a re-resolve.
+func (self *LinkHelper) fireCreate(created *commands.LinkNewResult) {
+	if created != nil {
+		self.c.Helpers().Hooks().Fire(HookEvent{Name: HookCreate, Path: created.Path, UUID: created.UUID, Title: created.Title})
+	}
+}
+
 func (self *LinkHelper) parseLinkContent(content, url string) (title, comment string, tags []string) {
 	lines := strings.Split(content, "\n")
 	var commentLines []string
@@ -418,15 +385,7 @@ func (self *LinkHelper) BrowseLinks() error {
 			return err
 		}
 
-		source := context.CardListSource{
-			Query: "",
-			Requery: func(filterText string) ([]models.Note, error) {
-				o := self.c.Helpers().Preview().BuildSearchOptions()
-				o.Limit = 50
-				o.Link = true
-				return self.c.RuinCmd().Search.Search(strings.TrimSpace(filterText), o)
-			},
-		}
+		source := self.c.Helpers().Preview().CardListSource(context.SourceDescriptor{Kind: context.SourceLinks})
 
 		self.c.Helpers().Preview().ShowCardList("Links", notes, source)
 		return nil
@@ -0,0 +1,131 @@
+package helpers
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/donnellyk/lazyruin/pkg/gui/context"
+	"github.com/donnellyk/lazyruin/pkg/merge"
+)
+
+// MergeConflictHelper reconciles an edit with changes made to the note on
+// disk while the edit popup was open. Clean merges are saved directly;
+// conflicting regions are resolved side by side in a popup over the
+// capture, so the edit is never lost.
+type MergeConflictHelper struct {
+	c *HelperCommon
+}
+
+func NewMergeConflictHelper(c *HelperCommon) *MergeConflictHelper {
+	return &MergeConflictHelper{c: c}
+}
+
+func (self *MergeConflictHelper) ctx() *context.MergeConflictContext {
+	return self.c.GuiCommon().Contexts().MergeConflict
+}
+
+// MergeExternalEdit three-way merges the edited text with the note's
+// current body, using the body the popup was opened with as the base.
+func (self *MergeConflictHelper) MergeExternalEdit(edited string) error {
+	gui := self.c.GuiCommon()
+	capture := gui.Contexts().Capture
+	theirs, mtime, err := readNoteBodyAndMtime(capture.EditingPath)
+	if err != nil {
+		gui.ShowError(fmt.Errorf("failed to read note: %w", err))
+		return nil
+	}
+	result := merge.Merge(capture.EditingBase, edited, theirs)
+	n := result.Conflicts()
+	if n == 0 {
+		name := filepath.Base(capture.EditingPath)
+		if self.save(mtime, result.Text(nil)) {
+			gui.ShowStatus(fmt.Sprintf("Merged your edit with the changes made to %s on disk", name))
+		}
+		return nil
+	}
+
+	ctx := self.ctx()
+	ctx.Path = capture.EditingPath
+	ctx.NoteTitle = capture.EditingTitle
+	ctx.Mtime = mtime
+	ctx.Result = result
+	ctx.Sides = make([]merge.Side, n)
+	ctx.SelectedIdx = 0
+	gui.SetCursorEnabled(false)
+	gui.PushContextByKey("mergeConflict")
+	return nil
+}
+
+// save writes the merged body over the version that was merged and
+// closes the edit popup. On failure the popup stays open with the edit.
+func (self *MergeConflictHelper) save(mtime time.Time, body string) bool {
+	gui := self.c.GuiCommon()
+	path := gui.Contexts().Capture.EditingPath
+	written, err := self.c.Helpers().Capture().saveEdit(path, mtime, body)
+	if errors.Is(err, errEditConflict) {
+		gui.ShowError(fmt.Errorf("%q changed on disk again; save again to merge", filepath.Base(path)))
+		return false
+	}
+	if err != nil && !written {
+		gui.ShowError(err)
+		return false
+	}
+	self.c.Helpers().Capture().finishEdit(err)
+	return true
+}
+
+// Move selects the conflict delta places away.
+func (self *MergeConflictHelper) Move(delta int) error {
+	ctx := self.ctx()
+	idx := ctx.SelectedIdx + delta
+	if idx < 0 || idx >= len(ctx.Sides) {
+		return nil
+	}
+	ctx.SelectedIdx = idx
+	return nil
+}
+
+// Choose resolves the selected conflict and moves on to the next
+// unresolved one.
+func (self *MergeConflictHelper) Choose(side merge.Side) error {
+	ctx := self.ctx()
+	if ctx.SelectedIdx >= len(ctx.Sides) {
+		return nil
+	}
+	ctx.Sides[ctx.SelectedIdx] = side
+	for i := range ctx.Sides {
+		j := (ctx.SelectedIdx + 1 + i) % len(ctx.Sides)
+		if ctx.Sides[j] == merge.Unresolved {
+			ctx.SelectedIdx = j
+			break
+		}
+	}
+	return nil
+}
+
+// Save writes the resolved note once every conflict has a choice.
+func (self *MergeConflictHelper) Save() error {
+	gui := self.c.GuiCommon()
+	ctx := self.ctx()
+	if n := ctx.Unresolved(); n > 0 {
+		gui.ShowStatus(fmt.Sprintf("%d of %d conflicts unresolved", n, len(ctx.Sides)))
+		return nil
+	}
+	body := ctx.Result.Text(ctx.Sides)
+	gui.PopContext()
+	gui.SetCursorEnabled(true)
+	if self.save(ctx.Mtime, body) {
+		gui.ShowStatus(fmt.Sprintf("Saved %q with conflicts resolved", ctx.NoteTitle))
+	}
+	return nil
+}
+
+// Cancel returns to the edit popup with the edited text untouched.
+func (self *MergeConflictHelper) Cancel() error {
+	gui := self.c.GuiCommon()
+	gui.PopContext()
+	gui.SetCursorEnabled(true)
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/donnellyk/lazyruin/pkg/commands"
 	"github.com/donnellyk/lazyruin/pkg/gui/context"
 	"github.com/donnellyk/lazyruin/pkg/gui/types"
 )
@@ -42,6 +43,7 @@ func (n *Navigator) IsCurrentCommitted() bool { return n.currentIsCommitted }
 // the outgoing state, load runs to mutate preview context state, focus
 // shifts to the destination preview, and a new history entry is recorded.
 func (n *Navigator) NavigateTo(destination types.ContextKey, title string, load func() error) error {
+	n.cancelPendingHover()
 	n.captureOnDeparture()
 
 	if load != nil {
@@ -65,6 +67,7 @@ func (n *Navigator) NavigateTo(destination types.ContextKey, title string, load
 // when the caller is already in a preview context and wants to swap the
 // data (e.g. search submitted from card list).
 func (n *Navigator) ReplaceCurrent(destination types.ContextKey, title string, load func() error) error {
+	n.cancelPendingHover()
 	n.captureOnDeparture()
 
 	if load != nil {
@@ -89,6 +92,7 @@ func (n *Navigator) ReplaceCurrent(destination types.ContextKey, title string, l
 // state, no history entry is recorded, and the title is decorated with
 // italics to signal the view is not committed.
 func (n *Navigator) ShowHover(destination types.ContextKey, title string, load func() error) error {
+	n.cancelPendingHover()
 	n.captureOnDeparture()
 
 	if load != nil {
@@ -103,9 +107,29 @@ func (n *Navigator) ShowHover(destination types.ContextKey, title string, load f
 	return nil
 }
 
+// ShowHoverAsync is ShowHover with the ruin calls moved off the main
+// goroutine. fetch runs in the background against a cancellable
+// RuinCommand and returns the load step, which then goes through ShowHover
+// on the main goroutine. A newer hover, or any other navigation, cancels a
+// pending one, so scrolling quickly through a list only lands the last
+// selection. Fetch errors are dropped, matching how hover callers already
+// ignore ShowHover's error.
+func (n *Navigator) ShowHoverAsync(destination types.ContextKey, title string, fetch func(cmd *commands.RuinCommand) (func() error, error)) {
+	n.c.Helpers().Async().Run(PreviewTaskKey, func(cmd *commands.RuinCommand) func() error {
+		load, err := fetch(cmd)
+		if err != nil {
+			return nil
+		}
+		return func() error {
+			return n.ShowHover(destination, title, load)
+		}
+	})
+}
+
 // Back restores the previous committed view; no-op when there is no
 // previous entry.
 func (n *Navigator) Back() error {
+	n.cancelPendingHover()
 	n.captureOnDeparture()
 
 	evt, ok := n.mgr.Back()
@@ -125,6 +149,7 @@ func (n *Navigator) Back() error {
 // Forward restores the next committed view; no-op when there is no next
 // entry.
 func (n *Navigator) Forward() error {
+	n.cancelPendingHover()
 	n.captureOnDeparture()
 
 	evt, ok := n.mgr.Forward()
@@ -143,6 +168,7 @@ func (n *Navigator) Forward() error {
 // departure saves the outgoing committed state first. No-op if idx is out
 // of range.
 func (n *Navigator) JumpTo(idx int) error {
+	n.cancelPendingHover()
 	n.captureOnDeparture()
 
 	evt, ok := n.mgr.JumpTo(idx)
@@ -157,6 +183,36 @@ func (n *Navigator) JumpTo(idx int) error {
 	return nil
 }
 
+// Checkpoint saves the current committed view into its history entry, the
+// same capture-on-departure every navigation does first. Called before the
+// history is persisted so the saved entry has the latest cursor and scroll.
+func (n *Navigator) Checkpoint() {
+	n.captureOnDeparture()
+}
+
+// RestoreSession replaces the history with a saved one and shows current
+// in the preview, focusing its context. committed reports whether current
+// was the history entry at index rather than a hover.
+func (n *Navigator) RestoreSession(entries []context.NavigationEvent, index int, current context.NavigationEvent, committed bool) error {
+	n.cancelPendingHover()
+	n.mgr.Load(entries, index)
+
+	target := current.ContextKey
+	if target == "" {
+		target = "cardList"
+	}
+	n.c.GuiCommon().Contexts().ActivePreviewKey = target
+	if context.IsPreviewContextKey(target) && n.c.GuiCommon().CurrentContextKey() != target {
+		n.c.GuiCommon().PushContextByKey(target)
+	}
+	if err := n.restore(current); err != nil {
+		n.currentIsCommitted = false
+		return err
+	}
+	n.currentIsCommitted = committed
+	return nil
+}
+
 // CommitHover promotes the current hover view to a committed history entry.
 // Strips the hover-title decoration and records a new entry at the current
 // active preview context. No-op if the current view is already committed or
@@ -199,6 +255,12 @@ func (n *Navigator) captureOnDeparture() {
 	n.mgr.UpdateCurrent(snap)
 }
 
+// cancelPendingHover drops any in-flight ShowHoverAsync load so it cannot
+// land on top of the navigation that is replacing it.
+func (n *Navigator) cancelPendingHover() {
+	n.c.Helpers().Async().Cancel(PreviewTaskKey)
+}
+
 func (n *Navigator) recordCurrent(destination types.ContextKey, title string) {
 	snap, _ := n.currentSnapshot()
 	n.mgr.Record(context.NavigationEvent{
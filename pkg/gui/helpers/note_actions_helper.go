@@ -1,9 +1,11 @@
 package helpers
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/donnellyk/lazyruin/pkg/gui/types"
+	"github.com/donnellyk/lazyruin/pkg/models"
 	"github.com/donnellyk/ruin-note-cli/pkg/notetext"
 )
 
@@ -30,6 +32,18 @@ func resolveTypedTag(raw string, item *types.CompletionItem) string {
 	return tags[0]
 }
 
+// containsFold reports whether tags holds tag, ignoring case and the
+// leading "#" (note tags come back from the CLI with or without it).
+func containsFold(tags []string, tag string) bool {
+	tag = strings.TrimPrefix(tag, "#")
+	for _, t := range tags {
+		if strings.EqualFold(strings.TrimPrefix(t, "#"), tag) {
+			return true
+		}
+	}
+	return false
+}
+
 // NoteActionsHelper handles note-level mutations (tags, parents, bookmarks).
 type NoteActionsHelper struct {
 	c *HelperCommon
@@ -40,16 +54,39 @@ func NewNoteActionsHelper(c *HelperCommon) *NoteActionsHelper {
 	return &NoteActionsHelper{c: c}
 }
 
-// AddGlobalTag opens the input popup to add a global tag to the current preview card.
-func (self *NoteActionsHelper) AddGlobalTag() error {
-	gui := self.c.GuiCommon()
+// targets returns the notes a note action applies to: the multi-selection
+// in the focused Notes list or card list when there is one, otherwise the
+// current preview card.
+func (self *NoteActionsHelper) targets() []models.Note {
+	if notes := self.c.Helpers().Selection().SelectedNotes(); len(notes) > 0 {
+		return notes
+	}
 	card := self.c.Helpers().Preview().CurrentPreviewCard()
 	if card == nil {
 		return nil
 	}
-	uuid := card.UUID
+	return []models.Note{*card}
+}
+
+// bulkTitle suffixes a popup title or undo label with the note count
+// for bulk actions.
+func bulkTitle(title string, notes []models.Note) string {
+	if len(notes) > 1 {
+		return fmt.Sprintf("%s (%d notes)", title, len(notes))
+	}
+	return title
+}
+
+// AddGlobalTag opens the input popup to add a global tag to the current
+// preview card, or to every selected note.
+func (self *NoteActionsHelper) AddGlobalTag() error {
+	gui := self.c.GuiCommon()
+	notes := self.targets()
+	if len(notes) == 0 {
+		return nil
+	}
 	self.c.Helpers().InputPopup().OpenInputPopup(&types.InputPopupConfig{
-		Title:  "Add Tag",
+		Title:  bulkTitle("Add Tag", notes),
 		Footer: " # for tags | Tab: accept | Esc: cancel ",
 		Seed:   "#",
 		Triggers: func() []types.CompletionTrigger {
@@ -60,10 +97,24 @@ func (self *NoteActionsHelper) AddGlobalTag() error {
 			if tag == "" {
 				return nil
 			}
-			err := self.c.RuinCmd().Note.AddTag(uuid, tag)
-			if err != nil {
+			errs := bulkErrors{total: len(notes)}
+			self.c.Helpers().Undo().Batch(bulkTitle("Add "+tag, notes), func() {
+				for _, note := range notes {
+					if err := self.c.RuinCmd().Note.AddTag(note.UUID, tag); err != nil {
+						errs.add(note, err)
+						continue
+					}
+					self.fireTagChange(note, []string{tag}, nil)
+					if !containsFold(note.Tags, tag) {
+						self.c.Helpers().Undo().RecordAddTag(note.UUID, tag)
+					}
+				}
+			})
+			if err := errs.err(); err != nil {
 				gui.ShowError(err)
-				return nil
+				if errs.allFailed() {
+					return nil
+				}
 			}
 			self.c.Helpers().Preview().ReloadContent()
 			self.c.Helpers().Tags().RefreshTags(false)
@@ -73,24 +124,24 @@ func (self *NoteActionsHelper) AddGlobalTag() error {
 	return nil
 }
 
-// RemoveTag opens the input popup showing only the current card's tags for removal.
+// RemoveTag opens the input popup showing only the tags on the current
+// card (or on any selected note) for removal.
 func (self *NoteActionsHelper) RemoveTag() error {
 	gui := self.c.GuiCommon()
-	card := self.c.Helpers().Preview().CurrentPreviewCard()
-	if card == nil {
+	notes := self.targets()
+	if len(notes) == 0 {
 		return nil
 	}
-	allTags := append(card.Tags, card.InlineTags...)
-	if len(allTags) == 0 {
+	candidates := self.c.Helpers().Completion().NoteTagCandidates(notes)
+	if len(candidates("")) == 0 {
 		return nil
 	}
-	uuid := card.UUID
 	self.c.Helpers().InputPopup().OpenInputPopup(&types.InputPopupConfig{
-		Title:  "Remove Tag",
+		Title:  bulkTitle("Remove Tag", notes),
 		Footer: " # for tags | Tab: accept | Esc: cancel ",
 		Seed:   "#",
 		Triggers: func() []types.CompletionTrigger {
-			return []types.CompletionTrigger{{Prefix: "#", Candidates: self.c.Helpers().Completion().CurrentCardTagCandidates}}
+			return []types.CompletionTrigger{{Prefix: "#", Candidates: candidates}}
 		},
 		OnAccept: func(_ string, item *types.CompletionItem) error {
 			tag := ""
@@ -100,10 +151,19 @@ func (self *NoteActionsHelper) RemoveTag() error {
 			if tag == "" {
 				return nil
 			}
-			err := self.c.RuinCmd().Note.RemoveTag(uuid, tag)
-			if err != nil {
+			errs := bulkErrors{total: len(notes)}
+			self.c.Helpers().Undo().Batch(bulkTitle("Remove "+tag, notes), func() {
+				for _, note := range notes {
+					if err := self.removeTagFrom(note, tag); err != nil {
+						errs.add(note, err)
+					}
+				}
+			})
+			if err := errs.err(); err != nil {
 				gui.ShowError(err)
-				return nil
+				if errs.allFailed() {
+					return nil
+				}
 			}
 			self.c.Helpers().Preview().ReloadContent()
 			self.c.Helpers().Tags().RefreshTags(false)
@@ -113,16 +173,52 @@ func (self *NoteActionsHelper) RemoveTag() error {
 	return nil
 }
 
+// removeTagFrom removes tag from one note and journals it. A global tag
+// comes back with AddTag; an inline one may sit on several lines, so the
+// file is snapshotted instead. Notes carrying neither are skipped.
+func (self *NoteActionsHelper) removeTagFrom(note models.Note, tag string) error {
+	if containsFold(note.Tags, tag) {
+		if err := self.c.RuinCmd().Note.RemoveTag(note.UUID, tag); err != nil {
+			return err
+		}
+		self.c.Helpers().Undo().RecordRemoveTag(note.UUID, tag)
+		self.fireTagChange(note, nil, []string{tag})
+		return nil
+	}
+	if !containsFold(note.InlineTags, tag) {
+		return nil
+	}
+	err := self.c.Helpers().Undo().RecordSnapshot("Remove "+tag, []string{note.Path}, func() error {
+		return self.c.RuinCmd().Note.RemoveTag(note.UUID, tag)
+	})
+	if err == nil {
+		self.fireTagChange(note, nil, []string{tag})
+	}
+	return err
+}
+
+// fireTagChange runs the on_tag_change hook for note.
+func (self *NoteActionsHelper) fireTagChange(note models.Note, added, removed []string) {
+	self.c.Helpers().Hooks().Fire(HookEvent{
+		Name:        HookTagChange,
+		Path:        note.Path,
+		UUID:        note.UUID,
+		Title:       note.Title,
+		Changed:     []string{"tags"},
+		TagsAdded:   added,
+		TagsRemoved: removed,
+	})
+}
+
 // SetParentDialog opens the input popup with > parent completion.
 func (self *NoteActionsHelper) SetParentDialog() error {
 	gui := self.c.GuiCommon()
-	card := self.c.Helpers().Preview().CurrentPreviewCard()
-	if card == nil {
+	notes := self.targets()
+	if len(notes) == 0 {
 		return nil
 	}
-	uuid := card.UUID
 	self.c.Helpers().InputPopup().OpenInputPopup(&types.InputPopupConfig{
-		Title:  "Set Parent",
+		Title:  bulkTitle("Set Parent", notes),
 		Footer: " > parent | / drill | Tab: accept | Esc: cancel ",
 		Seed:   ">",
 		Triggers: func() []types.CompletionTrigger {
@@ -141,10 +237,21 @@ func (self *NoteActionsHelper) SetParentDialog() error {
 			if parentRef == "" {
 				return nil
 			}
-			err := self.c.RuinCmd().Note.SetParent(uuid, parentRef)
-			if err != nil {
+			errs := bulkErrors{total: len(notes)}
+			self.c.Helpers().Undo().Batch(bulkTitle("Set parent", notes), func() {
+				for _, note := range notes {
+					if err := self.c.RuinCmd().Note.SetParent(note.UUID, parentRef); err != nil {
+						errs.add(note, err)
+						continue
+					}
+					self.c.Helpers().Undo().RecordSetParent(note.UUID, note.Parent, parentRef)
+				}
+			})
+			if err := errs.err(); err != nil {
 				gui.ShowError(err)
-				return nil
+				if errs.allFailed() {
+					return nil
+				}
 			}
 			self.c.Helpers().Preview().ReloadContent()
 			return nil
@@ -153,29 +260,49 @@ func (self *NoteActionsHelper) SetParentDialog() error {
 	return nil
 }
 
-// RemoveParent removes the parent from the current card.
+// RemoveParent removes the parent from the current card, or from every
+// selected note.
 func (self *NoteActionsHelper) RemoveParent() error {
 	gui := self.c.GuiCommon()
-	card := self.c.Helpers().Preview().CurrentPreviewCard()
-	if card == nil {
+	notes := self.targets()
+	if len(notes) == 0 {
 		return nil
 	}
-	err := self.c.RuinCmd().Note.RemoveParent(card.UUID)
-	if err != nil {
+	errs := bulkErrors{total: len(notes)}
+	self.c.Helpers().Undo().Batch(bulkTitle("Remove parent", notes), func() {
+		for _, note := range notes {
+			if err := self.c.RuinCmd().Note.RemoveParent(note.UUID); err != nil {
+				errs.add(note, err)
+				continue
+			}
+			if note.Parent != "" {
+				self.c.Helpers().Undo().RecordSetParent(note.UUID, note.Parent, "")
+			}
+		}
+	})
+	if err := errs.err(); err != nil {
 		gui.ShowError(err)
-		return nil
+		if errs.allFailed() {
+			return nil
+		}
 	}
 	self.c.Helpers().Preview().ReloadContent()
 	return nil
 }
 
-// ToggleBookmark toggles a parent bookmark for the current card.
+// ToggleBookmark toggles a parent bookmark for the current card. With
+// several notes selected, it bookmarks each one under its title, or
+// removes their bookmarks when every selected note already has one.
 func (self *NoteActionsHelper) ToggleBookmark() error {
-	gui := self.c.GuiCommon()
-	card := self.c.Helpers().Preview().CurrentPreviewCard()
-	if card == nil {
+	notes := self.targets()
+	if len(notes) == 0 {
 		return nil
 	}
+	if len(notes) > 1 {
+		return self.toggleBookmarks(notes)
+	}
+	gui := self.c.GuiCommon()
+	card := &notes[0]
 	// Check if a bookmark already exists for this note
 	bookmarks, err := self.c.RuinCmd().Parent.List()
 	if err == nil {
@@ -189,15 +316,11 @@ func (self *NoteActionsHelper) ToggleBookmark() error {
 		}
 	}
 	// No bookmark exists -- prompt for a name
-	defaultName := card.Title
-	if defaultName == "" {
-		defaultName = card.UUID[:8]
-	}
 	cardUUID := card.UUID
 	self.c.Helpers().InputPopup().OpenInputPopup(&types.InputPopupConfig{
 		Title:  "Save Bookmark",
 		Footer: " Enter: save | Esc: cancel ",
-		Seed:   defaultName,
+		Seed:   bookmarkName(*card),
 		OnAccept: func(raw string, _ *types.CompletionItem) error {
 			if raw == "" {
 				return nil
@@ -213,3 +336,52 @@ func (self *NoteActionsHelper) ToggleBookmark() error {
 	})
 	return nil
 }
+
+func (self *NoteActionsHelper) toggleBookmarks(notes []models.Note) error {
+	gui := self.c.GuiCommon()
+	bookmarks, err := self.c.RuinCmd().Parent.List()
+	if err != nil {
+		gui.ShowError(err)
+		return nil
+	}
+	existing := make(map[string]string, len(bookmarks)) // note UUID -> bookmark name
+	for _, bm := range bookmarks {
+		existing[bm.UUID] = bm.Name
+	}
+	allBookmarked := true
+	for _, note := range notes {
+		if _, ok := existing[note.UUID]; !ok {
+			allBookmarked = false
+			break
+		}
+	}
+
+	errs := bulkErrors{total: len(notes)}
+	for _, note := range notes {
+		name, has := existing[note.UUID]
+		switch {
+		case allBookmarked:
+			err = self.c.RuinCmd().Parent.Delete(name)
+		case !has:
+			err = self.c.RuinCmd().Parent.Save(bookmarkName(note), note.UUID)
+		default:
+			continue
+		}
+		if err != nil {
+			errs.add(note, err)
+		}
+	}
+	if err := errs.err(); err != nil {
+		gui.ShowError(err)
+	}
+	self.c.Helpers().Queries().RefreshParents(false)
+	return nil
+}
+
+// bookmarkName is the default bookmark name for a note.
+func bookmarkName(note models.Note) string {
+	if note.Title != "" {
+		return note.Title
+	}
+	return note.UUID[:8]
+}
@@ -1,6 +1,7 @@
 package helpers
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/donnellyk/lazyruin/pkg/gui/context"
@@ -99,6 +100,7 @@ func (self *NotesHelper) CycleNotesTab() {
 	CycleTab(context.NotesTabs, notesCtx.TabIndex(), func(tab context.NotesTab) {
 		notesCtx.CurrentTab = tab
 		notesCtx.SetSelectedLineIdx(0)
+		notesCtx.Selection.Clear()
 	}, self.LoadNotesForCurrentTab)
 }
 
@@ -123,6 +125,7 @@ func (self *NotesHelper) SwitchNotesTabByIndex(tabIndex int) error {
 	SwitchTab(context.NotesTabs, tabIndex, func(tab context.NotesTab) {
 		notesCtx.CurrentTab = tab
 		notesCtx.SetSelectedLineIdx(0)
+		notesCtx.Selection.Clear()
 	}, func() {
 		self.LoadNotesForCurrentTab()
 		gui.PushContextByKey("notes")
@@ -140,8 +143,11 @@ func (self *NotesHelper) DeleteNote(note *models.Note) error {
 		displayName = note.Path
 	}
 	uuid := note.UUID
+	path := note.Path
 	self.c.Helpers().Confirmation().ConfirmDelete("Note", displayName,
-		func() error { return self.c.RuinCmd().Note.Delete(uuid) },
+		func() error {
+			return self.c.Helpers().Trash().TrashNote(uuid, path, displayName)
+		},
 		func() {
 			self.c.Helpers().Navigator().NoteDeleted(uuid)
 			self.c.Helpers().Notes().FetchNotesForCurrentTab(false)
@@ -149,3 +155,35 @@ func (self *NotesHelper) DeleteNote(note *models.Note) error {
 	)
 	return nil
 }
+
+// DeleteNotes asks once, then moves every note to the trash as a single
+// undo step and reports any failures together. onDeleted receives the
+// UUIDs that were deleted.
+func (self *NotesHelper) DeleteNotes(notes []models.Note, onDeleted func(deleted map[string]bool)) error {
+	gui := self.c.GuiCommon()
+	gui.ShowConfirm("Delete Notes", fmt.Sprintf("Move %d notes to the trash?", len(notes)), func() error {
+		errs := bulkErrors{total: len(notes)}
+		deleted := make(map[string]bool, len(notes))
+		self.c.Helpers().Undo().Batch(bulkTitle("Delete", notes), func() {
+			for _, note := range notes {
+				name := note.Title
+				if name == "" {
+					name = note.Path
+				}
+				if err := self.c.Helpers().Trash().TrashNote(note.UUID, note.Path, name); err != nil {
+					errs.add(note, err)
+					continue
+				}
+				deleted[note.UUID] = true
+				self.c.Helpers().Navigator().NoteDeleted(note.UUID)
+			}
+		})
+		if err := errs.err(); err != nil {
+			gui.ShowError(err)
+		}
+		onDeleted(deleted)
+		self.FetchNotesForCurrentTab(false)
+		return nil
+	})
+	return nil
+}
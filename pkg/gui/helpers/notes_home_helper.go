@@ -202,7 +202,7 @@ func (self *NotesHomeHelper) Activate(row context.NotesHomeRow) error {
 	}
 
 	return self.c.Helpers().Navigator().NavigateTo("cardList", title, func() error {
-		notes, err := loadFn()
+		notes, err := loadFn(self.c.RuinCmd())
 		if err != nil {
 			return err
 		}
@@ -222,17 +222,17 @@ func (self *NotesHomeHelper) Hover(row context.NotesHomeRow) {
 
 	switch row.Action.Kind {
 	case context.NotesHomeActionToday:
-		_ = self.c.Helpers().DatePreview().HoverDatePreview(time.Now().Format("2006-01-02"))
+		self.c.Helpers().DatePreview().HoverDatePreview(time.Now().Format("2006-01-02"))
 		return
 	case context.NotesHomeActionNext7:
 		start, end := next7DaysRange()
-		_ = self.c.Helpers().DatePreview().HoverDateRangePreview("Next 7 Days", start, end)
+		self.c.Helpers().DatePreview().HoverDateRangePreview("Next 7 Days", start, end)
 		return
 	case context.NotesHomeActionParent:
 		if row.Action.Parent == nil {
 			return
 		}
-		_ = self.hoverParent(*row.Action.Parent)
+		self.hoverParent(*row.Action.Parent)
 		return
 	}
 
@@ -256,34 +256,29 @@ func (self *NotesHomeHelper) commitParent(parent models.ParentBookmark) error {
 			return err
 		}
 		self.c.Helpers().Preview().ShowCompose(title, composed, sourceMap, parent)
-		gui.Contexts().Compose.Requery = self.parentRequery(parent)
+		gui.Contexts().Compose.Requery = self.c.Helpers().Preview().ComposeRequery(parent)
 		return nil
 	})
 }
 
-// hoverParent is the hover counterpart to commitParent.
-func (self *NotesHomeHelper) hoverParent(parent models.ParentBookmark) error {
+// hoverParent is the hover counterpart to commitParent. The compose runs
+// in the background so j/k over parent rows never blocks on ruin.
+func (self *NotesHomeHelper) hoverParent(parent models.ParentBookmark) {
 	gui := self.c.GuiCommon()
 	title := "Parent: " + parent.Name
-	return self.c.Helpers().Navigator().ShowHover("compose", title, func() error {
-		composed, sourceMap, err := self.c.RuinCmd().Parent.Compose(parent)
+	self.c.Helpers().Navigator().ShowHoverAsync("compose", title, func(cmd *commands.RuinCommand) (func() error, error) {
+		composed, sourceMap, err := cmd.Parent.Compose(parent)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		self.c.Helpers().Preview().ShowCompose(title, composed, sourceMap, parent)
-		gui.Contexts().Compose.Requery = self.parentRequery(parent)
-		return nil
+		return func() error {
+			self.c.Helpers().Preview().ShowCompose(title, composed, sourceMap, parent)
+			gui.Contexts().Compose.Requery = self.c.Helpers().Preview().ComposeRequery(parent)
+			return nil
+		}, nil
 	})
 }
 
-// parentRequery returns the closure ComposeContext stores so a history
-// restore can re-run compose against the same bookmark.
-func (self *NotesHomeHelper) parentRequery(parent models.ParentBookmark) context.ComposeRequery {
-	return func() (models.Note, []models.SourceMapEntry, error) {
-		return self.c.RuinCmd().Parent.Compose(parent)
-	}
-}
-
 // next7DaysRange returns ISO start/end dates for the [today, today+6]
 // window. Inclusive on both ends — same shape as ruin's `between:`.
 func next7DaysRange() (string, string) {
@@ -296,9 +291,10 @@ func next7DaysRange() (string, string) {
 // dispatch returns the title and a flat-card-list loader for actions
 // that don't have a dedicated preview path. Today, Next 7 Days, and
 // Parent rows are handled directly in Activate/Hover (Date view and
-// Compose view respectively), so they're not represented here.
-func (self *NotesHomeHelper) dispatch(row context.NotesHomeRow) (string, func() ([]models.Note, error)) {
-	cmd := self.c.RuinCmd()
+// Compose view respectively), so they're not represented here. The loader
+// takes the RuinCommand to run against so Hover can hand it a cancellable
+// one.
+func (self *NotesHomeHelper) dispatch(row context.NotesHomeRow) (string, func(cmd *commands.RuinCommand) ([]models.Note, error)) {
 	opts := self.c.Helpers().Preview().BuildSearchOptions()
 	opts.IncludeContent = true
 	opts.StripTitle = true
@@ -306,18 +302,18 @@ func (self *NotesHomeHelper) dispatch(row context.NotesHomeRow) (string, func()
 
 	switch row.Action.Kind {
 	case context.NotesHomeActionInbox:
-		return "Inbox", func() ([]models.Note, error) {
+		return "Inbox", func(cmd *commands.RuinCommand) ([]models.Note, error) {
 			o := opts
 			o.Sort = "created:desc"
 			o.Limit = inboxLimit
 			return cmd.Search.Search("tags:none", o)
 		}
 	case context.NotesHomeActionQuery:
-		return row.Title, func() ([]models.Note, error) {
+		return row.Title, func(cmd *commands.RuinCommand) ([]models.Note, error) {
 			return cmd.Queries.Run(row.Action.Detail, opts)
 		}
 	case context.NotesHomeActionEmbed:
-		return row.Title, func() ([]models.Note, error) {
+		return row.Title, func(cmd *commands.RuinCommand) ([]models.Note, error) {
 			res, err := cmd.Embed.Eval(row.Action.Detail)
 			if err != nil {
 				return nil, err
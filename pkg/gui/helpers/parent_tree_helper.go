@@ -0,0 +1,249 @@
+package helpers
+
+import (
+	"fmt"
+
+	"github.com/donnellyk/lazyruin/pkg/commands"
+	"github.com/donnellyk/lazyruin/pkg/gui/context"
+)
+
+// ParentTreeHelper drives the parent/child tree navigator popup.
+type ParentTreeHelper struct {
+	c *HelperCommon
+}
+
+func NewParentTreeHelper(c *HelperCommon) *ParentTreeHelper {
+	return &ParentTreeHelper{c: c}
+}
+
+func (self *ParentTreeHelper) ctx() *context.ParentTreeContext {
+	return self.c.GuiCommon().Contexts().ParentTree
+}
+
+// OpenBookmarks opens the tree rooted at the bookmarked parents.
+func (self *ParentTreeHelper) OpenBookmarks() error {
+	var refs []string
+	for _, p := range self.c.GuiCommon().Contexts().Queries.Parents {
+		if p.UUID != "" {
+			refs = append(refs, p.UUID)
+		} else if p.File != "" {
+			refs = append(refs, p.File)
+		}
+	}
+	if len(refs) == 0 {
+		self.c.GuiCommon().ShowStatus("No bookmarked parents")
+		return nil
+	}
+	return self.open(refs)
+}
+
+// OpenForCurrentNote opens the tree rooted at the note under the preview
+// cursor.
+func (self *ParentTreeHelper) OpenForCurrentNote() error {
+	card := self.c.Helpers().Preview().CurrentPreviewCard()
+	if card == nil {
+		return nil
+	}
+	return self.open([]string{card.UUID})
+}
+
+func (self *ParentTreeHelper) open(refs []string) error {
+	gui := self.c.GuiCommon()
+	if gui.PopupActive() {
+		return nil
+	}
+	ctx := self.ctx()
+	ctx.RootRefs = refs
+	ctx.Expanded = make(map[string]bool)
+	ctx.SelectedIdx = 0
+	ctx.CutUUID = ""
+	if err := self.load(); err != nil {
+		gui.ShowError(err)
+		return nil
+	}
+	// A single root starts expanded; a forest of bookmarks starts folded.
+	if len(ctx.Roots) == 1 {
+		ctx.Expanded[ctx.Roots[0].UUID] = true
+	}
+	gui.PushContextByKey("parentTree")
+	self.hoverSelected()
+	return nil
+}
+
+// load fetches the tree for each root ref. Roots that fail to load are
+// skipped unless none load.
+func (self *ParentTreeHelper) load() error {
+	ctx := self.ctx()
+	var roots []*context.ParentTreeNode
+	var firstErr error
+	for _, ref := range ctx.RootRefs {
+		tree, err := self.c.RuinCmd().Parent.Tree(ref)
+		if err != nil || tree == nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		roots = append(roots, toTreeNode(*tree))
+	}
+	if len(roots) == 0 && firstErr != nil {
+		return firstErr
+	}
+	ctx.Roots = roots
+	ctx.ClampSelection()
+	return nil
+}
+
+func toTreeNode(t commands.TreeNode) *context.ParentTreeNode {
+	n := &context.ParentTreeNode{UUID: t.UUID, Title: t.Title}
+	for _, c := range t.Children {
+		n.Children = append(n.Children, toTreeNode(c))
+	}
+	return n
+}
+
+// Move moves the cursor by delta rows and previews the new selection.
+func (self *ParentTreeHelper) Move(delta int) error {
+	ctx := self.ctx()
+	idx := ctx.SelectedIdx + delta
+	if idx < 0 || idx >= len(ctx.Rows()) {
+		return nil
+	}
+	ctx.SelectedIdx = idx
+	self.hoverSelected()
+	return nil
+}
+
+// Expand opens the selected node, or steps into its first child when it's
+// already open.
+func (self *ParentTreeHelper) Expand() error {
+	ctx := self.ctx()
+	row := ctx.SelectedRow()
+	if row == nil || len(row.Node.Children) == 0 {
+		return nil
+	}
+	if !ctx.Expanded[row.Node.UUID] {
+		ctx.Expanded[row.Node.UUID] = true
+		return nil
+	}
+	return self.Move(1)
+}
+
+// Collapse closes the selected node, or steps out to its parent when it's
+// already closed or has no children.
+func (self *ParentTreeHelper) Collapse() error {
+	ctx := self.ctx()
+	row := ctx.SelectedRow()
+	if row == nil {
+		return nil
+	}
+	if ctx.Expanded[row.Node.UUID] && len(row.Node.Children) > 0 {
+		delete(ctx.Expanded, row.Node.UUID)
+		return nil
+	}
+	if row.Parent == nil {
+		return nil
+	}
+	rows := ctx.Rows()
+	for i := ctx.SelectedIdx - 1; i >= 0; i-- {
+		if rows[i].Node == row.Parent {
+			ctx.SelectedIdx = i
+			self.hoverSelected()
+			break
+		}
+	}
+	return nil
+}
+
+// hoverSelected previews the selected note without recording history.
+func (self *ParentTreeHelper) hoverSelected() {
+	if row := self.ctx().SelectedRow(); row != nil {
+		self.c.Helpers().Preview().HoverNote(row.Node.UUID, row.Node.Title)
+	}
+}
+
+// OpenSelected closes the popup and opens the selected note in the preview.
+func (self *ParentTreeHelper) OpenSelected() error {
+	row := self.ctx().SelectedRow()
+	if row == nil {
+		return nil
+	}
+	uuid := row.Node.UUID
+	self.Close()
+	return self.c.Helpers().PreviewNav().OpenNoteByUUID(uuid)
+}
+
+// Cut marks the selected node to be moved by the next Paste.
+func (self *ParentTreeHelper) Cut() error {
+	ctx := self.ctx()
+	row := ctx.SelectedRow()
+	if row == nil {
+		return nil
+	}
+	ctx.CutUUID = row.Node.UUID
+	self.c.GuiCommon().ShowStatus(fmt.Sprintf("Cut %q — select a new parent and press p", row.Node.Title))
+	return nil
+}
+
+// Paste makes the selected node the parent of the cut node. Pasting a
+// node into itself or one of its descendants is refused.
+func (self *ParentTreeHelper) Paste() error {
+	gui := self.c.GuiCommon()
+	ctx := self.ctx()
+	row := ctx.SelectedRow()
+	if row == nil || ctx.CutUUID == "" {
+		return nil
+	}
+	cut := ctx.Find(ctx.CutUUID)
+	if cut == nil {
+		ctx.CutUUID = ""
+		return nil
+	}
+	target := row.Node
+	if cut.Contains(target.UUID) {
+		gui.ShowError(fmt.Errorf("can't move %q under itself", cut.Title))
+		return nil
+	}
+
+	prev := ""
+	if note, err := self.c.RuinCmd().Search.Get(cut.UUID, commands.SearchOptions{}); err == nil && note != nil {
+		prev = note.Parent
+	}
+	if prev == target.UUID {
+		ctx.CutUUID = ""
+		return nil
+	}
+	if err := self.c.RuinCmd().Note.SetParent(cut.UUID, target.UUID); err != nil {
+		gui.ShowError(err)
+		return nil
+	}
+	self.c.Helpers().Undo().RecordSetParent(cut.UUID, prev, target.UUID)
+	ctx.CutUUID = ""
+
+	if err := self.load(); err != nil {
+		gui.ShowError(err)
+	}
+	ctx.Expanded[target.UUID] = true
+	ctx.Select(cut.UUID)
+	self.c.Helpers().Queries().RefreshParents(false)
+	self.c.Helpers().Notes().FetchNotesForCurrentTab(true)
+	self.c.GuiCommon().ShowStatus(fmt.Sprintf("Moved %q under %q", cut.Title, target.Title))
+	return nil
+}
+
+// Escape cancels a pending cut, or closes the popup.
+func (self *ParentTreeHelper) Escape() error {
+	ctx := self.ctx()
+	if ctx.CutUUID != "" {
+		ctx.CutUUID = ""
+		return nil
+	}
+	self.Close()
+	return nil
+}
+
+// Close closes the popup, leaving the hovered note in the preview.
+func (self *ParentTreeHelper) Close() {
+	self.ctx().CutUUID = ""
+	self.c.GuiCommon().PopContext()
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/donnellyk/lazyruin/pkg/gui/context"
 	"github.com/donnellyk/lazyruin/pkg/gui/types"
 	"github.com/donnellyk/lazyruin/pkg/models"
+	"github.com/donnellyk/lazyruin/pkg/searchhistory"
 )
 
 // PickHelper encapsulates the pick popup logic.
@@ -31,6 +32,7 @@ func (self *PickHelper) OpenPick() error {
 	ctx.AllTagsMode = false
 	ctx.SeedHash = true
 	ctx.DialogMode = false
+	self.c.Helpers().SearchHistory().ResetRecall(searchhistory.KindPick)
 	gui.PushContextByKey("pick")
 	return nil
 }
@@ -61,6 +63,7 @@ func (self *PickHelper) OpenPickDialog() error {
 	ctx.SeedHash = true
 	ctx.DialogMode = true
 	ctx.ScopeTitle = scopeTitle
+	self.c.Helpers().SearchHistory().ResetRecall(searchhistory.KindPick)
 	gui.PushContextByKey("pick")
 	return nil
 }
@@ -108,6 +111,7 @@ func (self *PickHelper) ExecutePick(raw string) error {
 	if raw == "" {
 		return self.CancelPick()
 	}
+	self.c.Helpers().SearchHistory().Record(searchhistory.KindPick, withToggleFlags(raw, ctx))
 
 	if ctx.DialogMode {
 		ctx.DialogMode = false
@@ -132,24 +136,44 @@ func (self *PickHelper) ExecutePick(raw string) error {
 			results = nil
 		}
 
-		capturedRaw := raw
-		source := context.PickResultsSource{
-			Query: raw,
-			Requery: func(filterText string) ([]models.PickResult, error) {
-				combined := strings.TrimSpace(capturedRaw + " " + filterText)
-				t, d, f, fl := ParsePickQuery(combined)
-				return self.c.RuinCmd().Pick.Pick(t, commands.PickOpts{
-					Any: anyMode || fl.Any, Todo: todoMode || fl.Todo, All: allMode || fl.All,
-					Date: d, Filter: f,
-				})
-			},
-		}
+		source := self.c.Helpers().Preview().PickResultsSource(context.SourceDescriptor{
+			Kind: context.SourcePick, Query: raw,
+			Any: anyMode, Todo: todoMode, All: allMode,
+		})
 
 		self.c.Helpers().Preview().ShowPickResults("Pick: "+raw, results, source)
 		return nil
 	})
 }
 
+// RunPick runs raw as a fresh pick from outside the popup, such as from
+// the search history: flags come from raw alone.
+func (self *PickHelper) RunPick(raw string) error {
+	ctx := self.c.GuiCommon().Contexts().Pick
+	ctx.AnyMode = false
+	ctx.TodoMode = false
+	ctx.AllTagsMode = false
+	ctx.DialogMode = false
+	ctx.ScopeTitle = ""
+	return self.ExecutePick(raw)
+}
+
+// withToggleFlags appends the popup's toggled flags to raw, so the query
+// recorded in the history re-runs the same pick.
+func withToggleFlags(raw string, ctx *context.PickContext) string {
+	_, _, _, flags := ParsePickQuery(raw)
+	if ctx.AnyMode && !flags.Any {
+		raw += " --any"
+	}
+	if ctx.TodoMode && !flags.Todo {
+		raw += " --todo"
+	}
+	if ctx.DialogMode && ctx.AllTagsMode && !flags.AllTags {
+		raw += " --all-tags"
+	}
+	return raw
+}
+
 // scopedPickOpts builds PickOpts with context-appropriate scoping:
 // compose mode scopes to the parent's children, cardList mode scopes to
 // the selected note, and all other modes are unscoped.
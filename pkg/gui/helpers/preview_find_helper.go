@@ -0,0 +1,144 @@
+package helpers
+
+import (
+	"regexp"
+
+	"github.com/donnellyk/lazyruin/pkg/gui/context"
+)
+
+// PreviewFindHelper drives the / find bar. As the typed text changes it
+// finds it in the active preview's rendered lines and moves the cursor to
+// the first match at or below where the bar was opened; n/N then step
+// through the matches like search-term matches.
+type PreviewFindHelper struct {
+	c *HelperCommon
+}
+
+func NewPreviewFindHelper(c *HelperCommon) *PreviewFindHelper {
+	return &PreviewFindHelper{c: c}
+}
+
+func (self *PreviewFindHelper) ctx() *context.PreviewFindContext {
+	return self.c.GuiCommon().Contexts().PreviewFind
+}
+
+func (self *PreviewFindHelper) navState() *context.PreviewNavState {
+	return self.c.GuiCommon().Contexts().ActivePreview().NavState()
+}
+
+// Open shows the find bar over the preview. The regex and case toggles
+// carry over from the preview's last find.
+func (self *PreviewFindHelper) Open() error {
+	gui := self.c.GuiCommon()
+	if gui.PopupActive() {
+		return nil
+	}
+	ctx := self.ctx()
+	ctx.Origin = self.navState().CursorLine
+	ctx.Invalid = false
+	gui.PushContextByKey("previewFind")
+	return nil
+}
+
+// Update finds pattern in the preview. Called whenever the bar's text
+// changes.
+func (self *PreviewFindHelper) Update(pattern string) {
+	self.navState().Find.Pattern = pattern
+	self.apply()
+}
+
+// ToggleRegex switches between matching the pattern as literal text and
+// as a regular expression.
+func (self *PreviewFindHelper) ToggleRegex() error {
+	f := &self.navState().Find
+	f.Regex = !f.Regex
+	self.apply()
+	return nil
+}
+
+// ToggleCaseSensitive switches between ignoring and matching case.
+func (self *PreviewFindHelper) ToggleCaseSensitive() error {
+	f := &self.navState().Find
+	f.CaseSensitive = !f.CaseSensitive
+	self.apply()
+	return nil
+}
+
+// apply recompiles the find and re-renders the preview, which collects
+// the matches, then jumps to the first one from the bar's origin. The
+// cursor stays at the origin while nothing matches.
+func (self *PreviewFindHelper) apply() {
+	ns := self.navState()
+	re, err := compileFind(ns.Find)
+	self.ctx().Invalid = err != nil
+	ns.Find.Re = re
+	ns.CursorLine = self.ctx().Origin
+	ns.CurrentMatch = -1
+	self.c.GuiCommon().RenderPreview()
+	nav := self.c.Helpers().PreviewNav()
+	if nav.HasMatches() {
+		_ = nav.NextMatch()
+		return
+	}
+	nav.SyncCardIndexFromCursor()
+}
+
+// Confirm closes the bar, leaving the matches highlighted for n/N. An
+// empty or invalid pattern clears the find instead.
+func (self *PreviewFindHelper) Confirm() error {
+	ns := self.navState()
+	invalid := self.ctx().Invalid
+	self.close()
+	switch {
+	case invalid:
+		self.clear()
+		self.c.GuiCommon().ShowStatus("Invalid regular expression")
+	case !ns.Find.Active():
+		self.clear()
+	case len(ns.Matches) == 0:
+		self.c.GuiCommon().ShowStatus("Not found: " + ns.Find.Pattern)
+	}
+	return nil
+}
+
+// Cancel closes the bar, clears the find and returns the cursor to where
+// it was when the bar opened.
+func (self *PreviewFindHelper) Cancel() error {
+	self.close()
+	ns := self.navState()
+	ns.CursorLine = self.ctx().Origin
+	self.c.Helpers().PreviewNav().SyncCardIndexFromCursor()
+	self.clear()
+	return nil
+}
+
+func (self *PreviewFindHelper) close() {
+	gui := self.c.GuiCommon()
+	gui.SetCursorEnabled(false)
+	gui.PopContext()
+}
+
+func (self *PreviewFindHelper) clear() {
+	ns := self.navState()
+	ns.Find.Pattern = ""
+	ns.Find.Re = nil
+	ns.CurrentMatch = -1
+	self.c.GuiCommon().RenderPreview()
+}
+
+// compileFind builds the matcher for f: its pattern as literal text or a
+// regular expression, ignoring case unless f is case-sensitive. An empty
+// pattern has no matcher.
+func compileFind(f context.PreviewFind) (*regexp.Regexp, error) {
+	if f.Pattern == "" {
+		return nil, nil
+	}
+	expr := f.Pattern
+	if !f.Regex {
+		expr = regexp.QuoteMeta(expr)
+	}
+	if !f.CaseSensitive {
+		expr = "(?i)" + expr
+	}
+	return regexp.Compile(expr)
+}
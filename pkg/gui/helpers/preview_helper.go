@@ -1,6 +1,7 @@
 package helpers
 
 import (
+	"path/filepath"
 	"strings"
 
 	"github.com/donnellyk/lazyruin/pkg/commands"
@@ -41,31 +42,129 @@ func (self *PreviewHelper) BuildSearchOptions() commands.SearchOptions {
 // NewSearchSource builds a CardListSource that re-queries via ruin search.
 // The baseQuery is prepended to any filter text. Sort is applied if non-empty.
 func (self *PreviewHelper) NewSearchSource(baseQuery, sort string) context.CardListSource {
-	return context.CardListSource{
-		Query: baseQuery,
-		Requery: func(filterText string) ([]models.Note, error) {
-			combined := strings.TrimSpace(baseQuery + " " + filterText)
-			o := self.BuildSearchOptions()
-			o.Sort = sort
-			return self.c.RuinCmd().Search.Search(combined, o)
-		},
-	}
+	return self.CardListSource(context.SourceDescriptor{Kind: context.SourceSearch, Query: baseQuery, Sort: sort})
 }
 
 // NewSearchSourceWithExtractSort builds a CardListSource that re-queries via
 // ruin search, extracting a sort: token from rawQuery on each re-query. This
 // is used for user-typed search strings that may contain "sort:value".
 func (self *PreviewHelper) NewSearchSourceWithExtractSort(rawQuery string) context.CardListSource {
-	return context.CardListSource{
-		Query: rawQuery,
-		Requery: func(filterText string) ([]models.Note, error) {
-			q, s := ExtractSort(rawQuery)
+	return self.CardListSource(context.SourceDescriptor{Kind: context.SourceSearchRaw, Query: rawQuery})
+}
+
+// CardListSource builds the live source a descriptor describes. Part of
+// context.SourceResolver; unknown kinds get a source without a Requery.
+func (self *PreviewHelper) CardListSource(d context.SourceDescriptor) context.CardListSource {
+	source := context.CardListSource{Query: d.Query, Descriptor: d}
+	switch d.Kind {
+	case context.SourceSearch:
+		source.Requery = func(filterText string) ([]models.Note, error) {
+			combined := strings.TrimSpace(d.Query + " " + filterText)
+			o := self.BuildSearchOptions()
+			o.Sort = d.Sort
+			return self.c.RuinCmd().Search.Search(combined, o)
+		}
+	case context.SourceSearchRaw:
+		source.Requery = func(filterText string) ([]models.Note, error) {
+			q, s := ExtractSort(d.Query)
 			combined := strings.TrimSpace(q + " " + filterText)
 			o := self.BuildSearchOptions()
 			o.Sort = s
 			return self.c.RuinCmd().Search.Search(combined, o)
-		},
+		}
+	case context.SourceNote:
+		source.Requery = func(_ string) ([]models.Note, error) {
+			o := self.BuildSearchOptions()
+			note, err := self.c.RuinCmd().Search.Get(d.Query, o)
+			if err != nil || note == nil {
+				return nil, err
+			}
+			return []models.Note{*note}, nil
+		}
+	case context.SourceLinks:
+		source.Requery = func(filterText string) ([]models.Note, error) {
+			o := self.BuildSearchOptions()
+			o.Limit = 50
+			o.Link = true
+			return self.c.RuinCmd().Search.Search(strings.TrimSpace(filterText), o)
+		}
+	}
+	return source
+}
+
+// PickResultsSource builds the live pick source a descriptor describes.
+// Part of context.SourceResolver.
+func (self *PreviewHelper) PickResultsSource(d context.SourceDescriptor) context.PickResultsSource {
+	source := context.PickResultsSource{Query: d.Query, Descriptor: d}
+	switch d.Kind {
+	case context.SourcePickTag:
+		source.Requery = func(filterText string) ([]models.PickResult, error) {
+			combined := strings.TrimSpace(d.Query + " " + filterText)
+			tags, _, _, _ := ParsePickQuery(combined)
+			return self.c.RuinCmd().Pick.Pick(tags, commands.PickOpts{})
+		}
+	case context.SourcePick:
+		source.Requery = func(filterText string) ([]models.PickResult, error) {
+			combined := strings.TrimSpace(d.Query + " " + filterText)
+			t, date, filter, fl := ParsePickQuery(combined)
+			return self.c.RuinCmd().Pick.Pick(t, commands.PickOpts{
+				Any: d.Any || fl.Any, Todo: d.Todo || fl.Todo, All: d.All || fl.All,
+				Date: date, Filter: filter,
+			})
+		}
+	case context.SourceBacklinks:
+		source.Requery = func(filterText string) ([]models.PickResult, error) {
+			results, err := self.c.Helpers().Backlinks().Find(self.c.RuinCmd(), d.Query)
+			if err != nil {
+				return nil, err
+			}
+			return filterPickResults(results, filterText), nil
+		}
 	}
+	return source
+}
+
+// filterPickResults keeps the results whose title or any match contains
+// text, case-insensitively.
+func filterPickResults(results []models.PickResult, text string) []models.PickResult {
+	text = strings.ToLower(strings.TrimSpace(text))
+	if text == "" {
+		return results
+	}
+	var kept []models.PickResult
+	for _, r := range results {
+		if strings.Contains(strings.ToLower(r.Title), text) {
+			kept = append(kept, r)
+			continue
+		}
+		for _, m := range r.Matches {
+			if strings.Contains(strings.ToLower(m.Content), text) {
+				kept = append(kept, r)
+				break
+			}
+		}
+	}
+	return kept
+}
+
+// ComposeRequery returns the closure ComposeContext stores so a history
+// restore can re-run compose against the same bookmark. Part of
+// context.SourceResolver.
+func (self *PreviewHelper) ComposeRequery(parent models.ParentBookmark) context.ComposeRequery {
+	return func() (models.Note, []models.SourceMapEntry, error) {
+		return self.c.RuinCmd().Parent.Compose(parent)
+	}
+}
+
+// DatePreviewRequery returns the re-fetch closure for a date preview
+// target: a single date, or a "start..end" range. Part of
+// context.SourceResolver.
+func (self *PreviewHelper) DatePreviewRequery(target string) context.DatePreviewRequery {
+	dp := self.c.Helpers().DatePreview()
+	if start, end, ok := strings.Cut(target, ".."); ok {
+		return dp.dateRangeRequery(start, end)
+	}
+	return dp.dateRequery(target)
 }
 
 // CurrentPreviewCard returns the currently selected card, or nil if none.
@@ -144,22 +243,68 @@ func (self *PreviewHelper) UpdatePreviewForNotes() {
 
 	// Different note or not in cardList — show hover
 	title := displayTitleForNote(note.Title)
-	_ = self.c.Helpers().Navigator().ShowHover("cardList", title, func() error {
-		self.ShowCardList(title, []models.Note{note}, self.NewSingleNoteSource(note.UUID))
-		return nil
-	})
+	self.hoverCardList(title, func(_ *commands.RuinCommand) ([]models.Note, error) {
+		return []models.Note{note}, nil
+	}, self.NewSingleNoteSource(note.UUID))
 }
 
 // UpdatePreviewCardList loads a card list into the preview as a hover
-// preview. Does not record a history entry.
-func (self *PreviewHelper) UpdatePreviewCardList(title string, loadFn func() ([]models.Note, error)) {
-	_ = self.c.Helpers().Navigator().ShowHover("cardList", title, func() error {
-		notes, err := loadFn()
+// preview. Does not record a history entry. loadFn runs in the background
+// (see Navigator.ShowHoverAsync) and must make its ruin calls through cmd
+// so a superseded hover can be cancelled.
+func (self *PreviewHelper) UpdatePreviewCardList(title string, loadFn func(cmd *commands.RuinCommand) ([]models.Note, error)) {
+	self.hoverCardList(title, loadFn)
+}
+
+// HoverNote previews a single note by UUID without recording history.
+// The note is fetched in the background like any other hover.
+func (self *PreviewHelper) HoverNote(uuid, title string) {
+	opts := self.BuildSearchOptions()
+	self.hoverCardList(displayTitleForNote(title), func(cmd *commands.RuinCommand) ([]models.Note, error) {
+		note, err := cmd.Search.Get(uuid, opts)
+		if err != nil || note == nil {
+			return nil, err
+		}
+		return []models.Note{*note}, nil
+	}, self.NewSingleNoteSource(uuid))
+}
+
+// hoverCardList is the async counterpart to ShowCardList. Everything that
+// needs ruin — the cards, unknown parent titles, the per-card compose
+// cache — is fetched in the background; the load step handed to the
+// Navigator only assigns state and renders.
+func (self *PreviewHelper) hoverCardList(title string, fetch func(cmd *commands.RuinCommand) ([]models.Note, error), source ...context.CardListSource) {
+	ds := *self.cardList().DisplayState()
+	titles := self.c.Helpers().TitleCache()
+	resolveParents := titles.ParentResolver()
+	resolveAncestors := titles.AncestorResolver()
+
+	self.c.Helpers().Navigator().ShowHoverAsync("cardList", title, func(cmd *commands.RuinCommand) (func() error, error) {
+		cards, err := fetch(cmd)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		self.ShowCardList(title, notes)
-		return nil
+		titles.PutNotes(cards)
+		resolveParents(cmd, cards)
+		if len(cards) == 1 {
+			resolveAncestors(cmd, cards[0])
+		}
+		var composed []*models.Note
+		var maps [][]models.SourceMapEntry
+		if ds.ShowCompose {
+			composed, maps = composeCards(cmd, cards, &ds)
+		}
+		return func() error {
+			cl := self.setCardList(title, cards, source...)
+			if cl.DisplayState().ShowCompose == ds.ShowCompose {
+				cl.ComposedCards = composed
+				cl.ComposedSourceMaps = maps
+			} else {
+				self.RefreshComposedCards()
+			}
+			self.c.GuiCommon().RenderPreview()
+			return nil
+		}, nil
 	})
 }
 
@@ -176,29 +321,34 @@ func displayTitleForNote(title string) string {
 // wiki-link follow, etc.) so re-query on history restore stays in sync
 // with edits made to the underlying file.
 func (self *PreviewHelper) NewSingleNoteSource(uuid string) context.CardListSource {
-	return context.CardListSource{
-		Query: uuid,
-		Requery: func(_ string) ([]models.Note, error) {
-			o := self.BuildSearchOptions()
-			note, err := self.c.RuinCmd().Search.Get(uuid, o)
-			if err != nil || note == nil {
-				return nil, err
-			}
-			return []models.Note{*note}, nil
-		},
-	}
+	return self.CardListSource(context.SourceDescriptor{Kind: context.SourceNote, Query: uuid})
 }
 
 // ShowCardList sets the preview to card-list mode with the given cards and title,
 // then renders. Does NOT push nav history or change context focus.
 // The optional source enables filtering; pass a zero-value source to disable.
 func (self *PreviewHelper) ShowCardList(title string, cards []models.Note, source ...context.CardListSource) {
+	self.setCardList(title, cards, source...)
+	self.c.Helpers().TitleCache().PutNotes(cards)
+	self.c.Helpers().TitleCache().ResolveUnknownParents(cards)
+	if len(cards) == 1 {
+		self.c.Helpers().TitleCache().AncestorResolver()(self.c.RuinCmd(), cards[0])
+	}
+	self.RefreshComposedCards()
+	self.c.GuiCommon().RenderPreview()
+}
+
+// setCardList assigns card-list state and makes it the active preview,
+// leaving the compose cache empty. Shared by ShowCardList and its async
+// counterpart hoverCardList, which fill the cache in differently.
+func (self *PreviewHelper) setCardList(title string, cards []models.Note, source ...context.CardListSource) *context.CardListContext {
 	contexts := self.c.GuiCommon().Contexts()
 	cl := contexts.CardList
 	cl.Cards = cards
 	cl.SelectedCardIdx = 0
 	cl.SetTitle(title)
 	cl.ClearFilter()
+	cl.Selection.Clear()
 	if len(source) > 0 {
 		cl.Source = source[0]
 	} else {
@@ -210,10 +360,7 @@ func (self *PreviewHelper) ShowCardList(title string, cards []models.Note, sourc
 	ns.CursorLine = 1
 	ns.ScrollOffset = 0
 	contexts.ActivePreviewKey = "cardList"
-	self.c.Helpers().TitleCache().PutNotes(cards)
-	self.c.Helpers().TitleCache().ResolveUnknownParents(cards)
-	self.RefreshComposedCards()
-	self.c.GuiCommon().RenderPreview()
+	return cl
 }
 
 // ShowPickResults sets the preview to pick-results mode with the given results
@@ -257,6 +404,66 @@ func (self *PreviewHelper) ShowCompose(title string, note models.Note, sourceMap
 
 // --- content reload ---
 
+// ShowsAnyPath reports whether the active preview displays a note stored
+// at one of paths. Relative note paths are resolved against the vault.
+func (self *PreviewHelper) ShowsAnyPath(paths []string) bool {
+	want := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		want[filepath.Clean(p)] = true
+	}
+	vault := self.c.RuinCmd().VaultPath()
+	shown := func(p string) bool {
+		if p == "" {
+			return false
+		}
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(vault, p)
+		}
+		return want[filepath.Clean(p)]
+	}
+
+	contexts := self.c.GuiCommon().Contexts()
+	switch contexts.ActivePreviewKey {
+	case "pickResults":
+		for _, r := range contexts.PickResults.Results {
+			if shown(r.File) {
+				return true
+			}
+		}
+	case "compose":
+		comp := contexts.Compose
+		if shown(comp.Note.Path) {
+			return true
+		}
+		for _, e := range comp.SourceMap {
+			if shown(e.Path) {
+				return true
+			}
+		}
+	case "datePreview":
+		dp := contexts.DatePreview
+		for _, picks := range [][]models.PickResult{dp.TagPicks, dp.TodoPicks} {
+			for _, r := range picks {
+				if shown(r.File) {
+					return true
+				}
+			}
+		}
+		for _, n := range dp.Notes {
+			if shown(n.Path) {
+				return true
+			}
+		}
+	default:
+		for _, card := range contexts.CardList.Cards {
+			if shown(card.Path) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // cursorIdentity captures the source-line identity at the current cursor
 // position so it can be restored after a reload changes the line array.
 type cursorIdentity struct {
@@ -514,21 +721,26 @@ func (self *PreviewHelper) RefreshComposedCards() {
 		cl.ComposedSourceMaps = nil
 		return
 	}
-	composed := make([]*models.Note, len(cl.Cards))
-	maps := make([][]models.SourceMapEntry, len(cl.Cards))
-	for i, card := range cl.Cards {
+	cl.ComposedCards, cl.ComposedSourceMaps = composeCards(self.c.RuinCmd(), cl.Cards, ds)
+}
+
+// composeCards runs `ruin compose` once per card, returning results
+// parallel to cards. Failed or UUID-less cards get a nil entry.
+func composeCards(cmd *commands.RuinCommand, cards []models.Note, ds *context.PreviewDisplayState) ([]*models.Note, [][]models.SourceMapEntry) {
+	composed := make([]*models.Note, len(cards))
+	maps := make([][]models.SourceMapEntry, len(cards))
+	for i, card := range cards {
 		if card.UUID == "" {
 			continue
 		}
-		c, sm, err := self.c.RuinCmd().Parent.ComposeNote(card.UUID, !ds.ShowTitle, !ds.ShowGlobalTags)
+		c, sm, err := cmd.Parent.ComposeNote(card.UUID, !ds.ShowTitle, !ds.ShowGlobalTags)
 		if err != nil {
 			continue
 		}
 		composed[i] = &c
 		maps[i] = sm
 	}
-	cl.ComposedCards = composed
-	cl.ComposedSourceMaps = maps
+	return composed, maps
 }
 
 // isViewingRawFile reports whether the current display-state has all four
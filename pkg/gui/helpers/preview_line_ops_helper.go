@@ -44,13 +44,17 @@ func (self *PreviewLineOpsHelper) view() *gocui.View {
 
 // resolveTarget uses the Lines[] lookup to map the current cursor position
 // to a source file line. Works for all preview contexts (cardList, pickResults,
-// compose) and the pickDialog overlay.
+// compose) and the pickDialog overlay; in the Todos dashboard the target is
+// the selected todo.
 func (self *PreviewLineOpsHelper) resolveTarget() *lineTarget {
 	gui := self.c.GuiCommon()
 	var ns *context.PreviewNavState
-	if gui.CurrentContextKey() == "pickDialog" {
+	switch gui.CurrentContextKey() {
+	case "todos":
+		return self.c.Helpers().Todos().Target()
+	case "pickDialog":
 		ns = gui.Contexts().PickDialog.NavState()
-	} else {
+	default:
 		ns = gui.Contexts().ActivePreview().NavState()
 	}
 	if ns.CursorLine < 0 || ns.CursorLine >= len(ns.Lines) {
@@ -99,18 +103,25 @@ func (self *PreviewLineOpsHelper) ToggleTodo() error {
 		return nil
 	}
 
-	err := self.c.RuinCmd().Note.ToggleTodo(target.UUID, target.LineNum)
+	srcLine, _, _ := readSourceLine(target.Path, target.LineNum)
+
+	// --sink moves a completed item to the end of its list, so toggling
+	// the same line number again wouldn't undo it; snapshot instead.
+	err := self.c.Helpers().Undo().RecordSnapshot("Toggle todo", []string{target.Path}, func() error {
+		return self.c.RuinCmd().Note.ToggleTodo(target.UUID, target.LineNum)
+	})
 	if err != nil {
 		self.c.GuiCommon().ShowError(err)
 		return nil
 	}
+	done := models.HasDoneTag(srcLine) || !doneCheckboxRe.MatchString(srcLine)
+	self.c.Helpers().Todos().RecordDone(target.UUID, srcLine, done)
 
-	if self.c.GuiCommon().Contexts().ActivePreviewKey == "cardList" {
-		cl := self.ctx()
+	if cl := self.ctx(); self.c.GuiCommon().Contexts().ActivePreviewKey == "cardList" && cl.SelectedCardIdx < len(cl.Cards) {
 		cl.Cards[cl.SelectedCardIdx].Content = ""
 	}
 	self.c.Helpers().Preview().ReloadActivePreview()
-	self.reloadPickDialogIfActive()
+	self.reloadOverlayIfActive()
 	return nil
 }
 
@@ -134,18 +145,37 @@ func (self *PreviewLineOpsHelper) AppendDone() error {
 		self.c.GuiCommon().ShowError(err)
 		return nil
 	}
+	self.c.Helpers().Undo().RecordLineTag(target.UUID, "#done", target.LineNum, !hasDone)
+	self.c.Helpers().Todos().RecordDone(target.UUID, srcLine, !hasDone || doneCheckboxRe.MatchString(srcLine))
+	self.fireLineTagChange(target.UUID, target.Path, "#done", !hasDone)
 
 	self.c.Helpers().Preview().ReloadActivePreview()
 	self.c.Helpers().Tags().RefreshTags(false)
-	self.reloadPickDialogIfActive()
+	self.reloadOverlayIfActive()
 	return nil
 }
 
-// reloadPickDialogIfActive reloads the pick dialog overlay if it is currently
-// the active context. Called after line-level mutations to keep results fresh.
-func (self *PreviewLineOpsHelper) reloadPickDialogIfActive() {
-	if self.c.GuiCommon().CurrentContextKey() == "pickDialog" {
+// fireLineTagChange runs the on_tag_change hook for an inline tag added to
+// or removed from a line.
+func (self *PreviewLineOpsHelper) fireLineTagChange(uuid, path, tag string, added bool) {
+	ev := HookEvent{Name: HookTagChange, Path: path, UUID: uuid, Changed: []string{"tags"}}
+	if added {
+		ev.TagsAdded = []string{tag}
+	} else {
+		ev.TagsRemoved = []string{tag}
+	}
+	self.c.Helpers().Hooks().Fire(ev)
+}
+
+// reloadOverlayIfActive reloads the pick dialog or Todos dashboard if it is
+// currently the active context. Called after line-level mutations to keep
+// results fresh.
+func (self *PreviewLineOpsHelper) reloadOverlayIfActive() {
+	switch self.c.GuiCommon().CurrentContextKey() {
+	case "pickDialog":
 		self.c.Helpers().Pick().ReloadPickDialog()
+	case "todos":
+		self.c.Helpers().Todos().Reload()
 	}
 }
 
@@ -195,15 +225,19 @@ func (self *PreviewLineOpsHelper) ToggleInlineTag() error {
 					gui.ShowError(err)
 					return nil
 				}
+				self.c.Helpers().Undo().RecordLineTag(uuid, tag, lineNum, false)
+				self.fireLineTagChange(uuid, target.Path, tag, false)
 			} else {
 				if err := self.c.RuinCmd().Note.AddTagToLine(uuid, tag, lineNum); err != nil {
 					gui.ShowError(err)
 					return nil
 				}
+				self.c.Helpers().Undo().RecordLineTag(uuid, tag, lineNum, true)
+				self.fireLineTagChange(uuid, target.Path, tag, true)
 			}
 			self.c.Helpers().Preview().ReloadActivePreview()
 			self.c.Helpers().Tags().RefreshTags(false)
-			self.reloadPickDialogIfActive()
+			self.reloadOverlayIfActive()
 			return nil
 		},
 	})
@@ -228,6 +262,7 @@ func (self *PreviewLineOpsHelper) SetInlineDate() error {
 
 	uuid := target.UUID
 	lineNum := target.LineNum
+	path := target.Path
 	gui := self.c.GuiCommon()
 
 	removeAllDates := func() error {
@@ -267,17 +302,19 @@ func (self *PreviewLineOpsHelper) SetInlineDate() error {
 			if len(existingDates) == 1 && existingDates[item.InsertText] {
 				return nil
 			}
-			if err := removeAllDates(); err != nil {
-				gui.ShowError(err)
-				return nil
-			}
 			dateArg := strings.TrimPrefix(item.InsertText, "@")
-			if err := self.c.RuinCmd().Note.AddDateToLine(uuid, dateArg, lineNum); err != nil {
+			err := self.c.Helpers().Undo().RecordSnapshot("Set date "+item.InsertText, []string{path}, func() error {
+				if err := removeAllDates(); err != nil {
+					return err
+				}
+				return self.c.RuinCmd().Note.AddDateToLine(uuid, dateArg, lineNum)
+			})
+			if err != nil {
 				gui.ShowError(err)
 				return nil
 			}
 			self.c.Helpers().Preview().ReloadActivePreview()
-			self.reloadPickDialogIfActive()
+			self.reloadOverlayIfActive()
 			return nil
 		},
 		OnCtrlX: func() error {
@@ -285,12 +322,12 @@ func (self *PreviewLineOpsHelper) SetInlineDate() error {
 			if len(existingDates) == 0 {
 				return nil
 			}
-			if err := removeAllDates(); err != nil {
+			if err := self.c.Helpers().Undo().RecordSnapshot("Remove dates", []string{path}, removeAllDates); err != nil {
 				gui.ShowError(err)
 				return nil
 			}
 			self.c.Helpers().Preview().ReloadActivePreview()
-			self.reloadPickDialogIfActive()
+			self.reloadOverlayIfActive()
 			return nil
 		},
 	})
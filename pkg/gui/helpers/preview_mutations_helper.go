@@ -1,8 +1,12 @@
 package helpers
 
 import (
+	"fmt"
+
+	"github.com/donnellyk/lazyruin/pkg/commands"
 	"github.com/donnellyk/lazyruin/pkg/gui/context"
 	"github.com/donnellyk/lazyruin/pkg/gui/types"
+	"github.com/donnellyk/lazyruin/pkg/models"
 )
 
 // PreviewMutationsHelper handles card mutations: delete, move, merge, order.
@@ -19,12 +23,20 @@ func (self *PreviewMutationsHelper) ctx() *context.CardListContext {
 	return self.c.GuiCommon().Contexts().CardList
 }
 
-// DeleteCard deletes the currently selected card.
+// DeleteCard deletes the currently selected card, or every card in the
+// multi-selection.
 func (self *PreviewMutationsHelper) DeleteCard() error {
 	cl := self.ctx()
 	if len(cl.Cards) == 0 {
 		return nil
 	}
+	if notes := cl.SelectedNotes(cl.SelectedCardIdx); len(notes) > 0 {
+		return self.c.Helpers().Notes().DeleteNotes(notes, func(deleted map[string]bool) {
+			self.dropCards(deleted)
+			cl.Selection.Clear()
+			self.c.GuiCommon().RenderPreview()
+		})
+	}
 
 	card := cl.Cards[cl.SelectedCardIdx]
 	displayName := card.Title
@@ -34,8 +46,11 @@ func (self *PreviewMutationsHelper) DeleteCard() error {
 
 	gui := self.c.GuiCommon()
 	uuid := card.UUID
+	path := card.Path
 	self.c.Helpers().Confirmation().ConfirmDelete("Note", displayName,
-		func() error { return self.c.RuinCmd().Note.Delete(uuid) },
+		func() error {
+			return self.c.Helpers().Trash().TrashNote(uuid, path, displayName)
+		},
 		func() {
 			idx := cl.SelectedCardIdx
 			cl.Cards = append(cl.Cards[:idx], cl.Cards[idx+1:]...)
@@ -96,9 +111,41 @@ func (self *PreviewMutationsHelper) moveCard(direction string) error {
 	return nil
 }
 
-// MergeCardDialog shows the merge direction menu.
+// dropCards removes the cards whose UUIDs are in gone, keeping the
+// selected card index in range.
+func (self *PreviewMutationsHelper) dropCards(gone map[string]bool) {
+	cl := self.ctx()
+	kept := cl.Cards[:0]
+	for _, card := range cl.Cards {
+		if !gone[card.UUID] {
+			kept = append(kept, card)
+		}
+	}
+	cl.Cards = kept
+	if cl.SelectedCardIdx >= len(cl.Cards) {
+		cl.SelectedCardIdx = max(len(cl.Cards)-1, 0)
+	}
+}
+
+// MergeCardDialog shows the merge direction menu. With cards selected it
+// merges them all into the first selected card instead.
 func (self *PreviewMutationsHelper) MergeCardDialog() error {
 	cl := self.ctx()
+	if notes := cl.SelectedNotes(cl.SelectedCardIdx); len(notes) > 0 {
+		return self.MergeNotes(notes, func(target string, tags []string, merged map[string]bool) {
+			for i := range cl.Cards {
+				if cl.Cards[i].UUID == target {
+					cl.Cards[i].Content = ""
+					if len(tags) > 0 {
+						cl.Cards[i].Tags = tags
+					}
+				}
+			}
+			self.dropCards(merged)
+			cl.Selection.Clear()
+			self.c.GuiCommon().RenderPreview()
+		})
+	}
 	if len(cl.Cards) <= 1 {
 		return nil
 	}
@@ -130,7 +177,13 @@ func (self *PreviewMutationsHelper) executeMerge(direction string) error {
 	target := cl.Cards[targetIdx]
 	source := cl.Cards[sourceIdx]
 
-	result, err := self.c.RuinCmd().Note.Merge(target.UUID, source.UUID, true, false)
+	var result *commands.MergeResult
+	err := self.c.Helpers().Undo().RecordSnapshot("Merge "+source.Title+" into "+target.Title,
+		[]string{target.Path, source.Path}, func() error {
+			var err error
+			result, err = self.c.RuinCmd().Note.Merge(target.UUID, source.UUID, true, false)
+			return err
+		})
 	if err != nil {
 		self.c.GuiCommon().ShowError(err)
 		return nil
@@ -154,15 +207,69 @@ func (self *PreviewMutationsHelper) executeMerge(direction string) error {
 	return nil
 }
 
+// MergeNotes asks once, then merges every note after the first into the
+// first, deleting the merged notes. Each merge snapshots its two files so
+// the whole batch undoes in one step. onMerged receives the target UUID,
+// its merged tag list, and the UUIDs that were merged away.
+func (self *PreviewMutationsHelper) MergeNotes(notes []models.Note, onMerged func(target string, tags []string, merged map[string]bool)) error {
+	gui := self.c.GuiCommon()
+	if len(notes) < 2 {
+		gui.ShowError(fmt.Errorf("select at least two notes to merge"))
+		return nil
+	}
+	target, sources := notes[0], notes[1:]
+	title := target.Title
+	if title == "" {
+		title = target.Path
+	}
+	msg := fmt.Sprintf("Merge %d notes into %q? The merged notes are deleted.", len(sources), title)
+	gui.ShowConfirm("Merge Notes", msg, func() error {
+		errs := bulkErrors{total: len(sources)}
+		merged := make(map[string]bool, len(sources))
+		var tags []string
+		self.c.Helpers().Undo().Batch(bulkTitle("Merge into "+title, notes), func() {
+			for _, source := range sources {
+				err := self.c.Helpers().Undo().RecordSnapshot("Merge "+source.Title+" into "+title,
+					[]string{target.Path, source.Path}, func() error {
+						result, err := self.c.RuinCmd().Note.Merge(target.UUID, source.UUID, true, false)
+						if err == nil && len(result.TagsMerged) > 0 {
+							tags = result.TagsMerged
+						}
+						return err
+					})
+				if err != nil {
+					errs.add(source, err)
+					continue
+				}
+				merged[source.UUID] = true
+				self.c.Helpers().Navigator().NoteDeleted(source.UUID)
+			}
+		})
+		if err := errs.err(); err != nil {
+			gui.ShowError(err)
+		}
+		onMerged(target.UUID, tags, merged)
+		self.c.Helpers().Notes().FetchNotesForCurrentTab(false)
+		return nil
+	})
+	return nil
+}
+
 // OrderCards persists the current card order to frontmatter order fields.
 func (self *PreviewMutationsHelper) OrderCards() error {
 	cl := self.ctx()
+	changes := make([]OrderChange, 0, len(cl.Cards))
 	for i, card := range cl.Cards {
 		if err := self.c.RuinCmd().Note.SetOrder(card.UUID, i+1); err != nil {
+			if len(changes) > 0 {
+				self.c.Helpers().Undo().RecordOrder(changes)
+			}
 			self.c.GuiCommon().ShowError(err)
 			return nil
 		}
+		changes = append(changes, OrderChange{UUID: card.UUID, Prev: card.Order, Next: i + 1})
 	}
+	self.c.Helpers().Undo().RecordOrder(changes)
 	cl.TemporarilyMoved = nil
 	self.c.Helpers().Preview().ReloadContent()
 	return nil
@@ -100,13 +100,18 @@ func (self *PreviewNavHelper) OpenSourceAtCursor() error {
 	if target == nil {
 		return nil
 	}
+	return self.OpenNoteAtLine(target.UUID, target.LineNum)
+}
+
+// OpenNoteAtLine opens a note in card-list view with the cursor on the
+// given 1-indexed content line. No-op if the note can't be found.
+func (self *PreviewNavHelper) OpenNoteAtLine(uuid string, targetLine int) error {
 	opts := self.c.Helpers().Preview().BuildSearchOptions()
-	note, err := self.c.RuinCmd().Search.Get(target.UUID, opts)
+	note, err := self.c.RuinCmd().Search.Get(uuid, opts)
 	if err != nil || note == nil {
 		return nil
 	}
 	noteCopy := *note
-	targetLine := target.LineNum
 	title := displayTitleForNote(noteCopy.Title)
 	return self.c.Helpers().Navigator().NavigateTo("cardList", title, func() error {
 		source := self.c.Helpers().Preview().NewSingleNoteSource(noteCopy.UUID)
@@ -423,6 +428,63 @@ func (self *PreviewNavHelper) PrevHeader() error {
 	return nil
 }
 
+// HasMatches reports whether the preview highlights any search matches.
+func (self *PreviewNavHelper) HasMatches() bool {
+	return len(self.activeCtx().NavState().Matches) > 0
+}
+
+// NextMatch jumps to the next search match, after the current one or the
+// first at or below the cursor, wrapping to the first.
+func (self *PreviewNavHelper) NextMatch() error {
+	ns := self.activeCtx().NavState()
+	if len(ns.Matches) == 0 {
+		return nil
+	}
+	next := 0
+	if cur := ns.ActiveMatch(); cur >= 0 {
+		next = (cur + 1) % len(ns.Matches)
+	} else {
+		for i, m := range ns.Matches {
+			if m.Line >= ns.CursorLine {
+				next = i
+				break
+			}
+		}
+	}
+	self.jumpToMatch(next)
+	return nil
+}
+
+// PrevMatch jumps to the previous search match, before the current one or
+// the last above the cursor, wrapping to the last.
+func (self *PreviewNavHelper) PrevMatch() error {
+	ns := self.activeCtx().NavState()
+	if len(ns.Matches) == 0 {
+		return nil
+	}
+	prev := len(ns.Matches) - 1
+	if cur := ns.ActiveMatch(); cur >= 0 {
+		prev = (cur - 1 + len(ns.Matches)) % len(ns.Matches)
+	} else {
+		for i := len(ns.Matches) - 1; i >= 0; i-- {
+			if ns.Matches[i].Line < ns.CursorLine {
+				prev = i
+				break
+			}
+		}
+	}
+	self.jumpToMatch(prev)
+	return nil
+}
+
+func (self *PreviewNavHelper) jumpToMatch(idx int) {
+	ns := self.activeCtx().NavState()
+	ns.CurrentMatch = idx
+	ns.CursorLine = ns.Matches[idx].Line
+	self.SyncCardIndexFromCursor()
+	self.renderActive()
+}
+
 // NextSection jumps to the next section header (datePreview only).
 func (self *PreviewNavHelper) NextSection() error {
 	gui := self.c.GuiCommon()
@@ -560,8 +622,12 @@ func (self *PreviewNavHelper) Click() error {
 	return nil
 }
 
-// Back pops the preview context.
+// Back pops the preview context, or first drops a card-list
+// multi-selection if there is one.
 func (self *PreviewNavHelper) Back() error {
+	if self.c.Helpers().Selection().Clear() {
+		return nil
+	}
 	self.c.GuiCommon().PopContext()
 	return nil
 }
@@ -1,6 +1,7 @@
 package helpers
 
 import (
+	"github.com/donnellyk/lazyruin/pkg/commands"
 	"github.com/donnellyk/lazyruin/pkg/gui/context"
 	"github.com/donnellyk/lazyruin/pkg/models"
 )
@@ -150,7 +151,7 @@ func (self *QueriesHelper) ViewParent() error {
 			return err
 		}
 		self.c.Helpers().Preview().ShowCompose("Parent: "+parentCopy.Name, composed, sourceMap, parentCopy)
-		gui.Contexts().Compose.Requery = self.parentRequery(parentCopy)
+		gui.Contexts().Compose.Requery = self.c.Helpers().Preview().ComposeRequery(parentCopy)
 		return nil
 	})
 }
@@ -183,8 +184,9 @@ func (self *QueriesHelper) UpdatePreviewForQueries() {
 		return
 	}
 
-	self.c.Helpers().Preview().UpdatePreviewCardList("Query: "+query.Name, func() ([]models.Note, error) {
-		return self.c.RuinCmd().Queries.Run(query.Name, self.c.Helpers().Preview().BuildSearchOptions())
+	opts := self.c.Helpers().Preview().BuildSearchOptions()
+	self.c.Helpers().Preview().UpdatePreviewCardList("Query: "+query.Name, func(cmd *commands.RuinCommand) ([]models.Note, error) {
+		return cmd.Queries.Run(query.Name, opts)
 	})
 }
 
@@ -198,14 +200,17 @@ func (self *QueriesHelper) UpdatePreviewForParents() {
 	}
 	parentCopy := *parent
 
-	_ = self.c.Helpers().Navigator().ShowHover("compose", "Parent: "+parentCopy.Name, func() error {
-		composed, sourceMap, err := self.composeParent(&parentCopy)
+	title := "Parent: " + parentCopy.Name
+	self.c.Helpers().Navigator().ShowHoverAsync("compose", title, func(cmd *commands.RuinCommand) (func() error, error) {
+		composed, sourceMap, err := cmd.Parent.Compose(parentCopy)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		self.c.Helpers().Preview().ShowCompose("Parent: "+parentCopy.Name, composed, sourceMap, parentCopy)
-		gui.Contexts().Compose.Requery = self.parentRequery(parentCopy)
-		return nil
+		return func() error {
+			self.c.Helpers().Preview().ShowCompose(title, composed, sourceMap, parentCopy)
+			gui.Contexts().Compose.Requery = self.c.Helpers().Preview().ComposeRequery(parentCopy)
+			return nil
+		}, nil
 	})
 }
 
@@ -213,11 +218,3 @@ func (self *QueriesHelper) UpdatePreviewForParents() {
 func (self *QueriesHelper) composeParent(parent *models.ParentBookmark) (models.Note, []models.SourceMapEntry, error) {
 	return self.c.RuinCmd().Parent.Compose(*parent)
 }
-
-// parentRequery returns a closure that re-composes the given parent. Used as
-// the ComposeContext.Requery to re-run on history restore.
-func (self *QueriesHelper) parentRequery(parent models.ParentBookmark) context.ComposeRequery {
-	return func() (models.Note, []models.SourceMapEntry, error) {
-		return self.c.RuinCmd().Parent.Compose(parent)
-	}
-}
@@ -73,7 +73,7 @@ func RefreshList[T any](
 
 // RefreshAll refreshes data for all panels. Programmatic callers (initial
 // layout, onboarding, background refresh) use this directly; the
-// user-triggered `<c-r>` path goes through ReloadAndRefresh below so a
+// user-triggered `F5` path goes through ReloadAndRefresh below so a
 // disk re-read of config doesn't clobber test fixtures or in-flight
 // mutations.
 func (self *RefreshHelper) RefreshAll() {
@@ -89,7 +89,7 @@ func (self *RefreshHelper) RefreshAll() {
 
 // ReloadAndRefresh re-reads `~/.config/lazyruin/config.yml` into the
 // in-memory Config and then refreshes every panel. Used by the explicit
-// `<c-r>` keybinding so users can pick up edits to
+// `F5` keybinding so users can pick up edits to
 // `notes_pane.custom_sections` (and other YAML keys) without restarting.
 // A YAML or IO error is surfaced via the status bar and the previous
 // in-memory config is left intact.
@@ -19,6 +19,12 @@ func NewScratchpadHelper(c *HelperCommon) *ScratchpadHelper {
 	return &ScratchpadHelper{c: c, store: store}
 }
 
+// LoadVault points the scratchpad at another vault's store.
+func (self *ScratchpadHelper) LoadVault(vaultPath string) {
+	self.store = scratchpad.NewStoreForVault(vaultPath)
+	_ = self.store.Load()
+}
+
 // SetTriggers sets the completion trigger provider (called from gui package
 // after initialization, since trigger functions live on *Gui).
 func (self *ScratchpadHelper) SetTriggers(fn func() []types.CompletionTrigger) {
@@ -5,6 +5,7 @@ import (
 
 	"github.com/donnellyk/lazyruin/pkg/gui/context"
 	"github.com/donnellyk/lazyruin/pkg/gui/types"
+	"github.com/donnellyk/lazyruin/pkg/searchhistory"
 )
 
 // SearchHelper manages search execution and query management.
@@ -30,6 +31,7 @@ func (self *SearchHelper) OpenSearch() error {
 	cs := types.NewCompletionState()
 	cs.FallbackCandidates = AmbientDateCandidates()
 	self.searchCtx().Completion = cs
+	self.c.Helpers().SearchHistory().ResetRecall(searchhistory.KindSearch)
 	gui.PushContextByKey("search")
 	return nil
 }
@@ -43,6 +45,7 @@ func (self *SearchHelper) ExecuteSearch(raw string) (executed bool) {
 		return false
 	}
 
+	self.c.Helpers().SearchHistory().Record(searchhistory.KindSearch, raw)
 	query, sort := ExtractSort(raw)
 
 	// "executed=true" means "input was non-empty and we attempted the
@@ -108,11 +111,20 @@ func (self *SearchHelper) ClearSearch() {
 // for a name under which to save the current query string.
 func (self *SearchHelper) PromptSaveQuery(raw string) error {
 	raw = strings.TrimSpace(raw)
-	gui := self.c.GuiCommon()
 	if raw == "" {
 		return nil
 	}
 	self.CancelSearch()
+	return self.PromptQueryName(raw)
+}
+
+// PromptQueryName opens an input popup asking for a name under which to
+// save query.
+func (self *SearchHelper) PromptQueryName(query string) error {
+	gui := self.c.GuiCommon()
+	if query == "" {
+		return nil
+	}
 	self.c.Helpers().InputPopup().OpenInputPopup(&types.InputPopupConfig{
 		Title:  "Save Query",
 		Footer: " Enter: save | Esc: cancel ",
@@ -121,7 +133,7 @@ func (self *SearchHelper) PromptSaveQuery(raw string) error {
 			if name == "" {
 				return nil
 			}
-			if err := self.c.RuinCmd().Queries.Save(name, raw); err != nil {
+			if err := self.c.RuinCmd().Queries.Save(name, query); err != nil {
 				gui.ShowError(err)
 				return nil
 			}
@@ -163,3 +175,39 @@ func ExtractSort(query string) (string, string) {
 	}
 	return strings.Join(remaining, " "), sortVal
 }
+
+// SearchTerms returns the free-text terms of a search query: plain words
+// and quoted phrases. Filters (#tags, @dates, key:value such as created:
+// or sort:), negated terms and the AND/OR/NOT operators are left out.
+func SearchTerms(query string) []string {
+	var terms []string
+	rest := strings.TrimSpace(query)
+	for rest != "" {
+		if after, ok := strings.CutPrefix(rest, `"`); ok {
+			phrase, tail, closed := strings.Cut(after, `"`)
+			if !closed {
+				phrase, tail = after, ""
+			}
+			if phrase = strings.TrimSpace(phrase); phrase != "" {
+				terms = append(terms, phrase)
+			}
+			rest = strings.TrimSpace(tail)
+			continue
+		}
+		token, tail, _ := strings.Cut(rest, " ")
+		if strings.Count(token, `"`)%2 == 1 {
+			// A quoted filter value (title:"two words") runs to the
+			// closing quote.
+			_, tail, _ = strings.Cut(tail, `"`)
+		}
+		rest = strings.TrimSpace(tail)
+		token = strings.Trim(token, "()")
+		switch {
+		case token == "", token == "AND", token == "OR", token == "NOT":
+		case strings.ContainsAny(token[:1], "#@-!"), strings.Contains(token, ":"):
+		default:
+			terms = append(terms, token)
+		}
+	}
+	return terms
+}
@@ -0,0 +1,148 @@
+package helpers
+
+import (
+	"strings"
+	"time"
+
+	"github.com/donnellyk/lazyruin/pkg/gui/types"
+	"github.com/donnellyk/lazyruin/pkg/searchhistory"
+)
+
+// SearchHistoryHelper records executed searches and picks in the active
+// vault's history, steps through them from the popups' inputs and lists
+// them for re-running or saving as queries.
+type SearchHistoryHelper struct {
+	c      *HelperCommon
+	now    func() time.Time
+	recall map[string]*historyRecall
+}
+
+// historyRecall is where Up/Down recall stands in one popup: the queries
+// loaded when it opened, the one shown (-1 = the user's own text) and that
+// text, restored when stepping back past the newest entry.
+type historyRecall struct {
+	queries []string
+	idx     int
+	draft   string
+}
+
+func NewSearchHistoryHelper(c *HelperCommon) *SearchHistoryHelper {
+	return &SearchHistoryHelper{c: c, now: time.Now, recall: map[string]*historyRecall{}}
+}
+
+func (self *SearchHistoryHelper) load() (*searchhistory.Store, error) {
+	store := searchhistory.NewStoreForVault(self.c.RuinCmd().VaultPath())
+	if err := store.Load(); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// Record puts query at the top of the vault's history. Saving is
+// best-effort: a failure only loses the entry, and a file that can't be
+// read is left alone rather than overwritten.
+func (self *SearchHistoryHelper) Record(kind, query string) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return
+	}
+	store, err := self.load()
+	if err != nil {
+		return
+	}
+	store.Add(kind, query, self.now())
+	_ = store.Save()
+}
+
+// ResetRecall starts Up/Down recall afresh for a popup of kind that just
+// opened.
+func (self *SearchHistoryHelper) ResetRecall(kind string) {
+	r := &historyRecall{idx: -1}
+	if store, err := self.load(); err == nil {
+		for _, e := range store.Entries(kind) {
+			r.queries = append(r.queries, e.Query)
+		}
+	}
+	self.recall[kind] = r
+}
+
+// Recall steps to the next older (or newer) entry of kind and returns the
+// text to show in place of current. It reports false when there is
+// nowhere further to go.
+func (self *SearchHistoryHelper) Recall(kind, current string, older bool) (string, bool) {
+	r := self.recall[kind]
+	if r == nil {
+		return "", false
+	}
+	if older {
+		if r.idx+1 >= len(r.queries) {
+			return "", false
+		}
+		if r.idx == -1 {
+			r.draft = current
+		}
+		r.idx++
+		return r.queries[r.idx], true
+	}
+	if r.idx < 0 {
+		return "", false
+	}
+	r.idx--
+	if r.idx == -1 {
+		return r.draft, true
+	}
+	return r.queries[r.idx], true
+}
+
+// OpenBrowser lists the vault's history in the palette, most recent first.
+// Enter re-runs an entry and <c-s> saves it as a named query.
+func (self *SearchHistoryHelper) OpenBrowser() error {
+	gui := self.c.GuiCommon()
+	store, err := self.load()
+	if err != nil {
+		gui.ShowError(err)
+		return nil
+	}
+	entries := store.Entries("")
+	if len(entries) == 0 {
+		gui.ShowStatus("No search history yet")
+		return nil
+	}
+	items := make([]types.PaletteCommand, 0, len(entries))
+	for _, e := range entries {
+		items = append(items, self.paletteItem(e))
+	}
+	gui.ShowPaletteList("Search History", " Enter: run | <c-s>: save as query | Esc: close ", items)
+	return nil
+}
+
+func (self *SearchHistoryHelper) paletteItem(e searchhistory.Entry) types.PaletteCommand {
+	query := e.Query
+	item := types.PaletteCommand{
+		Name:     query,
+		Category: "Search",
+		Detail:   e.Time.Format("Jan 2 15:04"),
+		OnRun: func() error {
+			self.c.Helpers().Search().ExecuteSearch(query)
+			return nil
+		},
+		OnSave: func() error { return self.c.Helpers().Search().PromptQueryName(query) },
+	}
+	if e.Kind == searchhistory.KindPick {
+		item.Category = "Pick"
+		item.OnRun = func() error { return self.c.Helpers().Pick().RunPick(query) }
+		item.OnSave = func() error { return self.c.Helpers().Search().PromptQueryName(pickAsSearch(query)) }
+	}
+	return item
+}
+
+// pickAsSearch turns a pick query into the search for the same tags and
+// date, since saved queries are searches. Pick flags have no search
+// equivalent and are dropped.
+func pickAsSearch(raw string) string {
+	tags, date, _, _ := ParsePickQuery(raw)
+	if date != "" {
+		tags = append(tags, date)
+	}
+	return strings.Join(tags, " ")
+}
@@ -0,0 +1,134 @@
+package helpers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/donnellyk/lazyruin/pkg/gui/context"
+	"github.com/donnellyk/lazyruin/pkg/models"
+)
+
+// SelectionHelper manages the multi-selection in the Notes list and the
+// card list. Whichever of the two is focused owns the selection that
+// note actions apply to.
+type SelectionHelper struct {
+	c *HelperCommon
+}
+
+// NewSelectionHelper creates a new SelectionHelper.
+func NewSelectionHelper(c *HelperCommon) *SelectionHelper {
+	return &SelectionHelper{c: c}
+}
+
+// ToggleNote marks or unmarks the note under the cursor.
+func (self *SelectionHelper) ToggleNote() error {
+	gui := self.c.GuiCommon()
+	switch gui.CurrentContextKey() {
+	case "notes":
+		ctx := gui.Contexts().Notes
+		if note := ctx.Selected(); note != nil {
+			ctx.Selection.Toggle(note.UUID)
+			gui.RenderNotes()
+		}
+	case "cardList":
+		cl := gui.Contexts().CardList
+		if cl.SelectedCardIdx < len(cl.Cards) {
+			cl.Selection.Toggle(cl.Cards[cl.SelectedCardIdx].UUID)
+			gui.RenderPreview()
+		}
+	}
+	return nil
+}
+
+// ToggleRange anchors a range at the cursor, or commits the range in
+// progress to the selection.
+func (self *SelectionHelper) ToggleRange() error {
+	gui := self.c.GuiCommon()
+	switch gui.CurrentContextKey() {
+	case "notes":
+		ctx := gui.Contexts().Notes
+		ctx.Selection.ToggleRange(context.NoteUUIDs(ctx.Items), ctx.GetSelectedLineIdx())
+		gui.RenderNotes()
+	case "cardList":
+		cl := gui.Contexts().CardList
+		cl.Selection.ToggleRange(context.NoteUUIDs(cl.Cards), cl.SelectedCardIdx)
+		gui.RenderPreview()
+	}
+	return nil
+}
+
+// Clear drops the selection in the focused list. Reports whether there
+// was one, so Esc can fall through to its usual action otherwise.
+func (self *SelectionHelper) Clear() bool {
+	gui := self.c.GuiCommon()
+	switch gui.CurrentContextKey() {
+	case "notes":
+		ctx := gui.Contexts().Notes
+		if ctx.Selection.Active() {
+			ctx.Selection.Clear()
+			gui.RenderNotes()
+			return true
+		}
+	case "cardList":
+		cl := gui.Contexts().CardList
+		if cl.Selection.Active() {
+			cl.Selection.Clear()
+			gui.RenderPreview()
+			return true
+		}
+	}
+	return false
+}
+
+// SelectedNotes returns the notes selected in the focused list, or nil
+// when it has no selection.
+func (self *SelectionHelper) SelectedNotes() []models.Note {
+	gui := self.c.GuiCommon()
+	switch gui.CurrentContextKey() {
+	case "notes":
+		return gui.Contexts().Notes.SelectedNotes()
+	case "cardList":
+		cl := gui.Contexts().CardList
+		return cl.SelectedNotes(cl.SelectedCardIdx)
+	}
+	return nil
+}
+
+// bulkErrors collects the per-note failures of an action applied to
+// total notes so they can be reported once.
+type bulkErrors struct {
+	total int
+	errs  []error
+}
+
+func (self *bulkErrors) add(note models.Note, err error) {
+	if self.total > 1 {
+		name := note.Title
+		if name == "" {
+			name = note.Path
+		}
+		err = fmt.Errorf("%s: %w", name, err)
+	}
+	self.errs = append(self.errs, err)
+}
+
+// allFailed reports whether every note failed, leaving nothing to refresh.
+func (self *bulkErrors) allFailed() bool {
+	return len(self.errs) >= self.total
+}
+
+// err folds the failures into one error, or nil if there were none. A
+// single-note action reports its error unchanged.
+func (self *bulkErrors) err() error {
+	switch {
+	case len(self.errs) == 0:
+		return nil
+	case self.total <= 1:
+		return self.errs[0]
+	}
+	msgs := make([]string, len(self.errs))
+	for i, err := range self.errs {
+		msgs[i] = err.Error()
+	}
+	return fmt.Errorf("%d of %d notes failed: %s", len(self.errs), self.total, strings.Join(msgs, "; "))
+}
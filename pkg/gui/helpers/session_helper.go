@@ -0,0 +1,177 @@
+package helpers
+
+import (
+	"slices"
+
+	"github.com/donnellyk/lazyruin/pkg/gui/context"
+	"github.com/donnellyk/lazyruin/pkg/gui/types"
+	"github.com/donnellyk/lazyruin/pkg/session"
+)
+
+// SessionHelper captures the UI session — tabs, the active preview and
+// navigation history — into the active vault's session file and restores
+// it on the next launch. Which panel had focus is filled in by the gui,
+// which owns the context stack.
+type SessionHelper struct {
+	c *HelperCommon
+}
+
+func NewSessionHelper(c *HelperCommon) *SessionHelper {
+	return &SessionHelper{c: c}
+}
+
+func (self *SessionHelper) store() *session.Store {
+	return session.NewStoreForVault(self.c.RuinCmd().VaultPath())
+}
+
+// Load reads the active vault's saved session. A missing or unreadable
+// file yields nil: the launch then starts fresh, as it always has.
+func (self *SessionHelper) Load() *session.Session {
+	sess, err := self.store().Load()
+	if err != nil {
+		return nil
+	}
+	return sess
+}
+
+// Save writes sess as the active vault's session.
+func (self *SessionHelper) Save(sess *session.Session) error {
+	return self.store().Save(sess)
+}
+
+// Capture records the current tabs, preview and history. Entries whose
+// view can't be re-queried (lists shown without a source) are left out.
+func (self *SessionHelper) Capture() *session.Session {
+	gui := self.c.GuiCommon()
+	contexts := gui.Contexts()
+	nav := self.c.Helpers().Navigator()
+	nav.Checkpoint()
+
+	sess := &session.Session{
+		NotesTab:      string(contexts.Notes.CurrentTab),
+		NotesOuterTab: gui.NotesOuterTab(),
+		QueriesTab:    string(contexts.Queries.CurrentTab),
+		TagsTab:       string(contexts.Tags.CurrentTab),
+		HistoryIndex:  -1,
+	}
+
+	mgr := nav.Manager()
+	for i, evt := range mgr.Entries() {
+		entry, ok := encodeEvent(evt)
+		if !ok {
+			continue
+		}
+		if i <= mgr.Index() {
+			sess.HistoryIndex = len(sess.History)
+		}
+		sess.History = append(sess.History, entry)
+	}
+
+	if ctx := contexts.ActivePreview(); ctx != nil {
+		if s, ok := ctx.(types.Snapshotter); ok {
+			entry, ok := encodeEvent(context.NavigationEvent{
+				ContextKey: contexts.ActivePreviewKey,
+				Title:      ctx.Title(),
+				Snapshot:   s.CaptureSnapshot(),
+			})
+			if ok {
+				sess.Preview = &entry
+				sess.PreviewCommitted = nav.IsCurrentCommitted()
+			}
+		}
+	}
+	return sess
+}
+
+// RestoreTabs selects the saved tabs. Call before the panels are first
+// loaded so they load the right tab. Unknown tab names are ignored.
+func (self *SessionHelper) RestoreTabs(sess *session.Session) {
+	if sess == nil {
+		return
+	}
+	gui := self.c.GuiCommon()
+	contexts := gui.Contexts()
+	if tab := context.NotesTab(sess.NotesTab); slices.Contains(context.NotesTabs, tab) {
+		contexts.Notes.CurrentTab = tab
+	}
+	if tab := context.QueriesTab(sess.QueriesTab); slices.Contains(context.QueriesTabs, tab) {
+		contexts.Queries.CurrentTab = tab
+	}
+	if tab := context.TagsTab(sess.TagsTab); slices.Contains(context.TagsTabs, tab) {
+		contexts.Tags.CurrentTab = tab
+	}
+	if sess.NotesOuterTab == "home" || sess.NotesOuterTab == "notes" {
+		gui.SetNotesOuterTab(sess.NotesOuterTab)
+	}
+	gui.UpdateNotesTab()
+	gui.UpdateQueriesTab()
+	gui.UpdateTagsTab()
+}
+
+// RestorePreview reloads the saved history and re-runs the saved preview's
+// query, focusing the preview. Reports false when there was nothing to
+// restore, leaving the caller to load its default preview.
+func (self *SessionHelper) RestorePreview(sess *session.Session) bool {
+	if sess == nil {
+		return false
+	}
+	var entries []context.NavigationEvent
+	index := -1
+	for i, e := range sess.History {
+		evt, ok := decodeEntry(e)
+		if !ok {
+			continue
+		}
+		if i <= sess.HistoryIndex {
+			index = len(entries)
+		}
+		entries = append(entries, evt)
+	}
+
+	current, committed := context.NavigationEvent{}, false
+	if sess.Preview != nil {
+		evt, ok := decodeEntry(*sess.Preview)
+		if ok {
+			current, committed = evt, sess.PreviewCommitted
+		}
+	}
+	if current.Snapshot == nil {
+		if index < 0 {
+			return false
+		}
+		current, committed = entries[index], true
+	}
+	if err := self.c.Helpers().Navigator().RestoreSession(entries, index, current, committed); err != nil {
+		return false
+	}
+	return true
+}
+
+func encodeEvent(evt context.NavigationEvent) (session.Entry, bool) {
+	data, ok := context.MarshalSnapshot(evt.Snapshot)
+	if !ok {
+		return session.Entry{}, false
+	}
+	return session.Entry{
+		Context:  string(evt.ContextKey),
+		Title:    evt.Title,
+		ID:       evt.ID,
+		Time:     evt.Timestamp,
+		Snapshot: data,
+	}, true
+}
+
+func decodeEntry(e session.Entry) (context.NavigationEvent, bool) {
+	key := types.ContextKey(e.Context)
+	snap, err := context.UnmarshalSnapshot(key, e.Snapshot)
+	if err != nil {
+		return context.NavigationEvent{}, false
+	}
+	return context.NavigationEvent{
+		ContextKey: key,
+		Title:      e.Title,
+		Snapshot:   snap,
+		Timestamp:  e.Time,
+		ID:         e.ID,
+	}, true
+}
@@ -1,8 +1,6 @@
 package helpers
 
 import (
-	"strings"
-
 	"github.com/donnellyk/lazyruin/pkg/commands"
 	"github.com/donnellyk/lazyruin/pkg/gui/context"
 	"github.com/donnellyk/lazyruin/pkg/models"
@@ -112,14 +110,7 @@ func (self *TagsHelper) FilterByTagPick(tag *models.Tag) error {
 		pickCtx.Query = tagName
 		pickCtx.AnyMode = false
 
-		source := context.PickResultsSource{
-			Query: tagName,
-			Requery: func(filterText string) ([]models.PickResult, error) {
-				combined := strings.TrimSpace(tagName + " " + filterText)
-				tags, _, _, _ := ParsePickQuery(combined)
-				return self.c.RuinCmd().Pick.Pick(tags, commands.PickOpts{})
-			},
-		}
+		source := self.c.Helpers().Preview().PickResultsSource(context.SourceDescriptor{Kind: context.SourcePickTag, Query: tagName})
 
 		self.c.Helpers().Preview().ShowPickResults("Pick: "+tagName, results, source)
 		return nil
@@ -142,6 +133,12 @@ func (self *TagsHelper) RenameTag(tag *models.Tag) error {
 			gui.ShowError(err)
 			return nil
 		}
+		self.c.Helpers().Hooks().Fire(HookEvent{
+			Name:        HookTagChange,
+			Changed:     []string{"tags"},
+			TagsAdded:   []string{newName},
+			TagsRemoved: []string{tag.Name},
+		})
 		self.c.Helpers().Tags().RefreshTags(false)
 		self.c.Helpers().Preview().ReloadActivePreview()
 		return nil
@@ -162,6 +159,11 @@ func (self *TagsHelper) DeleteTag(tag *models.Tag) error {
 			gui.ShowError(err)
 			return nil
 		}
+		self.c.Helpers().Hooks().Fire(HookEvent{
+			Name:        HookTagChange,
+			Changed:     []string{"tags"},
+			TagsRemoved: []string{tag.Name},
+		})
 		self.c.Helpers().Tags().RefreshTags(false)
 		self.c.Helpers().Preview().ReloadActivePreview()
 		return nil
@@ -183,8 +185,10 @@ func (self *TagsHelper) UpdatePreviewForTags() {
 		return
 	}
 
-	self.c.Helpers().Preview().UpdatePreviewCardList("Tag: "+tag.Name, func() ([]models.Note, error) {
-		return self.c.RuinCmd().Search.Search(tag.Name, self.c.Helpers().Preview().BuildSearchOptions())
+	tagName := tag.Name
+	opts := self.c.Helpers().Preview().BuildSearchOptions()
+	self.c.Helpers().Preview().UpdatePreviewCardList("Tag: "+tagName, func(cmd *commands.RuinCommand) ([]models.Note, error) {
+		return cmd.Search.Search(tagName, opts)
 	})
 }
 
@@ -193,26 +197,21 @@ func (self *TagsHelper) UpdatePreviewForTags() {
 func (self *TagsHelper) UpdatePreviewPickResults(tag *models.Tag) {
 	gui := self.c.GuiCommon()
 	tagName := tag.Name
-	_ = self.c.Helpers().Navigator().ShowHover("pickResults", "Pick: "+tagName, func() error {
-		results, err := self.c.RuinCmd().Pick.Pick([]string{tagName}, commands.PickOpts{})
+	self.c.Helpers().Navigator().ShowHoverAsync("pickResults", "Pick: "+tagName, func(cmd *commands.RuinCommand) (func() error, error) {
+		results, err := cmd.Pick.Pick([]string{tagName}, commands.PickOpts{})
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		pickCtx := gui.Contexts().Pick
-		pickCtx.Query = tagName
-		pickCtx.AnyMode = false
+		return func() error {
+			pickCtx := gui.Contexts().Pick
+			pickCtx.Query = tagName
+			pickCtx.AnyMode = false
 
-		source := context.PickResultsSource{
-			Query: tagName,
-			Requery: func(filterText string) ([]models.PickResult, error) {
-				combined := strings.TrimSpace(tagName + " " + filterText)
-				tags, _, _, _ := ParsePickQuery(combined)
-				return self.c.RuinCmd().Pick.Pick(tags, commands.PickOpts{})
-			},
-		}
+			source := self.c.Helpers().Preview().PickResultsSource(context.SourceDescriptor{Kind: context.SourcePickTag, Query: tagName})
 
-		self.c.Helpers().Preview().ShowPickResults("Pick: "+tagName, results, source)
-		return nil
+			self.c.Helpers().Preview().ShowPickResults("Pick: "+tagName, results, source)
+			return nil
+		}, nil
 	})
 }
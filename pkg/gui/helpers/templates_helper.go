@@ -0,0 +1,275 @@
+package helpers
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/donnellyk/lazyruin/pkg/commands"
+	"github.com/donnellyk/lazyruin/pkg/config"
+	"github.com/donnellyk/lazyruin/pkg/gui/context"
+	"github.com/donnellyk/lazyruin/pkg/gui/types"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TemplateData is what a note template's body sees.
+type TemplateData struct {
+	Date    string            // 2006-01-02
+	Time    string            // 15:04
+	Weekday string            // Monday
+	Title   string            // asked for when the template uses it
+	Parent  string            // title of the parent the note is filed under
+	Form    map[string]string // prompt answers, by prompt key
+}
+
+// TemplatesHelper drives "New from Template": pick a template, answer its
+// prompts, and open the capture popup pre-filled.
+type TemplatesHelper struct {
+	c   *HelperCommon
+	now func() time.Time
+}
+
+func NewTemplatesHelper(c *HelperCommon) *TemplatesHelper {
+	return &TemplatesHelper{c: c, now: time.Now}
+}
+
+// Templates returns the configured templates followed by the ones in the
+// vault's templates folder. A folder template named like a configured one
+// is skipped.
+func (self *TemplatesHelper) Templates() ([]config.NoteTemplate, error) {
+	var templates []config.NoteTemplate
+	seen := map[string]bool{}
+	if cfg := self.c.Config(); cfg != nil {
+		for _, t := range cfg.Templates {
+			templates = append(templates, t)
+			seen[t.Name] = true
+		}
+	}
+	fromDir, err := loadTemplateDir(filepath.Join(self.c.RuinCmd().VaultPath(), config.TemplatesDir))
+	for _, t := range fromDir {
+		if !seen[t.Name] {
+			templates = append(templates, t)
+		}
+	}
+	return templates, err
+}
+
+// loadTemplateDir reads `<name>.md` templates from dir. Frontmatter may set
+// `parent`, `tags` and `prompts` as in the config; the rest is the body.
+// A missing dir holds no templates.
+func loadTemplateDir(dir string) ([]config.NoteTemplate, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var templates []config.NoteTemplate
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".md" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return templates, err
+		}
+		var t config.NoteTemplate
+		if fm := extractFrontmatter(data); fm != nil {
+			if err := yaml.Unmarshal(fm, &t); err != nil {
+				return templates, fmt.Errorf("template %s: %w", e.Name(), err)
+			}
+		}
+		t.Name, t.Body = strings.TrimSuffix(e.Name(), ".md"), noteBody(data)
+		templates = append(templates, t)
+	}
+	return templates, nil
+}
+
+// Open shows a menu of the templates. The parent selected when the menu
+// opens is the one a template without its own parent files the note under.
+func (self *TemplatesHelper) Open() error {
+	gui := self.c.GuiCommon()
+	if gui.PopupActive() {
+		return nil
+	}
+	templates, err := self.Templates()
+	if err != nil {
+		gui.ShowError(err)
+		if len(templates) == 0 {
+			return nil
+		}
+	}
+	if len(templates) == 0 {
+		gui.ShowStatus("No templates — add a templates: list to config.yml or .md files to " + config.TemplatesDir + "/ in the vault")
+		return nil
+	}
+
+	parent := self.selectedParent()
+	items := make([]types.MenuItem, 0, len(templates))
+	for i, t := range templates {
+		item := types.MenuItem{
+			Label: t.Name,
+			OnRun: func() error {
+				self.start(t, parent)
+				return nil
+			},
+		}
+		if i < 9 {
+			item.Key = fmt.Sprint(i + 1)
+		}
+		items = append(items, item)
+	}
+	gui.ShowMenuDialog("New from Template", items)
+	return nil
+}
+
+// selectedParent returns the parent bookmark selected in the Parents tab,
+// or the note under the cursor in a composed parent, as for a new child.
+func (self *TemplatesHelper) selectedParent() *context.CaptureParentInfo {
+	gui := self.c.GuiCommon()
+	switch gui.CurrentContextKey() {
+	case "queries":
+		queries := gui.Contexts().Queries
+		if queries.CurrentTab != context.QueriesTabParents {
+			return nil
+		}
+		if p := queries.SelectedParent(); p != nil {
+			return &context.CaptureParentInfo{UUID: p.UUID, Title: p.Title}
+		}
+	case "compose":
+		target := self.c.Helpers().PreviewLineOps().ResolveTarget()
+		if target == nil {
+			return nil
+		}
+		note, err := self.c.RuinCmd().Search.Get(target.UUID, commands.SearchOptions{})
+		if err == nil && note != nil {
+			return &context.CaptureParentInfo{UUID: note.UUID, Title: note.Title}
+		}
+	}
+	return nil
+}
+
+// resolveParent finds a template's parent by bookmark name, then by note
+// title.
+func (self *TemplatesHelper) resolveParent(ref string) (*context.CaptureParentInfo, error) {
+	cmd := self.c.RuinCmd()
+	if parents, err := cmd.Parent.List(); err == nil {
+		for _, p := range parents {
+			if p.Name == ref {
+				return &context.CaptureParentInfo{UUID: p.UUID, Title: p.Title}, nil
+			}
+		}
+	}
+	note, err := cmd.Search.GetByTitle(ref, commands.SearchOptions{})
+	if err != nil || note == nil {
+		return nil, fmt.Errorf("template parent %q not found", ref)
+	}
+	return &context.CaptureParentInfo{UUID: note.UUID, Title: note.Title}, nil
+}
+
+// templatePrompt is one question asked before a template is filled in.
+type templatePrompt struct {
+	title, seed string
+	set         func(string)
+}
+
+// start asks for the title, when the body uses it, and then the
+// template's own prompts, and opens the capture popup.
+func (self *TemplatesHelper) start(t config.NoteTemplate, parent *context.CaptureParentInfo) {
+	gui := self.c.GuiCommon()
+	tmpl, err := parseNoteTemplate(t)
+	if err != nil {
+		gui.ShowError(err)
+		return
+	}
+	if t.Parent != "" {
+		if parent, err = self.resolveParent(t.Parent); err != nil {
+			gui.ShowError(err)
+			return
+		}
+	}
+
+	now := self.now()
+	data := &TemplateData{
+		Date:    now.Format("2006-01-02"),
+		Time:    now.Format("15:04"),
+		Weekday: now.Weekday().String(),
+		Form:    map[string]string{},
+	}
+	if parent != nil {
+		data.Parent = parent.Title
+	}
+
+	var prompts []templatePrompt
+	if strings.Contains(t.Body, ".Title") {
+		prompts = append(prompts, templatePrompt{title: "Title", set: func(v string) { data.Title = v }})
+	}
+	for _, p := range t.Prompts {
+		title := p.Title
+		if title == "" {
+			title = p.Key
+		}
+		prompts = append(prompts, templatePrompt{title: title, seed: p.InitialValue, set: func(v string) { data.Form[p.Key] = v }})
+	}
+	self.prompt(prompts, func() {
+		var body bytes.Buffer
+		if err := tmpl.Execute(&body, data); err != nil {
+			gui.ShowError(err)
+			return
+		}
+		content, cursorLine := withTagLine(body.String(), t.Tags)
+		self.c.Helpers().Capture().OpenCaptureFromTemplate(content, cursorLine, parent)
+	})
+}
+
+func (self *TemplatesHelper) prompt(prompts []templatePrompt, done func()) {
+	if len(prompts) == 0 {
+		done()
+		return
+	}
+	p := prompts[0]
+	self.c.Helpers().InputPopup().OpenInputPopup(&types.InputPopupConfig{
+		Title:  p.title,
+		Footer: " Enter: Continue | Esc: Cancel ",
+		Seed:   p.seed,
+		OnAccept: func(raw string, _ *types.CompletionItem) error {
+			p.set(raw)
+			self.prompt(prompts[1:], done)
+			return nil
+		},
+	})
+}
+
+// parseNoteTemplate parses a template's body.
+func parseNoteTemplate(t config.NoteTemplate) (*template.Template, error) {
+	tmpl, err := template.New(t.Name).Option("missingkey=error").Parse(t.Body)
+	if err != nil {
+		return nil, fmt.Errorf("template %q: %w", t.Name, err)
+	}
+	return tmpl, nil
+}
+
+// withTagLine adds tags to body as a line of global tags. The cursor goes
+// on the blank line left between the body and the tags; cursorLine 0
+// keeps it at the end, below the tags of an empty body.
+func withTagLine(body string, tags []string) (content string, cursorLine int) {
+	if len(tags) == 0 {
+		return body, 0
+	}
+	words := make([]string, len(tags))
+	for i, tag := range tags {
+		words[i] = "#" + strings.TrimPrefix(tag, "#")
+	}
+	line := strings.Join(words, " ")
+	body = strings.TrimRight(body, "\n")
+	if body == "" {
+		return line + "\n\n", 0
+	}
+	return body + "\n\n" + line, strings.Count(body, "\n") + 1
+}
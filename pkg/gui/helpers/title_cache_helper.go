@@ -1,6 +1,7 @@
 package helpers
 
 import (
+	"slices"
 	"sync"
 
 	"github.com/donnellyk/lazyruin/pkg/commands"
@@ -10,21 +11,35 @@ import (
 // TitleCacheHelper caches note UUID → title mappings so that parent badges
 // can be resolved to titles even when the parent note is not a bookmark and
 // not currently in the Notes panel (e.g., an older parent of a "today" note).
+// It also remembers each loaded note's parent UUID so the preview can show
+// a note's full ancestor chain.
 //
-// The cache is populated two ways:
+// The cache is populated three ways:
 //   - PutNotes, called whenever a batch of notes is loaded from any source.
 //   - ResolveUnknownParents, which fetches parents that aren't yet known.
+//   - AncestorResolver, which fetches a single note's chain up to the root.
 type TitleCacheHelper struct {
 	c *HelperCommon
 
-	mu    sync.RWMutex
-	cache map[string]string
+	mu      sync.RWMutex
+	cache   map[string]string
+	parents map[string]string // UUID → parent UUID ("" for a root)
 }
 
 func NewTitleCacheHelper(c *HelperCommon) *TitleCacheHelper {
-	return &TitleCacheHelper{c: c, cache: make(map[string]string)}
+	return &TitleCacheHelper{c: c, cache: make(map[string]string), parents: make(map[string]string)}
 }
 
+// Ancestor is one step of a note's parent chain.
+type Ancestor struct {
+	UUID  string
+	Title string
+}
+
+// maxAncestorDepth bounds the chain walk so a corrupt vault can't stall a
+// load, cycle or not.
+const maxAncestorDepth = 64
+
 // Get returns the cached title for a UUID.
 func (h *TitleCacheHelper) Get(uuid string) (string, bool) {
 	h.mu.RLock()
@@ -43,7 +58,16 @@ func (h *TitleCacheHelper) Put(uuid, title string) {
 	h.cache[uuid] = title
 }
 
-// PutNotes records titles from any notes with a non-empty UUID and Title.
+// Clear drops every cached title.
+func (h *TitleCacheHelper) Clear() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.cache = make(map[string]string)
+	h.parents = make(map[string]string)
+}
+
+// PutNotes records titles from any notes with a non-empty UUID and Title,
+// and the parent of every note with a UUID.
 func (h *TitleCacheHelper) PutNotes(notes []models.Note) {
 	if len(notes) == 0 {
 		return
@@ -51,11 +75,39 @@ func (h *TitleCacheHelper) PutNotes(notes []models.Note) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 	for _, n := range notes {
-		if n.UUID == "" || n.Title == "" {
+		if n.UUID == "" {
 			continue
 		}
-		h.cache[n.UUID] = n.Title
+		h.parents[n.UUID] = n.Parent
+		if n.Title != "" {
+			h.cache[n.UUID] = n.Title
+		}
+	}
+}
+
+// Ancestors returns note's cached parent chain, root first. The walk stops
+// at the first ancestor whose title or parent isn't cached, and at any
+// UUID seen twice so a parent cycle can't loop.
+func (h *TitleCacheHelper) Ancestors(note models.Note) []Ancestor {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	var chain []Ancestor
+	seen := map[string]bool{note.UUID: true}
+	for p := note.Parent; p != "" && !seen[p] && len(chain) < maxAncestorDepth; {
+		seen[p] = true
+		title, ok := h.cache[p]
+		if !ok {
+			break
+		}
+		chain = append(chain, Ancestor{UUID: p, Title: title})
+		next, ok := h.parents[p]
+		if !ok {
+			break
+		}
+		p = next
 	}
+	slices.Reverse(chain)
+	return chain
 }
 
 // ResolveUnknownParents fetches titles for parent UUIDs referenced by the
@@ -63,34 +115,63 @@ func (h *TitleCacheHelper) PutNotes(notes []models.Note) {
 // unknown parent is fetched once via `ruin get --uuid`; failures are ignored
 // so the render can still fall back to a truncated UUID.
 func (h *TitleCacheHelper) ResolveUnknownParents(notes []models.Note) {
-	if len(notes) == 0 {
-		return
-	}
+	h.ParentResolver()(h.c.RuinCmd(), notes)
+}
 
-	known := make(map[string]bool)
+// ParentResolver is ResolveUnknownParents split for background loads: the
+// bookmark lookup reads GUI state, so it happens here on the main
+// goroutine, and the returned func can then run on any goroutine against
+// a task-bound RuinCommand.
+func (h *TitleCacheHelper) ParentResolver() func(cmd *commands.RuinCommand, notes []models.Note) {
+	bookmarked := make(map[string]bool)
 	for _, bm := range h.c.GuiCommon().Contexts().Queries.Parents {
 		if bm.UUID != "" {
-			known[bm.UUID] = true
+			bookmarked[bm.UUID] = true
 		}
 	}
 
-	h.mu.RLock()
-	for uuid := range h.cache {
-		known[uuid] = true
+	return func(cmd *commands.RuinCommand, notes []models.Note) {
+		seen := make(map[string]bool)
+		for _, n := range notes {
+			p := n.Parent
+			if p == "" || bookmarked[p] || seen[p] {
+				continue
+			}
+			seen[p] = true
+			if _, ok := h.Get(p); ok {
+				continue
+			}
+			parent, err := cmd.Search.Get(p, commands.SearchOptions{})
+			if err != nil || parent == nil || parent.Title == "" {
+				continue
+			}
+			h.Put(parent.UUID, parent.Title)
+		}
 	}
-	h.mu.RUnlock()
+}
 
-	seen := make(map[string]bool)
-	for _, n := range notes {
-		p := n.Parent
-		if p == "" || known[p] || seen[p] {
-			continue
-		}
-		seen[p] = true
-		parent, err := h.c.RuinCmd().Search.Get(p, commands.SearchOptions{})
-		if err != nil || parent == nil || parent.Title == "" {
-			continue
+// AncestorResolver returns a func that fetches every ancestor of a note
+// missing from the cache, walking Parent up to the root. Like
+// ParentResolver it is safe to run on a background goroutine; fetch
+// failures end the walk and the breadcrumb shows what was resolved.
+func (h *TitleCacheHelper) AncestorResolver() func(cmd *commands.RuinCommand, note models.Note) {
+	return func(cmd *commands.RuinCommand, note models.Note) {
+		seen := map[string]bool{note.UUID: true}
+		for p, depth := note.Parent, 0; p != "" && !seen[p] && depth < maxAncestorDepth; depth++ {
+			seen[p] = true
+			h.mu.RLock()
+			_, hasTitle := h.cache[p]
+			next, hasParent := h.parents[p]
+			h.mu.RUnlock()
+			if !hasTitle || !hasParent {
+				parent, err := cmd.Search.Get(p, commands.SearchOptions{})
+				if err != nil || parent == nil {
+					return
+				}
+				h.PutNotes([]models.Note{*parent})
+				next = parent.Parent
+			}
+			p = next
 		}
-		h.Put(parent.UUID, parent.Title)
 	}
 }
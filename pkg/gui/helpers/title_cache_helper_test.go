@@ -0,0 +1,37 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/donnellyk/lazyruin/pkg/models"
+)
+
+func TestTitleCache_AncestorsRootFirst(t *testing.T) {
+	h := NewTitleCacheHelper(nil)
+	h.PutNotes([]models.Note{
+		{UUID: "root", Title: "Root"},
+		{UUID: "mid", Title: "Mid", Parent: "root"},
+	})
+
+	got := h.Ancestors(models.Note{UUID: "leaf", Parent: "mid"})
+	if len(got) != 2 || got[0].Title != "Root" || got[1].Title != "Mid" {
+		t.Errorf("Ancestors = %+v, want Root, Mid", got)
+	}
+}
+
+func TestTitleCache_AncestorsStopsAtUnknownAndCycles(t *testing.T) {
+	h := NewTitleCacheHelper(nil)
+	// Mid's own parent was never loaded, so the chain ends at Mid.
+	h.Put("mid", "Mid")
+	if got := h.Ancestors(models.Note{UUID: "leaf", Parent: "mid"}); len(got) != 1 || got[0].UUID != "mid" {
+		t.Errorf("Ancestors = %+v, want just Mid", got)
+	}
+
+	h.PutNotes([]models.Note{
+		{UUID: "a", Title: "A", Parent: "b"},
+		{UUID: "b", Title: "B", Parent: "a"},
+	})
+	if got := h.Ancestors(models.Note{UUID: "a", Parent: "b"}); len(got) != 1 || got[0].UUID != "b" {
+		t.Errorf("Ancestors = %+v, want the cycle cut after B", got)
+	}
+}
@@ -0,0 +1,373 @@
+package helpers
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/donnellyk/lazyruin/pkg/commands"
+	"github.com/donnellyk/lazyruin/pkg/config"
+	"github.com/donnellyk/lazyruin/pkg/donelog"
+	"github.com/donnellyk/lazyruin/pkg/gui/context"
+	"github.com/donnellyk/lazyruin/pkg/models"
+)
+
+// todoCheckboxRe matches a todo's list bullet and checkbox; doneCheckboxRe
+// only a checked one. doneTagRe matches an inline #done tag.
+var (
+	todoCheckboxRe = regexp.MustCompile(`^\s*[-*+]\s+\[[ xX]\]\s*`)
+	doneCheckboxRe = regexp.MustCompile(`^\s*[-*+]\s+\[[xX]\]`)
+	doneTagRe      = regexp.MustCompile(`(?i)(^|\s)#done\b`)
+)
+
+// recentDoneWindow is how far back "done in the last 7 days" looks.
+const recentDoneWindow = 7 * 24 * time.Hour
+
+// TodosHelper loads the vault-wide todo dashboard and applies its grouping
+// and filters.
+type TodosHelper struct {
+	c   *HelperCommon
+	now func() time.Time
+}
+
+func NewTodosHelper(c *HelperCommon) *TodosHelper {
+	return &TodosHelper{c: c, now: time.Now}
+}
+
+func (self *TodosHelper) ctx() *context.TodosContext {
+	return self.c.GuiCommon().Contexts().Todos
+}
+
+// options returns the persisted dashboard options, or zero options when
+// there is no config.
+func (self *TodosHelper) options() *config.TodosViewOptions {
+	if cfg := self.c.Config(); cfg != nil {
+		return &cfg.ViewOptions.Todos
+	}
+	return &config.TodosViewOptions{}
+}
+
+// Open shows the dashboard.
+func (self *TodosHelper) Open() error {
+	gui := self.c.GuiCommon()
+	if gui.PopupActive() {
+		return nil
+	}
+	ctx := self.ctx()
+	ctx.GroupBy = context.TodoGroupByNote
+	for _, g := range context.TodoGroupByOrder {
+		if string(g) == self.options().GroupBy {
+			ctx.GroupBy = g
+		}
+	}
+	ctx.SelectedIdx = 0
+	if err := self.load(""); err != nil {
+		gui.ShowError(err)
+		return nil
+	}
+	gui.PushContextByKey("todos")
+	return nil
+}
+
+// Reload re-runs the pick after a todo changed, keeping the cursor on the
+// same item.
+func (self *TodosHelper) Reload() {
+	preserve := ""
+	if item := self.ctx().Selected(); item != nil {
+		preserve = item.ID()
+	}
+	if err := self.load(preserve); err != nil {
+		self.c.GuiCommon().ShowError(err)
+	}
+}
+
+// Close hides the dashboard.
+func (self *TodosHelper) Close() {
+	self.c.GuiCommon().PopContext()
+}
+
+// CycleGroupBy switches to the next grouping and persists it.
+func (self *TodosHelper) CycleGroupBy() {
+	ctx := self.ctx()
+	order := context.TodoGroupByOrder
+	for i, g := range order {
+		if g == ctx.GroupBy {
+			ctx.GroupBy = order[(i+1)%len(order)]
+			break
+		}
+	}
+	self.options().GroupBy = string(ctx.GroupBy)
+	self.save()
+	self.Reload()
+}
+
+// ToggleHasDate toggles listing only dated todos.
+func (self *TodosHelper) ToggleHasDate() {
+	opts := self.options()
+	opts.HasDate = !opts.HasDate
+	if opts.HasDate {
+		opts.NoDate = false
+	}
+	self.save()
+	self.Reload()
+}
+
+// ToggleNoDate toggles listing only undated todos.
+func (self *TodosHelper) ToggleNoDate() {
+	opts := self.options()
+	opts.NoDate = !opts.NoDate
+	if opts.NoDate {
+		opts.HasDate = false
+	}
+	self.save()
+	self.Reload()
+}
+
+// ToggleRecentDone toggles also listing todos done in the last 7 days.
+func (self *TodosHelper) ToggleRecentDone() {
+	opts := self.options()
+	opts.RecentDone = !opts.RecentDone
+	self.save()
+	self.Reload()
+}
+
+func (self *TodosHelper) save() {
+	if cfg := self.c.Config(); cfg != nil {
+		if err := cfg.Save(); err != nil {
+			self.c.GuiCommon().ShowError(err)
+		}
+	}
+}
+
+// OpenSelected closes the dashboard and opens the selected todo's note
+// with the cursor on its line.
+func (self *TodosHelper) OpenSelected() error {
+	item := self.ctx().Selected()
+	if item == nil {
+		return nil
+	}
+	self.Close()
+	return self.c.Helpers().PreviewNav().OpenNoteAtLine(item.UUID, item.Line)
+}
+
+// Target returns the selected todo as a line-op target.
+func (self *TodosHelper) Target() *lineTarget {
+	item := self.ctx().Selected()
+	if item == nil {
+		return nil
+	}
+	return &lineTarget{UUID: item.UUID, LineNum: item.Line, Path: item.Path}
+}
+
+func (self *TodosHelper) load(preserveID string) error {
+	opts := self.options()
+	results, err := self.c.RuinCmd().Pick.Pick(nil, commands.PickOpts{Todo: true, All: opts.RecentDone})
+	if err != nil {
+		return err
+	}
+	var done *donelog.Store
+	if opts.RecentDone {
+		done = donelog.NewStoreForVault(self.c.RuinCmd().VaultPath())
+		if err := done.Load(); err != nil {
+			return err
+		}
+	}
+	items := self.todoItems(results, *opts, done)
+	ctx := self.ctx()
+	var parents map[string]string
+	if ctx.GroupBy == context.TodoGroupByParent {
+		parents = self.parentTitles(items)
+	}
+	ctx.SetRows(groupTodos(items, ctx.GroupBy, parents, self.now().Format("2006-01-02")), preserveID)
+	return nil
+}
+
+// todoItems flattens pick results into items and applies the filters. A
+// done todo is listed only when doneLog records it as checked off in the
+// last 7 days; without a log, done todos are left out.
+func (self *TodosHelper) todoItems(results []models.PickResult, opts config.TodosViewOptions, doneLog *donelog.Store) []*context.TodoItem {
+	cmd := self.c.RuinCmd()
+	cutoff := self.now().Add(-recentDoneWindow)
+	var items []*context.TodoItem
+	for _, r := range results {
+		path := vaultPath(cmd, r.File)
+		for _, m := range r.Matches {
+			if !todoCheckboxRe.MatchString(m.Content) {
+				continue
+			}
+			done := m.Done || models.HasDoneTag(m.Content) || doneCheckboxRe.MatchString(m.Content)
+			if done {
+				if doneLog == nil {
+					continue
+				}
+				if at, ok := doneLog.DoneAt(r.UUID, todoText(m.Content)); !ok || at.Before(cutoff) {
+					continue
+				}
+			}
+			date := strings.TrimPrefix(inlineDateRe.FindString(m.Content), "@")
+			if (opts.HasDate && date == "") || (opts.NoDate && date != "") {
+				continue
+			}
+			items = append(items, &context.TodoItem{
+				UUID:    r.UUID,
+				Title:   r.Title,
+				Path:    path,
+				Line:    m.Line,
+				Content: strings.TrimSpace(todoCheckboxRe.ReplaceAllString(m.Content, "")),
+				Tags:    m.Tags,
+				Done:    done,
+				Date:    date,
+			})
+		}
+	}
+	return items
+}
+
+// RecordDone logs that the todo on line was checked off (done) or reopened
+// in lazyruin, for the done filter. Lines that aren't todos are ignored.
+// Like the search history, the log is best-effort: a failure only loses
+// the entry.
+func (self *TodosHelper) RecordDone(uuid, line string, done bool) {
+	if !todoCheckboxRe.MatchString(line) {
+		return
+	}
+	store := donelog.NewStoreForVault(self.c.RuinCmd().VaultPath())
+	if err := store.Load(); err != nil {
+		return
+	}
+	now := self.now()
+	if done {
+		store.Mark(uuid, todoText(line), now)
+	} else {
+		store.Unmark(uuid, todoText(line))
+	}
+	store.Prune(now.Add(-recentDoneWindow))
+	_ = store.Save()
+}
+
+// todoText identifies a todo within its note: its text without the
+// checkbox or a #done tag, so checking it off either way keeps the same
+// identity.
+func todoText(line string) string {
+	line = doneTagRe.ReplaceAllString(todoCheckboxRe.ReplaceAllString(line, ""), " ")
+	return strings.Join(strings.Fields(line), " ")
+}
+
+// parentTitles maps each item's note UUID to its parent's title.
+func (self *TodosHelper) parentTitles(items []*context.TodoItem) map[string]string {
+	cmd := self.c.RuinCmd()
+	cache := self.c.Helpers().TitleCache()
+	titles := map[string]string{}
+	for _, item := range items {
+		if _, ok := titles[item.UUID]; ok {
+			continue
+		}
+		titles[item.UUID] = ""
+		note, err := cmd.Search.Get(item.UUID, commands.SearchOptions{})
+		if err != nil || note == nil || note.Parent == "" {
+			continue
+		}
+		title, ok := cache.Get(note.Parent)
+		if !ok {
+			if parent, err := cmd.Search.Get(note.Parent, commands.SearchOptions{}); err == nil && parent != nil {
+				title = parent.Title
+				cache.Put(parent.UUID, title)
+			}
+		}
+		titles[item.UUID] = title
+	}
+	return titles
+}
+
+// groupTodos lays items out as rows: open todos dated before today under
+// "Overdue" first, then the rest grouped by groupBy. Groups are sorted by
+// name, with the group of items lacking the grouped-by field last; items
+// keep the vault's order within a group.
+func groupTodos(items []*context.TodoItem, groupBy context.TodoGroupBy, parents map[string]string, today string) []context.TodoRow {
+	var rows []context.TodoRow
+	var overdue, rest []*context.TodoItem
+	for _, item := range items {
+		if !item.Done && item.Date != "" && item.Date < today {
+			overdue = append(overdue, item)
+		} else {
+			rest = append(rest, item)
+		}
+	}
+	if len(overdue) > 0 {
+		sort.SliceStable(overdue, func(i, j int) bool { return overdue[i].Date < overdue[j].Date })
+		rows = append(rows, context.TodoRow{Header: "Overdue"})
+		for _, item := range overdue {
+			rows = append(rows, context.TodoRow{Item: item})
+		}
+	}
+
+	key, none := todoGroupKey(groupBy, parents, today)
+	groups := map[string][]*context.TodoItem{}
+	var names []string
+	for _, item := range rest {
+		name := key(item)
+		if _, ok := groups[name]; !ok {
+			names = append(names, name)
+		}
+		groups[name] = append(groups[name], item)
+	}
+	sort.SliceStable(names, func(i, j int) bool {
+		if (names[i] == none) != (names[j] == none) {
+			return names[j] == none
+		}
+		// "Today" sorts with its date so it stays ahead of later days.
+		a, b := names[i], names[j]
+		if groupBy == context.TodoGroupByDate {
+			a, b = strings.Replace(a, "Today", today, 1), strings.Replace(b, "Today", today, 1)
+		}
+		return strings.ToLower(a) < strings.ToLower(b)
+	})
+	for _, name := range names {
+		rows = append(rows, context.TodoRow{Header: name})
+		for _, item := range groups[name] {
+			rows = append(rows, context.TodoRow{Item: item})
+		}
+	}
+	return rows
+}
+
+// todoGroupKey returns the function naming an item's group, and the name
+// of the group for items without the grouped-by field.
+func todoGroupKey(groupBy context.TodoGroupBy, parents map[string]string, today string) (func(*context.TodoItem) string, string) {
+	switch groupBy {
+	case context.TodoGroupByTag:
+		return func(item *context.TodoItem) string {
+			for _, tag := range item.Tags {
+				if !strings.EqualFold(strings.TrimPrefix(tag, "#"), "done") {
+					return "#" + strings.TrimPrefix(tag, "#")
+				}
+			}
+			return "No tag"
+		}, "No tag"
+	case context.TodoGroupByParent:
+		return func(item *context.TodoItem) string {
+			if title := parents[item.UUID]; title != "" {
+				return title
+			}
+			return "No parent"
+		}, "No parent"
+	case context.TodoGroupByDate:
+		return func(item *context.TodoItem) string {
+			switch item.Date {
+			case "":
+				return "No date"
+			case today:
+				return "Today"
+			}
+			return item.Date
+		}, "No date"
+	default:
+		return func(item *context.TodoItem) string {
+			if item.Title == "" {
+				return "Untitled"
+			}
+			return item.Title
+		}, ""
+	}
+}
@@ -0,0 +1,191 @@
+package helpers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/donnellyk/lazyruin/pkg/trash"
+)
+
+// TrashHelper moves deleted notes into the vault's trash and restores or
+// purges them from the trash browser.
+type TrashHelper struct {
+	c     *HelperCommon
+	store *trash.Store
+}
+
+func NewTrashHelper(c *HelperCommon) *TrashHelper {
+	store := trash.NewStoreForVault(c.RuinCmd().VaultPath())
+	// Non-fatal: the browser reloads and surfaces the error when opened.
+	_ = store.Load()
+	return &TrashHelper{c: c, store: store}
+}
+
+// LoadVault points the trash at another vault's store.
+func (self *TrashHelper) LoadVault(vaultPath string) {
+	self.store = trash.NewStoreForVault(vaultPath)
+	_ = self.store.Load()
+}
+
+// TrashNote copies a note's file into the trash, then deletes it from the
+// vault. The delete is journaled so undo restores it from the trash. If
+// the copy fails the note is left alone.
+func (self *TrashHelper) TrashNote(uuid, path, title string) error {
+	item, err := self.trash(uuid, path, title)
+	if err != nil {
+		return err
+	}
+	self.c.Helpers().Undo().Record("Delete "+title,
+		func() error { return self.restore(item) },
+		func() error {
+			var err error
+			item, err = self.trash(uuid, path, title)
+			return err
+		})
+	self.expire()
+	return nil
+}
+
+// RecordCreate journals a note that was just created. Undo moves it to
+// the trash and redo restores it from there, so it comes back with the
+// same UUID and later journal entries that refer to it still apply.
+func (self *TrashHelper) RecordCreate(uuid, path, title string) {
+	var item trash.Item
+	self.c.Helpers().Undo().Record("Create "+title,
+		func() error {
+			var err error
+			item, err = self.trash(uuid, path, title)
+			return err
+		},
+		func() error { return self.restore(item) })
+}
+
+func (self *TrashHelper) trash(uuid, path, title string) (trash.Item, error) {
+	abs := self.absPath(path)
+	data, err := os.ReadFile(abs)
+	if err != nil {
+		return trash.Item{}, fmt.Errorf("copy to trash: %w", err)
+	}
+	item, err := self.store.Add(uuid, title, self.vaultRelative(abs), data)
+	if err != nil {
+		return trash.Item{}, fmt.Errorf("copy to trash: %w", err)
+	}
+	if err := self.c.RuinCmd().Note.Delete(uuid); err != nil {
+		_ = self.store.Remove(item.ID)
+		return trash.Item{}, err
+	}
+	self.c.Helpers().Hooks().Fire(HookEvent{Name: HookDelete, Path: abs, UUID: uuid, Title: title})
+	return item, nil
+}
+
+// restore writes a trashed note back to its original path, drops it from
+// the trash, and reindexes it. A failed reindex is reported but doesn't
+// fail the restore; the file is back and the vault watcher reindexes it.
+func (self *TrashHelper) restore(item trash.Item) error {
+	data, err := self.store.Read(item.ID)
+	if err != nil {
+		return err
+	}
+	dest := self.absPath(item.Path)
+	if _, err := os.Stat(dest); err == nil {
+		return fmt.Errorf("a file already exists at %s", item.Path)
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return err
+	}
+	if err := writeFileAtomic(dest, data); err != nil {
+		return err
+	}
+	if err := self.store.Remove(item.ID); err != nil {
+		self.c.GuiCommon().ShowError(err)
+	}
+	if err := self.c.RuinCmd().Doctor(dest); err != nil {
+		self.c.GuiCommon().ShowError(fmt.Errorf("restored but reindex failed (%w); run `ruin doctor` to refresh", err))
+	}
+	return nil
+}
+
+// expire purges items past the configured retention period.
+func (self *TrashHelper) expire() {
+	cfg := self.c.Config()
+	if cfg == nil || cfg.TrashRetentionDays <= 0 {
+		return
+	}
+	retention := time.Duration(cfg.TrashRetentionDays) * 24 * time.Hour
+	if _, err := self.store.PurgeOlderThan(retention, time.Now()); err != nil {
+		self.c.GuiCommon().ShowError(err)
+	}
+}
+
+func (self *TrashHelper) absPath(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(self.c.RuinCmd().VaultPath(), path)
+}
+
+// vaultRelative returns abs relative to the vault when it lies inside it,
+// so restores still work if the vault directory is moved.
+func (self *TrashHelper) vaultRelative(abs string) string {
+	rel, err := filepath.Rel(self.c.RuinCmd().VaultPath(), abs)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return abs
+	}
+	return rel
+}
+
+// OpenBrowser opens the trash browser popup.
+func (self *TrashHelper) OpenBrowser() error {
+	gui := self.c.GuiCommon()
+	if gui.PopupActive() {
+		return nil
+	}
+	if err := self.store.Load(); err != nil {
+		gui.ShowError(err)
+		return nil
+	}
+	self.expire()
+	ctx := gui.Contexts().TrashBrowser
+	ctx.Items = self.store.Items()
+	ctx.SelectedIdx = 0
+	gui.PushContextByKey("trashBrowser")
+	return nil
+}
+
+// RestoreItem restores a trashed note to the vault and refreshes the lists.
+func (self *TrashHelper) RestoreItem(item trash.Item) error {
+	gui := self.c.GuiCommon()
+	if err := self.restore(item); err != nil {
+		gui.ShowError(err)
+		return nil
+	}
+	self.RefreshBrowser()
+	self.c.Helpers().Notes().FetchNotesForCurrentTab(false)
+	self.c.Helpers().Tags().RefreshTags(false)
+	gui.ShowStatus("Restored " + item.Title)
+	return nil
+}
+
+// PurgeItem permanently removes an item from the trash.
+func (self *TrashHelper) PurgeItem(id string) {
+	if err := self.store.Remove(id); err != nil {
+		self.c.GuiCommon().ShowError(err)
+	}
+	self.RefreshBrowser()
+}
+
+func (self *TrashHelper) RefreshBrowser() {
+	gui := self.c.GuiCommon()
+	if err := self.store.Load(); err != nil {
+		gui.ShowError(err)
+		return
+	}
+	ctx := gui.Contexts().TrashBrowser
+	ctx.Items = self.store.Items()
+	if ctx.SelectedIdx >= len(ctx.Items) {
+		ctx.SelectedIdx = max(0, len(ctx.Items)-1)
+	}
+}
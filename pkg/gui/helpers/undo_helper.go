@@ -0,0 +1,352 @@
+package helpers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/donnellyk/lazyruin/pkg/gui/types"
+)
+
+// maxUndoEntries caps the journal; the oldest entries fall off first.
+const maxUndoEntries = 100
+
+// UndoEntry is one journaled note mutation. Undo reverses it and Redo
+// re-applies it after an undo.
+type UndoEntry struct {
+	Label string
+	At    time.Time
+	Undo  func() error
+	Redo  func() error
+}
+
+// UndoHelper keeps the session's undo journal. Reversible operations
+// record their inverse NoteCommand call (AddTag ↔ RemoveTag, SetParent ↔
+// the previous parent); destructive or position-changing ones snapshot
+// the affected files first and undo by writing them back.
+type UndoHelper struct {
+	c       *HelperCommon
+	entries []UndoEntry
+	pos     int // entries[:pos] are applied; entries[pos:] can be redone
+
+	batch *[]UndoEntry // non-nil while Batch is collecting entries
+}
+
+// NewUndoHelper creates a new UndoHelper.
+func NewUndoHelper(c *HelperCommon) *UndoHelper {
+	return &UndoHelper{c: c}
+}
+
+// Record appends a mutation to the journal. Any undone entries past the
+// current position are discarded, as in an editor. Every journaled change
+// passes through here, so it is also where auto-commit happens.
+func (self *UndoHelper) Record(label string, undo, redo func() error) {
+	if self.batch != nil {
+		*self.batch = append(*self.batch, UndoEntry{Label: label, Undo: undo, Redo: redo})
+		return
+	}
+	self.entries = append(self.entries[:self.pos], UndoEntry{
+		Label: label,
+		At:    time.Now(),
+		Undo:  undo,
+		Redo:  redo,
+	})
+	if len(self.entries) > maxUndoEntries {
+		self.entries = self.entries[len(self.entries)-maxUndoEntries:]
+	}
+	self.pos = len(self.entries)
+	self.autoCommit(label)
+}
+
+// autoCommit hands a journaled change to git auto-commit. The journal
+// also runs standalone in tests, without the other helpers.
+func (self *UndoHelper) autoCommit(label string) {
+	if h := self.c.Helpers(); h != nil {
+		h.Git().AutoCommit(label)
+	}
+}
+
+// Batch runs fn and journals everything it records as one entry, so a
+// bulk action over many notes is undone in a single step. The grouped
+// entries are undone in reverse order and redone in order. A batch that
+// records only one entry keeps that entry's own label.
+func (self *UndoHelper) Batch(label string, fn func()) {
+	var collected []UndoEntry
+	self.batch = &collected
+	fn()
+	self.batch = nil
+
+	switch len(collected) {
+	case 0:
+		return
+	case 1:
+		self.Record(collected[0].Label, collected[0].Undo, collected[0].Redo)
+		return
+	}
+	self.Record(label,
+		func() error {
+			for i := len(collected) - 1; i >= 0; i-- {
+				if err := collected[i].Undo(); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		func() error {
+			for _, e := range collected {
+				if err := e.Redo(); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+}
+
+// Clear empties the journal. Entries close over paths in the vault they
+// were recorded against, so they can't be replayed after a switch.
+func (self *UndoHelper) Clear() {
+	self.entries = nil
+	self.pos = 0
+}
+
+// Entries returns the journal, oldest first.
+func (self *UndoHelper) Entries() []UndoEntry {
+	return self.entries
+}
+
+// Position returns how many entries are currently applied.
+func (self *UndoHelper) Position() int {
+	return self.pos
+}
+
+// Undo reverses the most recent applied entry.
+func (self *UndoHelper) Undo() error {
+	if self.pos == 0 {
+		self.c.GuiCommon().ShowStatus("Nothing to undo")
+		return nil
+	}
+	entry := self.entries[self.pos-1]
+	if err := entry.Undo(); err != nil {
+		self.c.GuiCommon().ShowError(fmt.Errorf("undo %s: %w", entry.Label, err))
+		return nil
+	}
+	self.pos--
+	self.refresh()
+	self.autoCommit("Undo " + entry.Label)
+	self.c.GuiCommon().ShowStatus("Undid: " + entry.Label)
+	return nil
+}
+
+// Redo re-applies the most recently undone entry.
+func (self *UndoHelper) Redo() error {
+	if self.pos == len(self.entries) {
+		self.c.GuiCommon().ShowStatus("Nothing to redo")
+		return nil
+	}
+	entry := self.entries[self.pos]
+	if err := entry.Redo(); err != nil {
+		self.c.GuiCommon().ShowError(fmt.Errorf("redo %s: %w", entry.Label, err))
+		return nil
+	}
+	self.pos++
+	self.refresh()
+	self.autoCommit("Redo " + entry.Label)
+	self.c.GuiCommon().ShowStatus("Redid: " + entry.Label)
+	return nil
+}
+
+// ShowHistory lists recent actions, newest first. Choosing an entry
+// undoes or redoes everything between it and the current position, so
+// the vault ends up as it was right after that action.
+func (self *UndoHelper) ShowHistory() error {
+	if len(self.entries) == 0 {
+		self.c.GuiCommon().ShowStatus("No actions to undo")
+		return nil
+	}
+	items := make([]types.MenuItem, 0, len(self.entries))
+	for i := len(self.entries) - 1; i >= 0; i-- {
+		entry := self.entries[i]
+		label := entry.At.Format("15:04") + "  " + entry.Label
+		if i >= self.pos {
+			label += " (undone)"
+		}
+		target := i + 1
+		items = append(items, types.MenuItem{
+			Label: label,
+			OnRun: func() error { return self.jumpTo(target) },
+		})
+	}
+	self.c.GuiCommon().ShowMenuDialog("Undo History", items)
+	return nil
+}
+
+// jumpTo steps the journal until exactly target entries are applied,
+// stopping at the first failure.
+func (self *UndoHelper) jumpTo(target int) error {
+	for self.pos > target {
+		entry := self.entries[self.pos-1]
+		if err := entry.Undo(); err != nil {
+			self.refresh()
+			self.c.GuiCommon().ShowError(fmt.Errorf("undo %s: %w", entry.Label, err))
+			return nil
+		}
+		self.pos--
+	}
+	for self.pos < target {
+		entry := self.entries[self.pos]
+		if err := entry.Redo(); err != nil {
+			self.refresh()
+			self.c.GuiCommon().ShowError(fmt.Errorf("redo %s: %w", entry.Label, err))
+			return nil
+		}
+		self.pos++
+	}
+	self.refresh()
+	return nil
+}
+
+// refresh reloads the panels an undo or redo may have changed.
+func (self *UndoHelper) refresh() {
+	h := self.c.Helpers()
+	h.Preview().ReloadActivePreview()
+	h.Notes().FetchNotesForCurrentTab(false)
+	h.Tags().RefreshTags(false)
+	h.Queries().RefreshParents(false)
+}
+
+// fileSnapshot is the full content of a note file before a mutation.
+type fileSnapshot struct {
+	path string
+	data []byte
+}
+
+// RecordSnapshot snapshots paths, runs mutate, and journals it with an
+// undo that writes the snapshots back. Redo runs mutate again, which
+// lands on the same result because it starts from the restored files.
+// If a file can't be read, mutate still runs but isn't journaled.
+func (self *UndoHelper) RecordSnapshot(label string, paths []string, mutate func() error) error {
+	snaps, snapErr := self.snapshot(paths)
+	if err := mutate(); err != nil {
+		return err
+	}
+	if snapErr == nil {
+		self.Record(label, func() error { return self.restore(snaps) }, mutate)
+	}
+	return nil
+}
+
+func (self *UndoHelper) snapshot(paths []string) ([]fileSnapshot, error) {
+	vault := self.c.RuinCmd().VaultPath()
+	snaps := make([]fileSnapshot, 0, len(paths))
+	for _, p := range paths {
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(vault, p)
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return nil, err
+		}
+		snaps = append(snaps, fileSnapshot{path: p, data: data})
+	}
+	return snaps, nil
+}
+
+// restore writes each snapshot back (recreating deleted files) and
+// reindexes it. A failed reindex is left for the vault watcher to catch.
+func (self *UndoHelper) restore(snaps []fileSnapshot) error {
+	for _, s := range snaps {
+		if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+			return err
+		}
+		if err := writeFileAtomic(s.path, s.data); err != nil {
+			return err
+		}
+		_ = self.c.RuinCmd().Doctor(s.path)
+	}
+	return nil
+}
+
+// RecordAddTag journals a global tag added to a note.
+func (self *UndoHelper) RecordAddTag(uuid, tag string) {
+	note := self.c.RuinCmd().Note
+	self.Record("Add "+tag,
+		func() error { return note.RemoveTag(uuid, tag) },
+		func() error { return note.AddTag(uuid, tag) })
+}
+
+// RecordRemoveTag journals a global tag removed from a note.
+func (self *UndoHelper) RecordRemoveTag(uuid, tag string) {
+	note := self.c.RuinCmd().Note
+	self.Record("Remove "+tag,
+		func() error { return note.AddTag(uuid, tag) },
+		func() error { return note.RemoveTag(uuid, tag) })
+}
+
+// RecordLineTag journals an inline tag added to (or removed from) a
+// content line. Tag edits don't move lines, so the inverse targets the
+// same line number.
+func (self *UndoHelper) RecordLineTag(uuid, tag string, line int, added bool) {
+	note := self.c.RuinCmd().Note
+	add := func() error { return note.AddTagToLine(uuid, tag, line) }
+	remove := func() error { return note.RemoveTagFromLine(uuid, tag, line) }
+	if added {
+		self.Record(fmt.Sprintf("Add %s to line %d", tag, line), remove, add)
+	} else {
+		self.Record(fmt.Sprintf("Remove %s from line %d", tag, line), add, remove)
+	}
+}
+
+// RecordSetParent journals a parent change from prev to next, where an
+// empty ref means "no parent".
+func (self *UndoHelper) RecordSetParent(uuid, prev, next string) {
+	note := self.c.RuinCmd().Note
+	set := func(ref string) func() error {
+		return func() error {
+			if ref == "" {
+				return note.RemoveParent(uuid)
+			}
+			return note.SetParent(uuid, ref)
+		}
+	}
+	label := "Set parent"
+	if next == "" {
+		label = "Remove parent"
+	}
+	self.Record(label, set(prev), set(next))
+}
+
+// OrderChange is one note's order field before and after a reorder.
+type OrderChange struct {
+	UUID string
+	Prev *int
+	Next int
+}
+
+// RecordOrder journals a batch of order changes as a single entry.
+func (self *UndoHelper) RecordOrder(changes []OrderChange) {
+	note := self.c.RuinCmd().Note
+	self.Record(fmt.Sprintf("Order %d cards", len(changes)),
+		func() error {
+			for _, ch := range changes {
+				var err error
+				if ch.Prev == nil {
+					err = note.RemoveOrder(ch.UUID)
+				} else {
+					err = note.SetOrder(ch.UUID, *ch.Prev)
+				}
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		func() error {
+			for _, ch := range changes {
+				if err := note.SetOrder(ch.UUID, ch.Next); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+}
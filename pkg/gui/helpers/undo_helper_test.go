@@ -0,0 +1,137 @@
+package helpers
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/donnellyk/lazyruin/pkg/commands"
+	"github.com/donnellyk/lazyruin/pkg/testutil"
+)
+
+func newTestUndoHelper(vault string) *UndoHelper {
+	ruinCmd := commands.NewRuinCommandWithExecutor(testutil.NewMockExecutor(), vault)
+	return NewUndoHelper(NewHelperCommon(ruinCmd, nil, &mockGuiCommon{}))
+}
+
+func nopUndo() error { return nil }
+
+func TestUndoRecord_DiscardsRedoTail(t *testing.T) {
+	h := newTestUndoHelper("/mock")
+	h.Record("one", nopUndo, nopUndo)
+	h.Record("two", nopUndo, nopUndo)
+	h.pos = 1 // as if "two" had been undone
+
+	h.Record("three", nopUndo, nopUndo)
+
+	entries := h.Entries()
+	if len(entries) != 2 || entries[1].Label != "three" {
+		t.Errorf("entries = %v, want [one three]", labels(entries))
+	}
+	if h.Position() != 2 {
+		t.Errorf("Position = %d, want 2", h.Position())
+	}
+}
+
+func TestUndoRecord_CapsJournal(t *testing.T) {
+	h := newTestUndoHelper("/mock")
+	for range maxUndoEntries + 5 {
+		h.Record("x", nopUndo, nopUndo)
+	}
+	if len(h.Entries()) != maxUndoEntries {
+		t.Errorf("len = %d, want %d", len(h.Entries()), maxUndoEntries)
+	}
+	if h.Position() != maxUndoEntries {
+		t.Errorf("Position = %d, want %d", h.Position(), maxUndoEntries)
+	}
+}
+
+func TestRecordSnapshot_UndoRestoresFile(t *testing.T) {
+	vault := t.TempDir()
+	path := filepath.Join(vault, "note.md")
+	if err := os.WriteFile(path, []byte("original\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	h := newTestUndoHelper(vault)
+
+	// Relative paths resolve against the vault, as card paths may be.
+	err := h.RecordSnapshot("Delete note", []string{"note.md"}, func() error {
+		return os.Remove(path)
+	})
+	if err != nil {
+		t.Fatalf("RecordSnapshot: %v", err)
+	}
+	if err := h.Entries()[0].Undo(); err != nil {
+		t.Fatalf("Undo: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != "original\n" {
+		t.Errorf("restored file = %q, %v; want original content", data, err)
+	}
+}
+
+func TestRecordSnapshot_FailedMutationIsNotJournaled(t *testing.T) {
+	vault := t.TempDir()
+	path := filepath.Join(vault, "note.md")
+	if err := os.WriteFile(path, []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	h := newTestUndoHelper(vault)
+
+	boom := errors.New("boom")
+	if err := h.RecordSnapshot("Toggle todo", []string{path}, func() error { return boom }); !errors.Is(err, boom) {
+		t.Errorf("err = %v, want boom", err)
+	}
+	if len(h.Entries()) != 0 {
+		t.Errorf("failed mutation should not be journaled, got %v", labels(h.Entries()))
+	}
+}
+
+func TestRecordSnapshot_UnreadableFileStillMutates(t *testing.T) {
+	h := newTestUndoHelper(t.TempDir())
+
+	ran := false
+	err := h.RecordSnapshot("Delete", []string{"missing.md"}, func() error { ran = true; return nil })
+	if err != nil || !ran {
+		t.Errorf("mutate should still run: ran=%v err=%v", ran, err)
+	}
+	if len(h.Entries()) != 0 {
+		t.Error("a mutation without a snapshot can't be undone and should not be journaled")
+	}
+}
+
+func labels(entries []UndoEntry) []string {
+	out := make([]string, len(entries))
+	for i, e := range entries {
+		out[i] = e.Label
+	}
+	return out
+}
+
+func TestUndoBatch_GroupsEntriesAndUndoesInReverse(t *testing.T) {
+	h := newTestUndoHelper("/mock")
+	var order []string
+	step := func(name string) func() error {
+		return func() error { order = append(order, name); return nil }
+	}
+	h.Batch("Tag 2 notes", func() {
+		h.Record("a", step("undo a"), step("redo a"))
+		h.Record("b", step("undo b"), step("redo b"))
+	})
+	h.Batch("Single", func() {
+		h.Record("c", nopUndo, nopUndo)
+	})
+
+	if got := labels(h.Entries()); len(got) != 2 || got[0] != "Tag 2 notes" || got[1] != "c" {
+		t.Fatalf("entries = %v, want [Tag 2 notes c]", got)
+	}
+	_ = h.entries[0].Undo()
+	_ = h.entries[0].Redo()
+	want := []string{"undo b", "undo a", "redo a", "redo b"}
+	if !slices.Equal(order, want) {
+		t.Errorf("order = %v, want %v", order, want)
+	}
+}
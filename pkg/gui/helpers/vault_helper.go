@@ -0,0 +1,97 @@
+package helpers
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/donnellyk/lazyruin/pkg/commands"
+	"github.com/donnellyk/lazyruin/pkg/config"
+	"github.com/donnellyk/lazyruin/pkg/gui/types"
+)
+
+// VaultHelper lists the vaults configured under `vaults:` and rebinds the
+// helpers when the active vault is switched at runtime.
+type VaultHelper struct {
+	c *HelperCommon
+}
+
+// NewVaultHelper creates a new VaultHelper.
+func NewVaultHelper(c *HelperCommon) *VaultHelper {
+	return &VaultHelper{c: c}
+}
+
+// Vaults returns the configured vaults.
+func (self *VaultHelper) Vaults() []config.VaultConfig {
+	if self.c.Config() == nil {
+		return nil
+	}
+	return self.c.Config().Vaults
+}
+
+// ActiveName returns the display name of the active vault: its configured
+// name, or the directory name when it isn't one of the configured vaults.
+func (self *VaultHelper) ActiveName() string {
+	path := self.c.RuinCmd().VaultPath()
+	if cfg := self.c.Config(); cfg != nil {
+		if name := cfg.VaultName(path); name != "" {
+			return name
+		}
+	}
+	return filepath.Base(path)
+}
+
+// OpenSwitcher opens a menu of the configured vaults with a `>` marker on
+// the active one. Choosing a vault switches to it.
+func (self *VaultHelper) OpenSwitcher() error {
+	gui := self.c.GuiCommon()
+	vaults := self.Vaults()
+	if len(vaults) == 0 {
+		gui.ShowStatus("No vaults configured — add a vaults: list to config.yml")
+		return nil
+	}
+
+	active := self.c.RuinCmd().VaultPath()
+	items := make([]types.MenuItem, 0, len(vaults))
+	for i, v := range vaults {
+		label := v.Name
+		if config.ExpandPath(v.Path) == active {
+			label = "> " + label
+		}
+		item := types.MenuItem{
+			Label: label,
+			OnRun: func() error {
+				if err := gui.SwitchVault(v.Path); err != nil {
+					gui.ShowError(err)
+				}
+				return nil
+			},
+		}
+		if i < 9 {
+			item.Key = fmt.Sprint(i + 1)
+		}
+		items = append(items, item)
+	}
+	gui.ShowMenuDialog("Switch Vault", items)
+	return nil
+}
+
+// Load rebinds the helpers to cmd's vault. State that belongs to the old
+// vault is dropped: in-flight loads, cached titles, the undo journal,
+// navigation history, multi-selections and the search query. The caller
+// refreshes the panels afterwards.
+func (self *VaultHelper) Load(cmd *commands.RuinCommand) {
+	h := self.c.Helpers()
+	h.Async().CancelAll()
+	self.c.ruinCmd = cmd
+
+	h.Scratchpad().LoadVault(cmd.VaultPath())
+	h.Trash().LoadVault(cmd.VaultPath())
+	h.TitleCache().Clear()
+	h.Undo().Clear()
+	h.Navigator().Manager().Clear()
+
+	contexts := self.c.GuiCommon().Contexts()
+	contexts.Notes.Selection.Clear()
+	contexts.CardList.Selection.Clear()
+	contexts.Search.Query = ""
+}
@@ -21,17 +21,7 @@ func (gui *Gui) highlightMarkdown(content string) string {
 	}
 	lexer = chroma.Coalesce(lexer)
 
-	styleName := gui.config.ChromaTheme
-	if styleName == "" {
-		styleName = "catppuccin-mocha"
-		if !gui.darkBackground {
-			styleName = "catppuccin-latte"
-		}
-	}
-	style := styles.Get(styleName)
-	if style == nil {
-		style = styles.Fallback
-	}
+	style := gui.highlightStyle()
 
 	formatter := formatters.Get("terminal256")
 	if formatter == nil {
@@ -53,6 +43,53 @@ func (gui *Gui) highlightMarkdown(content string) string {
 	return strings.TrimRight(buf.String(), "\n")
 }
 
+// highlightStyle returns the chroma style for the preview: chroma_theme,
+// or the theme preset's style, with the theme's tag, date and link roles
+// layered on top. Dates keep the link color unless the theme sets one.
+func (gui *Gui) highlightStyle() *chroma.Style {
+	if gui.chromaStyle != nil {
+		return gui.chromaStyle
+	}
+
+	styleName := gui.config.ChromaTheme
+	if styleName == "" || theme.ChromaStyle == "bw" {
+		styleName = theme.ChromaStyle
+	}
+	if styleName == "" {
+		styleName = "catppuccin-mocha"
+		if !gui.darkBackground {
+			styleName = "catppuccin-latte"
+		}
+	}
+	style := styles.Get(styleName)
+	if style == nil {
+		style = styles.Fallback
+	}
+
+	b := style.Builder()
+	// Get resolves inheritance down to the Background entry; drop that
+	// background again so the terminal formatter can clear it as usual.
+	date := style.Get(chroma.NameTag)
+	if date.Background == style.Get(chroma.Background).Background {
+		date.Background = 0
+	}
+	b.AddEntry(chroma.LiteralDate, date)
+	for ttype, entry := range map[chroma.TokenType]string{
+		chroma.NameEntity:  theme.Tag,
+		chroma.LiteralDate: theme.Date,
+		chroma.NameTag:     theme.Link,
+	} {
+		if entry != "" {
+			b.Add(ttype, entry)
+		}
+	}
+	if built, err := b.Build(); err == nil {
+		style = built
+	}
+	gui.chromaStyle = style
+	return style
+}
+
 // highlightWikilinks post-processes chroma tokens to style [[wikilinks]] as links.
 func highlightWikilinks(tokens []chroma.Token) []chroma.Token {
 	var result []chroma.Token
@@ -121,7 +158,9 @@ func captureUpdatedCursorAndOrigin(prevOrigin int, size int, cursor int) (int, i
 
 // highlightAtDates merges adjacent tokens that form @date patterns.
 // Chroma tokenizes "@2026-02-16" as NameEntity("@2026") + Text("-02-16"),
-// splitting the date across two colors. This merges them into a single NameTag.
+// splitting the date across two colors. This merges them into a single
+// LiteralDate, which highlightStyle colors like a link unless the theme
+// gives dates their own color.
 func highlightAtDates(tokens []chroma.Token) []chroma.Token {
 	var result []chroma.Token
 	for i := 0; i < len(tokens); i++ {
@@ -133,7 +172,7 @@ func highlightAtDates(tokens []chroma.Token) []chroma.Token {
 			combined := tok.Value + tokens[i+1].Value
 			// Split on newline in case the next token includes trailing text
 			datePart, rest, found := strings.Cut(combined, "\n")
-			result = append(result, chroma.Token{Type: chroma.NameTag, Value: datePart})
+			result = append(result, chroma.Token{Type: chroma.LiteralDate, Value: datePart})
 			if found {
 				result = append(result, chroma.Token{Type: chroma.Text, Value: "\n" + rest})
 			}
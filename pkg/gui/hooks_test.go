@@ -0,0 +1,124 @@
+package gui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/donnellyk/lazyruin/pkg/gui/helpers"
+	"github.com/donnellyk/lazyruin/pkg/models"
+)
+
+func TestHooks_OnEditRunsWithNoteEnv(t *testing.T) {
+	dir := t.TempDir()
+	notePath := writeNote(t, dir, "hooked.md", "---\nuuid: hook-1\ntitle: Hooked\n---\n\nold\n")
+
+	mock := defaultMock().WithVaultPath(dir).WithNotes(
+		models.Note{UUID: "hook-1", Title: "Hooked", Path: notePath, Created: time.Now()},
+	)
+	tg := newTestGui(t, mock)
+	defer tg.Close()
+	tg.gui.config.Hooks.OnEdit = `echo "$LAZYRUIN_EVENT|$LAZYRUIN_NOTE_UUID|$LAZYRUIN_NOTE_TITLE|$LAZYRUIN_CHANGED|$(basename "$LAZYRUIN_NOTE_PATH")" > hook.out`
+
+	note := tg.gui.contexts.Notes.Items[0]
+	if err := tg.gui.helpers.Capture().OpenCaptureForEdit(&note); err != nil {
+		t.Fatal(err)
+	}
+	if err := tg.gui.helpers.Capture().SubmitCapture("new body", false); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := os.ReadFile(filepath.Join(dir, "hook.out"))
+	if err != nil {
+		t.Fatalf("hook did not run: %v", err)
+	}
+	if got, want := strings.TrimSpace(string(out)), "edit|hook-1|Hooked|body|hooked.md"; got != want {
+		t.Errorf("hook env = %q, want %q", got, want)
+	}
+}
+
+func TestHooks_FailureIsReportedInStatus(t *testing.T) {
+	tg := newTestGui(t, defaultMock().WithVaultPath(t.TempDir()))
+	defer tg.Close()
+	tg.gui.config.Hooks.OnTagChange = "echo sync failed >&2; exit 3"
+
+	tg.gui.helpers.Hooks().Fire(helpers.HookEvent{Name: helpers.HookTagChange, TagsRemoved: []string{"#old"}})
+
+	status := tg.gui.views.Status.Buffer()
+	if !strings.Contains(status, "tag_change hook: exit status 3: sync failed") {
+		t.Errorf("status = %q, want the hook failure", status)
+	}
+}
+
+func TestHooks_UnconfiguredEventDoesNothing(t *testing.T) {
+	dir := t.TempDir()
+	tg := newTestGui(t, defaultMock().WithVaultPath(dir))
+	defer tg.Close()
+	tg.gui.config.Hooks.OnCreate = "touch created"
+
+	tg.gui.helpers.Hooks().Fire(helpers.HookEvent{Name: helpers.HookDelete, UUID: "x"})
+
+	if _, err := os.Stat(filepath.Join(dir, "created")); err == nil {
+		t.Error("on_create hook ran for a delete event")
+	}
+}
+
+func TestHooks_ReResolvedLinkFiresDeleteAndCreate(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "hook.out")
+	mock := defaultMock().WithVaultPath(t.TempDir()).
+		WithLinkJSON([]byte(`{"path":"fresh.md","uuid":"new-1","title":"Fresh"}`))
+	tg := newTestGui(t, mock)
+	defer tg.Close()
+	script := `echo "$LAZYRUIN_EVENT|$LAZYRUIN_NOTE_UUID|$LAZYRUIN_NOTE_TITLE" >> ` + out
+	tg.gui.config.Hooks.OnCreate = script
+	tg.gui.config.Hooks.OnDelete = script
+
+	tg.gui.contexts.Capture.LinkURL = "https://example.com"
+	tg.gui.contexts.Capture.LinkExistingUUID = "old-1"
+	if err := tg.gui.helpers.Link().SubmitLinkCapture("# Fresh\n\nhttps://example.com\n", false); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("hooks did not run: %v", err)
+	}
+	if got, want := strings.TrimSpace(string(data)), "delete|old-1|\ncreate|new-1|Fresh"; got != want {
+		t.Errorf("hook runs = %q, want %q", got, want)
+	}
+}
+
+func TestHooks_DocumentEditFiresCreateForNewChildren(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "hook.out")
+	fx := newComposeFixture(t)
+	newPath := writeNote(t, fx.dir, "new-child.md", "---\nuuid: new-uuid\nparent: parent-1\n---\nA new child\n")
+	fx.mock.WithVaultPath(fx.dir).
+		WithCreatedNote(models.Note{UUID: "new-uuid", Title: "A new child", Path: newPath, Parent: "parent-1"})
+	tg := newTestGuiWithOpts(t, fx.mock, testGuiOpts{OpenRef: "journal"})
+	defer tg.Close()
+	tg.gui.config.Hooks.OnCreate = `echo "$LAZYRUIN_EVENT|$LAZYRUIN_NOTE_UUID|$LAZYRUIN_NOTE_TITLE" >> ` + out
+
+	doc := "<!-- child:child-a-uuid Child A Title -->\n\n" +
+		"# Child A Title\n\nContent line A1\n- Task A #todo\nContent line A3\n\n" +
+		"<!-- child:child-b-uuid Child B Title -->\n\n" +
+		"# Child B Title\n\nContent line B1\n- Task B #todo\n\n" +
+		"<!-- child:new -->\n\nA new child\n"
+	editDocumentWith(t, tg, doc)
+	dialog := tg.gui.state.Dialog
+	if dialog == nil || dialog.OnConfirm == nil {
+		t.Fatal("expected a confirmation dialog")
+	}
+	if err := dialog.OnConfirm(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("on_create did not run: %v", err)
+	}
+	if got, want := strings.TrimSpace(string(data)), "create|new-uuid|A new child"; got != want {
+		t.Errorf("hook runs = %q, want %q", got, want)
+	}
+}
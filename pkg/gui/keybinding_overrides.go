@@ -0,0 +1,224 @@
+package gui
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/donnellyk/lazyruin/pkg/gui/types"
+
+	"github.com/jesseduffield/gocui"
+)
+
+// disabledKey is the config value that unbinds a key. The command stays
+// reachable from the palette.
+const disabledKey = "<disabled>"
+
+// contextBindings returns ctx's bindings with the user's `keybindings:`
+// overrides applied, followed by its `custom_commands:` entries. Every
+// consumer of controller bindings (registration, help, status bar,
+// palette, --debug-bindings) goes through here so they all agree on the
+// effective keys.
+func (gui *Gui) contextBindings(ctx types.Context) []*types.Binding {
+	bindings := applyOverrides(ctx.GetKeybindings(types.KeybindingsOpts{}), gui.keybindingOverrides(ctx.GetKey()))
+	if custom := gui.customCommandBindings(ctx.GetKey()); len(custom) > 0 {
+		bindings = append(slices.Clip(bindings), custom...)
+	}
+	return bindings
+}
+
+// applyOverrides returns bindings with overridden keys swapped in.
+// Remapped bindings are copies; the rest are passed through unchanged.
+func applyOverrides(bindings []*types.Binding, overrides map[string]string) []*types.Binding {
+	if len(overrides) == 0 {
+		return bindings
+	}
+	out := make([]*types.Binding, 0, len(bindings))
+	for _, b := range bindings {
+		if spec, ok := lookupOverride(overrides, b); ok {
+			if key, mod, err := parseKeySpec(spec); err == nil {
+				remapped := *b
+				remapped.Key, remapped.Mod, remapped.KeyDisplay = key, mod, ""
+				b = &remapped
+			}
+		}
+		out = append(out, b)
+	}
+	return out
+}
+
+func (gui *Gui) keybindingOverrides(key types.ContextKey) map[string]string {
+	if gui.config == nil {
+		return nil
+	}
+	return gui.config.Keybindings[string(key)]
+}
+
+// lookupOverride finds the override for b: by full ID, by ID without its
+// "context." prefix, then by description (case-insensitive). Bindings
+// without an ID are navigation aliases and can't be remapped.
+func lookupOverride(overrides map[string]string, b *types.Binding) (string, bool) {
+	if b.ID == "" {
+		return "", false
+	}
+	if spec, ok := overrides[b.ID]; ok {
+		return spec, true
+	}
+	if spec, ok := overrides[bindingSuffix(b.ID)]; ok {
+		return spec, true
+	}
+	if b.Description == "" {
+		return "", false
+	}
+	for name, spec := range overrides {
+		if strings.EqualFold(name, b.Description) {
+			return spec, true
+		}
+	}
+	return "", false
+}
+
+// parseKeySpec converts a config key into a gocui key. It accepts what
+// keyDisplayString prints: a single character, a named key ("enter",
+// "<enter>") or a ctrl chord ("<c-d>"). disabledKey yields a nil key.
+func parseKeySpec(spec string) (any, gocui.Modifier, error) {
+	if spec == disabledKey {
+		return nil, gocui.ModNone, nil
+	}
+	if utf8.RuneCountInString(spec) == 1 {
+		r, _ := utf8.DecodeRuneInString(spec)
+		if r == ' ' {
+			return gocui.KeySpace, gocui.ModNone, nil
+		}
+		return r, gocui.ModNone, nil
+	}
+	lower := strings.ToLower(spec)
+	for k, name := range ctrlKeyNames {
+		if lower == name {
+			return k, gocui.ModNone, nil
+		}
+	}
+	name := strings.TrimSuffix(strings.TrimPrefix(lower, "<"), ">")
+	for k, n := range keyNames {
+		if name == n {
+			return k, gocui.ModNone, nil
+		}
+	}
+	return nil, gocui.ModNone, fmt.Errorf("unknown key %q", spec)
+}
+
+// keybindingProblems validates the `keybindings:` section against the
+// registered contexts: unknown contexts or bindings, unparseable keys, and
+// remapped keys that collide with another binding in the same context.
+// Problems in `custom_commands:` are included. Returned sorted so the
+// report is stable.
+func (gui *Gui) keybindingProblems() []string {
+	if gui.config == nil {
+		return nil
+	}
+
+	contexts := map[string]types.Context{}
+	for _, ctx := range gui.contexts.All() {
+		contexts[string(ctx.GetKey())] = ctx
+	}
+
+	problems := gui.customCommandProblems()
+	for ctxName, overrides := range gui.config.Keybindings {
+		ctx, ok := contexts[ctxName]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("keybindings: unknown context %q", ctxName))
+			continue
+		}
+
+		defaults := ctx.GetKeybindings(types.KeybindingsOpts{})
+		for name, spec := range overrides {
+			if _, _, err := parseKeySpec(spec); err != nil {
+				problems = append(problems, fmt.Sprintf("keybindings.%s.%s: %v", ctxName, name, err))
+			}
+			if !overrideMatchesAny(name, spec, defaults) {
+				problems = append(problems, fmt.Sprintf("keybindings.%s: no binding %q", ctxName, name))
+			}
+		}
+
+		problems = append(problems, keyConflicts(ctxName, defaults, applyOverrides(defaults, overrides))...)
+	}
+	sort.Strings(problems)
+	return problems
+}
+
+// overrideMatchesAny reports whether the override entry name applies to
+// any of bindings.
+func overrideMatchesAny(name, spec string, bindings []*types.Binding) bool {
+	single := map[string]string{name: spec}
+	for _, b := range bindings {
+		if _, ok := lookupOverride(single, b); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// keyConflicts reports remapped bindings whose key is also bound to a
+// different binding on the same view. gocui would silently dispatch to
+// whichever was registered first. Clashes between two default bindings
+// are left alone; some are deliberate.
+func keyConflicts(ctxName string, defaults, effective []*types.Binding) []string {
+	type slot struct {
+		view string
+		key  any
+		mod  gocui.Modifier
+	}
+	owners := map[slot][]int{}
+	for i, b := range effective {
+		if b.Key == nil {
+			continue
+		}
+		s := slot{view: b.ViewName, key: b.Key, mod: b.Mod}
+		owners[s] = append(owners[s], i)
+	}
+
+	var problems []string
+	for s, idxs := range owners {
+		remapped := false
+		for _, i := range idxs {
+			if effective[i] != defaults[i] {
+				remapped = true
+			}
+		}
+		if !remapped {
+			continue
+		}
+		var ids []string
+		for _, i := range idxs {
+			if id := effective[i].ID; id != "" && !slices.Contains(ids, id) {
+				ids = append(ids, id)
+			}
+		}
+		if len(ids) > 1 {
+			problems = append(problems, fmt.Sprintf("keybindings.%s: %s is bound to %s", ctxName, keyDisplayString(s.key), strings.Join(ids, " and ")))
+		}
+	}
+	return problems
+}
+
+// reportKeybindingProblems surfaces config problems as a startup warning.
+// The status bar has room for one line, so only the first is spelled out.
+func (gui *Gui) reportKeybindingProblems() {
+	problems := gui.keybindingProblems()
+	if len(problems) == 0 {
+		return
+	}
+	msg := problems[0]
+	if len(problems) > 1 {
+		msg = fmt.Sprintf("%s (and %d more, see --debug-bindings)", msg, len(problems)-1)
+	}
+	gui.appendStartupWarning(msg)
+}
+
+// KeybindingProblems returns every problem found in the `keybindings:`
+// config section. Printed by --debug-bindings.
+func (gui *Gui) KeybindingProblems() []string {
+	return gui.keybindingProblems()
+}
@@ -0,0 +1,110 @@
+package gui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/donnellyk/lazyruin/pkg/gui/types"
+
+	"github.com/jesseduffield/gocui"
+)
+
+func TestParseKeySpec(t *testing.T) {
+	tests := []struct {
+		spec string
+		want any
+	}{
+		{"x", 'x'},
+		{"?", '?'},
+		{"space", gocui.KeySpace},
+		{" ", gocui.KeySpace},
+		{"enter", gocui.KeyEnter},
+		{"<Esc>", gocui.KeyEsc},
+		{"<c-d>", gocui.KeyCtrlD},
+		{disabledKey, nil},
+	}
+	for _, tt := range tests {
+		got, _, err := parseKeySpec(tt.spec)
+		if err != nil {
+			t.Errorf("parseKeySpec(%q) error: %v", tt.spec, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseKeySpec(%q) = %v, want %v", tt.spec, got, tt.want)
+		}
+	}
+	if _, _, err := parseKeySpec("<hyper-x>"); err == nil {
+		t.Error("parseKeySpec(<hyper-x>) should fail")
+	}
+}
+
+func bindingByID(bindings []*types.Binding, id string) *types.Binding {
+	for _, b := range bindings {
+		if b.ID == id {
+			return b
+		}
+	}
+	return nil
+}
+
+func TestKeybindingOverrides_RemapAndUnbind(t *testing.T) {
+	tg := newTestGuiWithOpts(t, defaultMock(), testGuiOpts{Keybindings: map[string]map[string]string{
+		"notes": {
+			"delete":         "x",         // ID without the context prefix
+			"Open in Editor": disabledKey, // description
+		},
+	}})
+	defer tg.Close()
+
+	bindings := tg.gui.contextBindings(tg.gui.contexts.Notes)
+	if b := bindingByID(bindings, "notes.delete"); b == nil || b.Key != 'x' {
+		t.Errorf("notes.delete key = %v, want x", b.Key)
+	}
+	if b := bindingByID(bindings, "notes.edit"); b == nil || b.Key != nil {
+		t.Errorf("notes.edit key = %v, want unbound", b.Key)
+	}
+
+	tg.gui.globalController.FocusNotes()
+	var deleteKey string
+	for _, item := range tg.gui.helpDialogItems() {
+		if item.Label == "Delete Note" {
+			deleteKey = item.Key
+		}
+		if item.Label == "Open in Editor" {
+			t.Error("unbound binding should not be listed in help")
+		}
+	}
+	if deleteKey != "x" {
+		t.Errorf("help shows Delete Note on %q, want x", deleteKey)
+	}
+	for _, h := range tg.gui.statusBarHints() {
+		if h.action == "Editor" {
+			t.Error("unbound binding should not be hinted in the status bar")
+		}
+	}
+
+	if tg.gui.state.StartupWarning != "" {
+		t.Errorf("unexpected startup warning: %q", tg.gui.state.StartupWarning)
+	}
+}
+
+func TestKeybindingOverrides_ReportsProblemsAtStartup(t *testing.T) {
+	tg := newTestGuiWithOpts(t, defaultMock(), testGuiOpts{Keybindings: map[string]map[string]string{
+		"notes":   {"delete": "e", "bogus": "z"},
+		"nowhere": {"x": "y"},
+	}})
+	defer tg.Close()
+
+	problems := tg.gui.KeybindingProblems()
+	want := []string{
+		`keybindings.notes: e is bound to notes.edit_inline and notes.delete`,
+		`keybindings.notes: no binding "bogus"`,
+		`keybindings: unknown context "nowhere"`,
+	}
+	if strings.Join(problems, "\n") != strings.Join(want, "\n") {
+		t.Errorf("problems = %q, want %q", problems, want)
+	}
+	if !strings.Contains(tg.gui.state.StartupWarning, "and 2 more") {
+		t.Errorf("startup warning = %q, want a summary of 3 problems", tg.gui.state.StartupWarning)
+	}
+}
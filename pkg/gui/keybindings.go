@@ -45,6 +45,11 @@ func (gui *Gui) setupKeybindings() error {
 	)); err != nil {
 		return err
 	}
+	if err := gui.g.SetTabClickBinding(PreviewView, gui.suppressTabClickDuringDialog(
+		func(idx int) error { return gui.helpers.Breadcrumb().ClickCrumb(idx) },
+	)); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -55,8 +60,12 @@ func (gui *Gui) setupKeybindings() error {
 // Preview contexts (cardList, pickResults, compose) share the "preview" view and
 // are skipped here; their bindings are dynamically registered via
 // reregisterPreviewBindings whenever the active preview context changes.
+//
+// User overrides from the `keybindings:` config section are applied by
+// contextBindings; problems with them are reported as a startup warning.
 func (gui *Gui) registerContextBindings() error {
 	opts := types.KeybindingsOpts{}
+	gui.reportKeybindingProblems()
 
 	for _, ctx := range gui.contexts.All() {
 		viewNames := ctx.GetViewNames()
@@ -68,7 +77,7 @@ func (gui *Gui) registerContextBindings() error {
 			continue
 		}
 
-		for _, b := range ctx.GetKeybindings(opts) {
+		for _, b := range gui.contextBindings(ctx) {
 			binding := b
 			// Skip palette-only bindings (Key == nil = no keybinding, just palette entry)
 			if binding.Key == nil {
@@ -141,7 +150,7 @@ func (gui *Gui) reregisterPreviewBindings() {
 	}
 
 	opts := types.KeybindingsOpts{}
-	for _, b := range ctx.GetKeybindings(opts) {
+	for _, b := range gui.contextBindings(ctx) {
 		binding := b
 		if binding.Key == nil {
 			continue
@@ -175,10 +184,9 @@ func (gui *Gui) reregisterPreviewBindings() {
 // DumpBindings returns a stable sorted list of all registered controller bindings
 // for debugging and regression diffing. Use with --debug-bindings flag.
 func (gui *Gui) DumpBindings() []string {
-	opts := types.KeybindingsOpts{}
 	var entries []string
 	for _, ctx := range gui.contexts.All() {
-		for _, b := range ctx.GetKeybindings(opts) {
+		for _, b := range gui.contextBindings(ctx) {
 			keyStr := ""
 			if b.Key != nil {
 				keyStr = keyDisplayString(b.Key)
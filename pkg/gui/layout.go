@@ -5,8 +5,13 @@ import (
 	"strings"
 	"time"
 
+	"github.com/donnellyk/lazyruin/pkg/gui/context"
+	helperspkg "github.com/donnellyk/lazyruin/pkg/gui/helpers"
 	"github.com/donnellyk/lazyruin/pkg/gui/types"
+	"github.com/donnellyk/lazyruin/pkg/merge"
 	"github.com/donnellyk/lazyruin/pkg/models"
+	"github.com/donnellyk/lazyruin/pkg/searchhistory"
+	"github.com/donnellyk/lazyruin/pkg/session"
 	"github.com/donnellyk/ruin-note-cli/pkg/notetext"
 
 	"github.com/jesseduffield/gocui"
@@ -130,6 +135,11 @@ func (gui *Gui) layout(g *gocui.Gui) error {
 				return err
 			}
 		}
+		if gui.contextMgr.Contains("todos") {
+			if err := gui.createTodos(g, maxX, maxY); err != nil {
+				return err
+			}
+		}
 		if err := gui.createInputPopup(g, maxX, maxY); err != nil {
 			return err
 		}
@@ -158,6 +168,35 @@ func (gui *Gui) layout(g *gocui.Gui) error {
 		if err := gui.createScratchpadBrowser(g, maxX, maxY); err != nil {
 			return err
 		}
+	case "trashBrowser":
+		if err := gui.createTrashBrowser(g, maxX, maxY); err != nil {
+			return err
+		}
+	case "todos":
+		if err := gui.createTodos(g, maxX, maxY); err != nil {
+			return err
+		}
+	case "parentTree":
+		if err := gui.createParentTree(g, sidebarWidth, contentHeight); err != nil {
+			return err
+		}
+	case "noteHistory":
+		if err := gui.createNoteHistory(g, sidebarWidth, contentHeight); err != nil {
+			return err
+		}
+	case "previewFind":
+		if err := gui.createPreviewFind(g); err != nil {
+			return err
+		}
+	case "mergeConflict":
+		if gui.contextMgr.Contains("capture") {
+			if err := gui.createCapturePopup(g, maxX, maxY); err != nil {
+				return err
+			}
+		}
+		if err := gui.createMergeConflict(g, maxX, maxY); err != nil {
+			return err
+		}
 	}
 	// Delete views for inactive overlays
 	ctx := gui.contextMgr.Current()
@@ -200,6 +239,24 @@ func (gui *Gui) layout(g *gocui.Gui) error {
 	if ctx != "scratchpadBrowser" {
 		g.DeleteView(ScratchpadBrowserView)
 	}
+	if ctx != "trashBrowser" {
+		g.DeleteView(TrashBrowserView)
+	}
+	if ctx != "todos" && !gui.contextMgr.Contains("todos") {
+		g.DeleteView(TodosView)
+	}
+	if ctx != "parentTree" {
+		g.DeleteView(ParentTreeView)
+	}
+	if ctx != "noteHistory" {
+		g.DeleteView(NoteHistoryView)
+	}
+	if ctx != "mergeConflict" {
+		g.DeleteView(MergeConflictView)
+	}
+	if ctx != "previewFind" {
+		g.DeleteView(PreviewFindView)
+	}
 
 	// Render any active dialogs
 	if err := gui.renderDialogs(g, maxX, maxY); err != nil {
@@ -210,13 +267,27 @@ func (gui *Gui) layout(g *gocui.Gui) error {
 		gui.state.Initialized = true
 		gui.state.lastWidth = maxX
 		gui.state.lastHeight = maxY
+		// The saved session's tabs are selected before the first refresh
+		// so each panel loads the tab it was left on.
+		var sess *session.Session
+		if !gui.QuickCapture && !gui.QuickLink {
+			sess = gui.helpers.Session().Load()
+			gui.helpers.Session().RestoreTabs(sess)
+		}
 		gui.RefreshAll()
 		if !gui.QuickCapture && !gui.QuickLink {
 			if gui.OpenRef != "" {
 				gui.openInitialRef(gui.OpenRef)
-			} else {
+			} else if !gui.restoreSessionView(sess) {
 				gui.helpers.DatePreview().LoadDatePreview(time.Now().Format("2006-01-02"))
 			}
+			// A --remote command with no instance to receive it runs here,
+			// on top of the launch view.
+			if gui.RemoteRequest != nil {
+				if err := gui.runRemote(*gui.RemoteRequest); err != nil {
+					gui.appendStartupWarning(err.Error())
+				}
+			}
 			// Migration prompt takes precedence: a registered upgrade
 			// requires re-indexing the vault before lazyruin can be
 			// usefully used. Init / onboarding only apply on a clean
@@ -265,8 +336,8 @@ func (gui *Gui) layout(g *gocui.Gui) error {
 // context key is the currently focused context (green = focused, default = not).
 func (gui *Gui) applyFocusColors(v *gocui.View, contextKey string) {
 	if gui.contextMgr.Current() == types.ContextKey(contextKey) {
-		v.FrameColor = gocui.ColorGreen
-		v.TitleColor = gocui.ColorGreen
+		v.FrameColor = theme.FrameActive
+		v.TitleColor = theme.FrameActive
 	} else {
 		v.FrameColor = gocui.ColorDefault
 		v.TitleColor = gocui.ColorDefault
@@ -301,11 +372,11 @@ func (gui *Gui) createSearchFilterView(g *gocui.Gui, x0, y0, x1, y1 int) error {
 	setRoundedCorners(v)
 
 	if gui.contextMgr.Current() == "searchFilter" {
-		v.FrameColor = gocui.ColorGreen
-		v.TitleColor = gocui.ColorGreen
+		v.FrameColor = theme.FrameActive
+		v.TitleColor = theme.FrameActive
 	} else {
-		v.FrameColor = gocui.ColorYellow
-		v.TitleColor = gocui.ColorYellow
+		v.FrameColor = theme.FrameAlert
+		v.TitleColor = theme.FrameAlert
 	}
 
 	v.Clear()
@@ -327,7 +398,11 @@ func (gui *Gui) createNotesView(g *gocui.Gui, x0, y0, x1, y1 int) error {
 	} else {
 		v.Tabs = []string{"All", "Today", "Recent", "Links"}
 	}
-	v.SelFgColor = gocui.ColorGreen
+	v.SelFgColor = theme.FrameActive
+	v.Footer = ""
+	if n := len(gui.contexts.Notes.SelectedNotes()); n > 0 && gui.NotesOuterTab() != "home" {
+		v.Footer = fmt.Sprintf("%d selected", n)
+	}
 	gui.UpdateNotesTab()
 	setRoundedCorners(v)
 
@@ -345,8 +420,8 @@ func (gui *Gui) createNotesView(g *gocui.Gui, x0, y0, x1, y1 int) error {
 func (gui *Gui) applyNotesFocusColors(v *gocui.View) {
 	cur := gui.contextMgr.Current()
 	if cur == "notes" || cur == "notesHome" {
-		v.FrameColor = gocui.ColorGreen
-		v.TitleColor = gocui.ColorGreen
+		v.FrameColor = theme.FrameActive
+		v.TitleColor = theme.FrameActive
 	} else {
 		v.FrameColor = gocui.ColorDefault
 		v.TitleColor = gocui.ColorDefault
@@ -362,7 +437,7 @@ func (gui *Gui) createQueriesView(g *gocui.Gui, x0, y0, x1, y1 int) error {
 	gui.views.Queries = v
 	v.TitlePrefix = "[2]"
 	v.Tabs = []string{"Parents", "Queries"}
-	v.SelFgColor = gocui.ColorGreen
+	v.SelFgColor = theme.FrameActive
 	v.Highlight = false
 	gui.UpdateQueriesTab()
 	setRoundedCorners(v)
@@ -381,7 +456,7 @@ func (gui *Gui) createTagsView(g *gocui.Gui, x0, y0, x1, y1 int) error {
 	gui.views.Tags = v
 	v.TitlePrefix = "[3]"
 	v.Tabs = []string{"All", "Global", "Inline"}
-	v.SelFgColor = gocui.ColorGreen
+	v.SelFgColor = theme.FrameActive
 	v.Highlight = false
 	gui.UpdateTagsTab()
 	setRoundedCorners(v)
@@ -402,6 +477,7 @@ func (gui *Gui) createPreviewView(g *gocui.Gui, x0, y0, x1, y1 int) error {
 	setRoundedCorners(v)
 
 	// Read title from context state; layout only adds padding.
+	v.Tabs = nil
 	switch gui.contexts.ActivePreviewKey {
 	case "pickResults":
 		pr := gui.contexts.PickResults
@@ -433,6 +509,8 @@ func (gui *Gui) createPreviewView(g *gocui.Gui, x0, y0, x1, y1 int) error {
 	default:
 		cl := gui.contexts.CardList
 		v.Title = " " + cl.Title() + " "
+		v.Tabs = gui.breadcrumbTabs()
+		v.TabIndex = len(v.Tabs) - 1
 		if cl.FilterActive() {
 			v.Subtitle = fmt.Sprintf(" Filter: %s ", cl.FilterText)
 			v.Footer = fmt.Sprintf("%d of %d matched",
@@ -445,12 +523,38 @@ func (gui *Gui) createPreviewView(g *gocui.Gui, x0, y0, x1, y1 int) error {
 				v.Footer = ""
 			}
 		}
+		if n := len(cl.SelectedNotes(cl.SelectedCardIdx)); n > 0 {
+			v.Footer = models.JoinDot(v.Footer, fmt.Sprintf("%d selected", n))
+		}
+	}
+
+	// A hover load still in flight keeps the old content on screen; the
+	// spinner signals that it is about to be replaced.
+	if async := gui.helpers.Async(); async.Pending(helperspkg.PreviewTaskKey) {
+		v.Title = strings.TrimSuffix(v.Title, " ") + " " + async.Spinner() + " "
+		if n := len(v.Tabs); n > 0 {
+			v.Tabs[n-1] += " " + async.Spinner()
+		}
+	}
+
+	if gui.contextMgr.Current() == "noteHistory" {
+		nh := gui.contexts.NoteHistory
+		v.Tabs = nil
+		v.Title = " History: " + nh.Note.Title + " "
+		if rev := nh.Selected(); rev != nil {
+			v.Title = " History: " + models.JoinDot(nh.Note.Title, rev.ShortHash()) + " "
+		}
+		if async := gui.helpers.Async(); async.Pending(helperspkg.NoteDiffTaskKey) {
+			v.Title += async.Spinner() + " "
+		}
+		v.Subtitle = ""
+		v.Footer = ""
 	}
 
 	// Preview maps to multiple context keys; use existing bool helper.
 	if gui.isPreviewActive() {
-		v.FrameColor = gocui.ColorGreen
-		v.TitleColor = gocui.ColorGreen
+		v.FrameColor = theme.FrameActive
+		v.TitleColor = theme.FrameActive
 	} else {
 		v.FrameColor = gocui.ColorDefault
 		v.TitleColor = gocui.ColorDefault
@@ -459,6 +563,21 @@ func (gui *Gui) createPreviewView(g *gocui.Gui, x0, y0, x1, y1 int) error {
 	return nil
 }
 
+// breadcrumbTabs returns the preview title as clickable tabs when a single
+// note with ancestors is open: one tab per ancestor, root first, then the
+// note itself. Nil otherwise, so the plain title is drawn.
+func (gui *Gui) breadcrumbTabs() []string {
+	crumbs := gui.helpers.Breadcrumb().Crumbs()
+	if len(crumbs) == 0 {
+		return nil
+	}
+	tabs := make([]string, 0, len(crumbs)+1)
+	for _, c := range crumbs {
+		tabs = append(tabs, c.Title)
+	}
+	return append(tabs, gui.contexts.CardList.Title())
+}
+
 func (gui *Gui) createStatusView(g *gocui.Gui, x0, y0, x1, y1 int) error {
 	v, err := g.SetView(StatusView, x0, y0, x1, y1, 0)
 	if err != nil && err.Error() != "unknown view" {
@@ -495,6 +614,12 @@ func (gui *Gui) createSearchPopup(g *gocui.Gui, maxX, maxY int) error {
 		state:      func() *types.CompletionState { return gui.contexts.Search.Completion },
 		triggers:   gui.searchOrFilterTriggers,
 		drillFlags: 0,
+		history: func() string {
+			if gui.contexts.Search.InFilterMode() {
+				return ""
+			}
+			return searchhistory.KindSearch
+		},
 	}
 	setRoundedCorners(v)
 	gui.applyFocusColors(v, "search")
@@ -534,6 +659,9 @@ func (gui *Gui) createInputPopup(g *gocui.Gui, maxX, maxY int) error {
 	}
 
 	v.Title = " " + config.Title + " "
+	if async := gui.helpers.Async(); config.SpinnerKey != "" && async.Pending(config.SpinnerKey) {
+		v.Title = " " + config.Title + " " + async.Spinner() + " "
+	}
 	v.Footer = config.Footer
 	v.Editable = !config.Locked
 	v.Wrap = false
@@ -669,6 +797,7 @@ func (gui *Gui) createPickPopup(g *gocui.Gui, maxX, maxY int) error {
 		state:      func() *types.CompletionState { return gui.contexts.Pick.Completion },
 		triggers:   gui.pickTriggers,
 		drillFlags: 0,
+		history:    func() string { return searchhistory.KindPick },
 	}
 	setRoundedCorners(v)
 	gui.applyFocusColors(v, "pick")
@@ -724,6 +853,8 @@ func (gui *Gui) createPalettePopup(g *gocui.Gui, maxX, maxY int) error {
 			v.TextArea.TypeString(gui.contexts.Palette.Seed)
 			gui.contexts.Palette.Seed = ""
 			gui.refreshPaletteFromBuffer(v)
+		} else if p := gui.contexts.Palette.Palette; p != nil && p.Title != "" {
+			v.Title = " " + p.Title + " "
 		} else {
 			v.Title = " Command Palette "
 			gui.filterPaletteCommands("")
@@ -749,6 +880,9 @@ func (gui *Gui) createPalettePopup(g *gocui.Gui, maxX, maxY int) error {
 	}
 	gui.views.PaletteList = lv
 	lv.Wrap = false
+	if p := gui.contexts.Palette.Palette; p != nil {
+		lv.Footer = p.Footer
+	}
 	setRoundedCorners(lv)
 	gui.applyFocusColors(lv, "palette")
 
@@ -766,6 +900,60 @@ func (gui *Gui) createPalettePopup(g *gocui.Gui, maxX, maxY int) error {
 	return nil
 }
 
+// createPreviewFind draws the find bar across the bottom of the preview.
+func (gui *Gui) createPreviewFind(g *gocui.Gui) error {
+	pv, err := g.View(PreviewView)
+	if err != nil {
+		return nil
+	}
+	px0, _, px1, py1 := pv.Dimensions()
+
+	v, err := g.SetView(PreviewFindView, px0+1, py1-3, px1-1, py1-1, 0)
+	if err != nil && err.Error() != "unknown view" {
+		return err
+	}
+
+	v.Title = " Find "
+	v.Subtitle = gui.previewFindSubtitle()
+	v.Footer = gui.previewFindFooter()
+	v.Editable = true
+	v.Wrap = false
+	v.Editor = &findEditor{gui: gui}
+	setRoundedCorners(v)
+	gui.applyFocusColors(v, "previewFind")
+
+	v.RenderTextArea()
+
+	g.Cursor = true
+	g.SetViewOnTop(PreviewFindView)
+	g.SetCurrentView(PreviewFindView)
+	return nil
+}
+
+// previewFindSubtitle reports a bad regex or a pattern with no matches;
+// the match count itself is in the status bar.
+func (gui *Gui) previewFindSubtitle() string {
+	if gui.contexts.PreviewFind.Invalid {
+		return " invalid regex "
+	}
+	ns := gui.contexts.ActivePreview().NavState()
+	if ns.Find.Active() && len(ns.Matches) == 0 {
+		return " no matches "
+	}
+	return ""
+}
+
+func (gui *Gui) previewFindFooter() string {
+	f := gui.contexts.ActivePreview().NavState().Find
+	onOff := func(b bool) string {
+		if b {
+			return "on"
+		}
+		return "off"
+	}
+	return " regex: " + onOff(f.Regex) + " <c-r> | case: " + onOff(f.CaseSensitive) + " <c-a> | Enter: done | Esc: clear "
+}
+
 func (gui *Gui) pickFooter() string {
 	anyLabel := "off"
 	if gui.contexts.Pick.AnyMode {
@@ -861,6 +1049,308 @@ func (gui *Gui) createScratchpadBrowser(g *gocui.Gui, maxX, maxY int) error {
 	return nil
 }
 
+func (gui *Gui) createTrashBrowser(g *gocui.Gui, maxX, maxY int) error {
+	ctx := gui.contexts.TrashBrowser
+
+	itemCount := len(ctx.Items)
+	height := max(
+		// items + border
+		itemCount+2, 4)
+	maxHeight := maxY * 60 / 100
+	if height > maxHeight {
+		height = maxHeight
+	}
+
+	x0, y0, x1, y1 := centerPopup(maxX, maxY, 60, height, 0)
+
+	v, err := g.SetView(TrashBrowserView, x0, y0, x1, y1, 0)
+	if err != nil && err.Error() != "unknown view" {
+		return err
+	}
+
+	v.Title = " Trash "
+	v.Highlight = false
+	setRoundedCorners(v)
+	gui.applyFocusColors(v, "trashBrowser")
+
+	if itemCount > 0 {
+		v.Footer = fmt.Sprintf("%d of %d items", ctx.SelectedIdx+1, itemCount)
+	} else {
+		v.Footer = "empty"
+	}
+
+	renderList(v, itemCount, ctx.SelectedIdx, true, 1, "  Trash is empty",
+		func(i int, selected bool) listItem {
+			item := ctx.Items[i]
+			title := item.Title
+			if title == "" {
+				title = item.Path
+			}
+			return listItem{Lines: []string{"  " + models.JoinDot(title, item.Deleted.Format("Jan 2 15:04"))}}
+		})
+
+	g.SetViewOnTop(TrashBrowserView)
+	g.SetCurrentView(TrashBrowserView)
+
+	return nil
+}
+
+// createTodos draws the Todos dashboard: group headers with their todos
+// beneath, the grouping in the title and the active filters in the footer.
+func (gui *Gui) createTodos(g *gocui.Gui, maxX, maxY int) error {
+	ctx := gui.contexts.Todos
+
+	x0, y0, x1, y1 := centerPopup(maxX, maxY, maxX*80/100, maxY*80/100, 0)
+
+	v, err := g.SetView(TodosView, x0, y0, x1, y1, 0)
+	if err != nil && err.Error() != "unknown view" {
+		return err
+	}
+
+	v.Title = " Todos · by " + string(ctx.GroupBy) + " "
+	v.Highlight = false
+	setRoundedCorners(v)
+	gui.applyFocusColors(v, "todos")
+
+	var filters []string
+	if gui.config != nil {
+		opts := gui.config.ViewOptions.Todos
+		if opts.HasDate {
+			filters = append(filters, "has date")
+		}
+		if opts.NoDate {
+			filters = append(filters, "no date")
+		}
+		if opts.RecentDone {
+			filters = append(filters, "done last 7 days")
+		}
+	}
+	v.Footer = models.JoinDot(append([]string{fmt.Sprintf("%d todos", ctx.ItemCount())}, filters...)...)
+
+	today := time.Now().Format("2006-01-02")
+	renderList(v, len(ctx.Rows), ctx.SelectedIdx, gui.contextMgr.Current() == "todos", 1, "  No open todos",
+		func(i int, selected bool) listItem {
+			row := ctx.Rows[i]
+			if row.Item == nil {
+				return listItem{Lines: []string{theme.Title + row.Header + AnsiReset}}
+			}
+			item := row.Item
+			box, source := "[ ] ", ""
+			if item.Done {
+				box = "[x] "
+			}
+			if ctx.GroupBy != context.TodoGroupByNote {
+				source = "  · " + item.Title
+			}
+			if selected {
+				return listItem{Lines: []string{"  " + box + item.Content + source}}
+			}
+			content := item.Content
+			switch {
+			case item.Done:
+				content = theme.Done + content + AnsiReset
+			case item.Date != "" && item.Date < today:
+				content = strings.Replace(content, "@"+item.Date, theme.Warning+"@"+item.Date+AnsiReset, 1)
+			}
+			return listItem{Lines: []string{"  " + box + content + theme.Muted + source + AnsiReset}}
+		})
+
+	g.SetViewOnTop(TodosView)
+	if gui.contextMgr.Current() == "todos" {
+		g.SetCurrentView(TodosView)
+	}
+
+	return nil
+}
+
+// createParentTree draws the tree navigator over the sidebar so the
+// preview beside it stays visible for the hovered note.
+func (gui *Gui) createParentTree(g *gocui.Gui, sidebarWidth, contentHeight int) error {
+	ctx := gui.contexts.ParentTree
+	rows := ctx.Rows()
+
+	v, err := g.SetView(ParentTreeView, 0, 0, sidebarWidth-1, contentHeight-1, 0)
+	if err != nil && err.Error() != "unknown view" {
+		return err
+	}
+
+	v.Title = " Parent Tree "
+	v.Highlight = false
+	setRoundedCorners(v)
+	gui.applyFocusColors(v, "parentTree")
+
+	if ctx.CutUUID != "" {
+		v.Footer = "p: paste | Esc: cancel"
+	} else if len(rows) > 0 {
+		v.Footer = fmt.Sprintf("%d of %d", ctx.SelectedIdx+1, len(rows))
+	} else {
+		v.Footer = "empty"
+	}
+
+	renderList(v, len(rows), ctx.SelectedIdx, true, 1, "  No notes",
+		func(i int, selected bool) listItem {
+			return listItem{Lines: []string{parentTreeLine(rows[i], ctx.Expanded[rows[i].Node.UUID], ctx.CutUUID, selected)}}
+		})
+
+	g.SetViewOnTop(ParentTreeView)
+	g.SetCurrentView(ParentTreeView)
+
+	return nil
+}
+
+// parentTreeLine renders a tree row: box-drawing guides for its ancestors,
+// a fold marker when it has children, and the title. The cut node is muted.
+func parentTreeLine(row context.ParentTreeRow, expanded bool, cutUUID string, selected bool) string {
+	var b strings.Builder
+	b.WriteString(" ")
+	for level := 1; level < len(row.Last); level++ {
+		switch {
+		case level < len(row.Last)-1 && row.Last[level]:
+			b.WriteString("   ")
+		case level < len(row.Last)-1:
+			b.WriteString("│  ")
+		case row.Last[level]:
+			b.WriteString("└─ ")
+		default:
+			b.WriteString("├─ ")
+		}
+	}
+	switch {
+	case len(row.Node.Children) == 0:
+		b.WriteString("  ")
+	case expanded:
+		b.WriteString("▾ ")
+	default:
+		b.WriteString("▸ ")
+	}
+	title := row.Node.Title
+	if title == "" {
+		title = "Untitled"
+	}
+	if row.Node.UUID == cutUUID {
+		title += " (cut)"
+		if !selected {
+			return b.String() + theme.Muted + title + AnsiReset
+		}
+	}
+	return b.String() + title
+}
+
+// createNoteHistory draws the revision list over the sidebar; the preview
+// beside it shows the selected revision's diff.
+func (gui *Gui) createNoteHistory(g *gocui.Gui, sidebarWidth, contentHeight int) error {
+	ctx := gui.contexts.NoteHistory
+
+	v, err := g.SetView(NoteHistoryView, 0, 0, sidebarWidth-1, contentHeight-1, 0)
+	if err != nil && err.Error() != "unknown view" {
+		return err
+	}
+
+	v.Title = " Note History "
+	v.Highlight = false
+	setRoundedCorners(v)
+	gui.applyFocusColors(v, "noteHistory")
+	v.Footer = fmt.Sprintf("%d of %d", ctx.SelectedIdx+1, len(ctx.Revisions))
+
+	renderList(v, len(ctx.Revisions), ctx.SelectedIdx, true, 2, "  No revisions",
+		func(i int, selected bool) listItem {
+			rev := ctx.Revisions[i]
+			meta := models.JoinDot(rev.Date.Format("Jan 2 2006 15:04"), rev.ShortHash())
+			return listItem{Lines: []string{"  " + rev.Subject, "  " + meta}}
+		})
+
+	g.SetViewOnTop(NoteHistoryView)
+	g.SetCurrentView(NoteHistoryView)
+
+	return nil
+}
+
+// createMergeConflict draws the conflict resolution popup over the edit
+// popup: merged regions in full, each conflict as two columns with the
+// edit on the left and the on-disk version on the right.
+func (gui *Gui) createMergeConflict(g *gocui.Gui, maxX, maxY int) error {
+	ctx := gui.contexts.MergeConflict
+
+	x0, y0, x1, y1 := centerPopup(maxX, maxY, 120, maxY-4, 0)
+	v, err := g.SetView(MergeConflictView, x0, y0, x1, y1, 0)
+	if err != nil && err.Error() != "unknown view" {
+		return err
+	}
+
+	v.Title = " " + ctx.NoteTitle + " changed on disk "
+	v.Subtitle = " 1 yours · 2 on disk · 3 both · enter to save "
+	v.Footer = fmt.Sprintf("%d of %d resolved", len(ctx.Sides)-ctx.Unresolved(), len(ctx.Sides))
+	v.Highlight = false
+	setRoundedCorners(v)
+	gui.applyFocusColors(v, "mergeConflict")
+
+	width, _ := v.InnerSize()
+	colWidth := max(10, (width-5)/2)
+	var lines []string
+	selLine, n := 0, 0
+	for _, chunk := range ctx.Result.Chunks {
+		if !chunk.Conflict {
+			for _, line := range chunk.Lines {
+				lines = append(lines, theme.Muted+"  "+line+AnsiReset)
+			}
+			continue
+		}
+		header := fmt.Sprintf(" Conflict %d of %d", n+1, len(ctx.Sides))
+		if label := conflictSideLabel(ctx.Sides[n]); label != "" {
+			header += " · " + label
+		}
+		if n == ctx.SelectedIdx {
+			selLine = len(lines)
+			lines = append(lines, theme.Selected+fitWidth(header, width)+AnsiReset)
+		} else {
+			lines = append(lines, theme.Accent+header+AnsiReset)
+		}
+		lines = append(lines, "  "+fitWidth("Your edit", colWidth)+" │ On disk")
+		for i := range max(len(chunk.Ours), len(chunk.Theirs)) {
+			var ours, theirs string
+			if i < len(chunk.Ours) {
+				ours = chunk.Ours[i]
+			}
+			if i < len(chunk.Theirs) {
+				theirs = chunk.Theirs[i]
+			}
+			lines = append(lines, "  "+theme.Success+fitWidth(ours, colWidth)+AnsiReset+" │ "+theme.Warning+theirs+AnsiReset)
+		}
+		n++
+	}
+	v.Clear()
+	fmt.Fprint(v, strings.Join(lines, "\n"))
+	v.SetOrigin(0, max(0, selLine-2))
+
+	g.Cursor = false
+	g.SetViewOnTop(MergeConflictView)
+	g.SetCurrentView(MergeConflictView)
+
+	return nil
+}
+
+// conflictSideLabel describes how a conflict has been resolved.
+func conflictSideLabel(side merge.Side) string {
+	switch side {
+	case merge.Ours:
+		return "keeping your edit"
+	case merge.Theirs:
+		return "keeping on disk"
+	case merge.Both:
+		return "keeping both"
+	}
+	return ""
+}
+
+// fitWidth pads or truncates s to exactly width runes.
+func fitWidth(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) > width {
+		return string(runes[:width-1]) + "…"
+	}
+	return s + strings.Repeat(" ", width-len(runes))
+}
+
 func (gui *Gui) createPickDialog(g *gocui.Gui, maxX, maxY int) error {
 	width := maxX * 85 / 100
 	if width < 40 {
@@ -0,0 +1,107 @@
+package gui
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/donnellyk/lazyruin/pkg/merge"
+	"github.com/donnellyk/lazyruin/pkg/models"
+)
+
+// openEditThenChangeOnDisk opens the edit popup on a note, then rewrites
+// the file as another process would.
+func openEditThenChangeOnDisk(t *testing.T, body, external string) (*testGui, string) {
+	t.Helper()
+	dir := t.TempDir()
+	notePath := writeNote(t, dir, "merge.md", "---\nuuid: merge-1\n---\n\n"+body)
+	mock := defaultMock().WithNotes(
+		models.Note{UUID: "merge-1", Title: "Merge Test", Path: notePath, Created: time.Now()},
+	)
+	tg := newTestGui(t, mock)
+	note := tg.gui.contexts.Notes.Items[0]
+	if err := tg.gui.helpers.Capture().OpenCaptureForEdit(&note); err != nil {
+		t.Fatalf("OpenCaptureForEdit: %v", err)
+	}
+	if err := os.WriteFile(notePath, []byte("---\nuuid: merge-1\n---\n\n"+external), 0644); err != nil {
+		t.Fatal(err)
+	}
+	future := time.Now().Add(2 * time.Second)
+	if err := os.Chtimes(notePath, future, future); err != nil {
+		t.Fatal(err)
+	}
+	return tg, notePath
+}
+
+func readBody(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, body, _ := strings.Cut(string(data), "---\n\n")
+	return body
+}
+
+func TestSubmitEdit_ExternalChange_MergesCleanly(t *testing.T) {
+	tg, notePath := openEditThenChangeOnDisk(t, "one\ntwo\nthree\n", "one\ntwo\nthree\nfour\n")
+	defer tg.Close()
+
+	if err := tg.gui.helpers.Capture().SubmitCapture("ONE\ntwo\nthree", false); err != nil {
+		t.Fatalf("SubmitCapture: %v", err)
+	}
+	if got := readBody(t, notePath); got != "ONE\ntwo\nthree\nfour\n" {
+		t.Errorf("body = %q, want both changes merged", got)
+	}
+	if tg.gui.contextMgr.Contains("capture") {
+		t.Error("capture popup should close after a clean merge")
+	}
+}
+
+func TestSubmitEdit_ExternalChange_ResolveConflict(t *testing.T) {
+	tg, notePath := openEditThenChangeOnDisk(t, "title\nbody\n", "title\ntheirs\n")
+	defer tg.Close()
+
+	if err := tg.gui.helpers.Capture().SubmitCapture("title\nmine", false); err != nil {
+		t.Fatalf("SubmitCapture: %v", err)
+	}
+	if tg.gui.contextMgr.Current() != "mergeConflict" {
+		t.Fatalf("current context = %v, want mergeConflict", tg.gui.contextMgr.Current())
+	}
+	tg.g.ForceLayoutAndRedraw()
+	view := tg.gui.GetView(MergeConflictView).Buffer()
+	if !strings.Contains(view, "mine") || !strings.Contains(view, "theirs") {
+		t.Errorf("resolution view should show both sides, got:\n%s", view)
+	}
+
+	mc := tg.gui.helpers.MergeConflict()
+	// Saving with an unresolved conflict does nothing.
+	mc.Save()
+	if tg.gui.contextMgr.Current() != "mergeConflict" {
+		t.Fatal("Save with unresolved conflicts should keep the popup open")
+	}
+
+	mc.Choose(merge.Both)
+	mc.Save()
+	if got := readBody(t, notePath); got != "title\nmine\ntheirs\n" {
+		t.Errorf("body = %q, want both sides kept", got)
+	}
+	if tg.gui.contextMgr.Contains("capture") || tg.gui.contextMgr.Contains("mergeConflict") {
+		t.Errorf("popups should close after saving, current context: %v", tg.gui.contextMgr.Current())
+	}
+}
+
+func TestMergeConflict_CancelReturnsToEdit(t *testing.T) {
+	tg, notePath := openEditThenChangeOnDisk(t, "a\n", "b\n")
+	defer tg.Close()
+
+	tg.gui.helpers.Capture().SubmitCapture("c", false)
+	tg.gui.helpers.MergeConflict().Cancel()
+	if tg.gui.contextMgr.Current() != "capture" {
+		t.Errorf("current context = %v, want capture", tg.gui.contextMgr.Current())
+	}
+	if got := readBody(t, notePath); got != "b\n" {
+		t.Errorf("file should be untouched, got %q", got)
+	}
+}
@@ -123,8 +123,8 @@ func (gui *Gui) createMigrationRunningDialog(g *gocui.Gui, maxX, maxY int) error
 	v.Title = " " + gui.state.Dialog.Title + " "
 	v.Footer = ""
 	setRoundedCorners(v)
-	v.FrameColor = gocui.ColorYellow
-	v.TitleColor = gocui.ColorYellow
+	v.FrameColor = theme.FrameAlert
+	v.TitleColor = theme.FrameAlert
 	v.Clear()
 
 	fmt.Fprintln(v, "")
@@ -0,0 +1,109 @@
+package gui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/donnellyk/lazyruin/pkg/models"
+)
+
+func TestBulkAddTag_AppliesToEverySelectedNoteAsOneUndo(t *testing.T) {
+	mock := defaultMock()
+	tg := newTestGui(t, mock)
+	defer tg.Close()
+
+	tg.gui.globalController.FocusNotes()
+	notes := tg.gui.contexts.Notes
+	notes.Selection.Toggle("2")
+	notes.Selection.Toggle("4")
+
+	if err := tg.gui.helpers.NoteActions().AddGlobalTag(); err != nil {
+		t.Fatal(err)
+	}
+	cfg := tg.gui.contexts.InputPopup.Config
+	if cfg == nil || cfg.Title != "Add Tag (2 notes)" {
+		t.Fatalf("input popup = %+v, want bulk Add Tag", cfg)
+	}
+
+	mock.Calls = nil
+	if err := cfg.OnAccept("#urgent", nil); err != nil {
+		t.Fatal(err)
+	}
+	for _, uuid := range []string{"2", "4"} {
+		if !hasCall(mock.Calls, "note", "set", uuid, "--add-tag", "#urgent", "-f") {
+			t.Errorf("note %s should be tagged; calls=%v", uuid, mock.Calls)
+		}
+	}
+
+	undo := tg.gui.helpers.Undo()
+	if n := len(undo.Entries()); n != 1 {
+		t.Fatalf("journal has %d entries, want one for the batch", n)
+	}
+	mock.Calls = nil
+	_ = undo.Undo()
+	for _, uuid := range []string{"2", "4"} {
+		if !hasCall(mock.Calls, "note", "set", uuid, "--remove-tag", "#urgent", "-f") {
+			t.Errorf("undo should untag note %s; calls=%v", uuid, mock.Calls)
+		}
+	}
+}
+
+func TestBulkRemoveParent_RangeSelection(t *testing.T) {
+	mock := defaultMock()
+	tg := newTestGui(t, mock)
+	defer tg.Close()
+
+	tg.gui.globalController.FocusNotes()
+	notes := tg.gui.contexts.Notes
+	notes.SetSelectedLineIdx(1)
+	_ = tg.gui.helpers.Selection().ToggleRange()
+	notes.SetSelectedLineIdx(3)
+
+	mock.Calls = nil
+	if err := tg.gui.helpers.NoteActions().RemoveParent(); err != nil {
+		t.Fatal(err)
+	}
+	for _, uuid := range []string{"2", "3", "4"} {
+		if !hasCall(mock.Calls, "note", "set", uuid, "--no-parent", "-f") {
+			t.Errorf("note %s should lose its parent; calls=%v", uuid, mock.Calls)
+		}
+	}
+	if hasCall(mock.Calls, "note", "set", "1", "--no-parent", "-f") {
+		t.Error("note outside the range should be untouched")
+	}
+}
+
+func TestBulkDelete_OneConfirmAggregatedErrors(t *testing.T) {
+	dir := t.TempDir()
+	real := writeNote(t, dir, "real.md", "---\nuuid: r-1\n---\n\nbody\n")
+	mock := defaultMock().WithNotes(
+		models.Note{UUID: "r-1", Title: "Real", Path: real},
+		models.Note{UUID: "m-1", Title: "Missing", Path: dir + "/missing.md"},
+	)
+	tg := newTestGui(t, mock)
+	defer tg.Close()
+
+	tg.gui.globalController.FocusNotes()
+	notes := tg.gui.contexts.Notes
+	notes.Selection.Toggle("r-1")
+	notes.Selection.Toggle("m-1")
+	if err := tg.gui.helpers.Notes().DeleteNotes(notes.SelectedNotes(), func(map[string]bool) {}); err != nil {
+		t.Fatal(err)
+	}
+	dialog := tg.gui.state.Dialog
+	if dialog == nil || dialog.Type != "confirm" {
+		t.Fatalf("want a single confirm dialog, got %+v", dialog)
+	}
+
+	mock.Calls = nil
+	_ = dialog.OnConfirm()
+	if !hasCall(mock.Calls, "note", "delete", "r-1", "-f") {
+		t.Errorf("readable note should be deleted; calls=%v", mock.Calls)
+	}
+	if hasCall(mock.Calls, "note", "delete", "m-1", "-f") {
+		t.Error("note that couldn't be copied to the trash must not be deleted")
+	}
+	if status := tg.gui.views.Status.Buffer(); !strings.Contains(status, "1 of 2 notes failed: Missing:") {
+		t.Errorf("want one aggregated error naming the failed note, status = %q", status)
+	}
+}
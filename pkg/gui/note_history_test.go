@@ -0,0 +1,130 @@
+package gui
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/donnellyk/lazyruin/pkg/models"
+	"github.com/donnellyk/lazyruin/pkg/testutil"
+)
+
+// gitVault creates a vault directory that is a git repo with a committer
+// identity, so commits work without a global git config.
+func gitVault(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"config", "user.name", "Test"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "commit.gpgsign", "false"},
+	} {
+		gitRun(t, dir, args...)
+	}
+	return dir
+}
+
+func gitRun(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+	return string(out)
+}
+
+func TestNoteHistory_ShowsDiffAndRestoresRevision(t *testing.T) {
+	vault := gitVault(t)
+	path := writeNote(t, vault, "hist.md", "---\nuuid: h-1\n---\n\nfirst version\n")
+	gitRun(t, vault, "add", "-A")
+	gitRun(t, vault, "commit", "-q", "-m", "add hist")
+	writeNote(t, vault, "hist.md", "---\nuuid: h-1\ntags: [\"#new\"]\n---\n\nsecond version\n")
+	gitRun(t, vault, "commit", "-q", "-am", "rewrite hist")
+
+	mock := testutil.NewMockExecutor().
+		WithVaultPath(vault).
+		WithNotes(models.Note{UUID: "h-1", Title: "Hist", Path: path})
+	tg := newTestGui(t, mock)
+	defer tg.Close()
+
+	if err := tg.gui.helpers.PreviewNav().OpenNoteByUUID("h-1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := tg.gui.helpers.Git().OpenNoteHistory(); err != nil {
+		t.Fatal(err)
+	}
+	nh := tg.gui.contexts.NoteHistory
+	if tg.gui.contextMgr.Current() != "noteHistory" || len(nh.Revisions) != 2 || nh.Revisions[0].Subject != "rewrite hist" {
+		t.Fatalf("history = %+v, want both commits newest first", nh.Revisions)
+	}
+
+	tg.g.ForceLayoutAndRedraw()
+	if buf := tg.gui.GetView(PreviewView).Buffer(); !strings.Contains(buf, "-first version") || !strings.Contains(buf, "+second version") {
+		t.Errorf("preview should show the selected diff, got:\n%s", buf)
+	}
+
+	if err := tg.gui.helpers.Git().Move(1); err != nil {
+		t.Fatal(err)
+	}
+	if err := tg.gui.helpers.Git().Restore(); err != nil {
+		t.Fatal(err)
+	}
+	d := tg.gui.state.Dialog
+	if d == nil || d.OnConfirm == nil {
+		t.Fatal("restore should ask for confirmation")
+	}
+	tg.gui.state.Dialog = nil
+	if err := d.OnConfirm(); err != nil {
+		t.Fatal(err)
+	}
+
+	got, _ := os.ReadFile(path)
+	if want := "---\nuuid: h-1\ntags: [\"#new\"]\n---\n\nfirst version\n"; string(got) != want {
+		t.Errorf("restored file = %q, want the old body under the current frontmatter %q", got, want)
+	}
+	if tg.gui.contextMgr.Current() == "noteHistory" {
+		t.Error("restoring should close the history popup")
+	}
+
+	if err := tg.gui.helpers.Undo().Undo(); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := os.ReadFile(path); !strings.Contains(string(got), "second version") {
+		t.Errorf("undo should bring back the current body, got %q", got)
+	}
+}
+
+func TestNoteHistory_AutoCommitsChanges(t *testing.T) {
+	vault := gitVault(t)
+	path := writeNote(t, vault, "auto.md", "---\nuuid: a-1\n---\n\nbody\n")
+	gitRun(t, vault, "add", "-A")
+	gitRun(t, vault, "commit", "-q", "-m", "init")
+
+	tg := newTestGui(t, testutil.NewMockExecutor().WithVaultPath(vault))
+	defer tg.Close()
+	tg.gui.config.Git.AutoCommit = true
+	tg.gui.config.Git.CommitMessage = "notes({vault}): {action}"
+
+	err := tg.gui.helpers.Undo().RecordSnapshot("Edit Auto", []string{path}, func() error {
+		return os.WriteFile(path, []byte("---\nuuid: a-1\n---\n\nchanged\n"), 0o644)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "notes(" + vault[strings.LastIndex(vault, "/")+1:] + "): Edit Auto"
+	if got := strings.TrimSpace(gitRun(t, vault, "log", "-1", "--format=%s")); got != want {
+		t.Errorf("last commit = %q, want %q", got, want)
+	}
+
+	if err := tg.gui.helpers.Undo().Undo(); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(gitRun(t, vault, "log", "-1", "--format=%s")); !strings.HasSuffix(got, "Undo Edit Auto") {
+		t.Errorf("last commit after undo = %q", got)
+	}
+}
@@ -7,16 +7,24 @@ import (
 	"github.com/donnellyk/lazyruin/pkg/models"
 )
 
-// openInitialRef resolves the --open reference and shows it in the preview.
-// Resolution order: parent bookmark name, then note by path, then note by title.
+// openInitialRef resolves the --open reference and shows it in the preview,
+// falling back to today's date preview when nothing matches.
 func (gui *Gui) openInitialRef(ref string) {
+	if !gui.openRef(ref) {
+		gui.helpers.DatePreview().LoadDatePreview(time.Now().Format("2006-01-02"))
+	}
+}
+
+// openRef shows ref in the preview and reports whether it matched.
+// Resolution order: parent bookmark name, then note by path, then note by title.
+func (gui *Gui) openRef(ref string) bool {
 	// 1. Try parent bookmark
 	parents, err := gui.ruinCmd.Parent.List()
 	if err == nil {
 		for _, p := range parents {
 			if p.Name == ref {
 				gui.openParentBookmark(&p)
-				return
+				return true
 			}
 		}
 	}
@@ -27,18 +35,16 @@ func (gui *Gui) openInitialRef(ref string) {
 	note, err := gui.ruinCmd.Search.GetByPath(ref, opts)
 	if err == nil && note != nil {
 		gui.openNote(note)
-		return
+		return true
 	}
 
 	// 3. Try note by title
 	note, err = gui.ruinCmd.Search.GetByTitle(ref, opts)
 	if err == nil && note != nil {
 		gui.openNote(note)
-		return
+		return true
 	}
-
-	// 4. Fallback to today view
-	gui.helpers.DatePreview().LoadDatePreview(time.Now().Format("2006-01-02"))
+	return false
 }
 
 func (gui *Gui) openNote(note *models.Note) {
@@ -59,9 +65,7 @@ func (gui *Gui) openParentBookmark(parent *models.ParentBookmark) {
 	}
 	_ = gui.helpers.Navigator().NavigateTo("compose", "Parent: "+parentCopy.Name, func() error {
 		gui.helpers.Preview().ShowCompose("Parent: "+parentCopy.Name, composed, sourceMap, parentCopy)
-		gui.contexts.Compose.Requery = func() (models.Note, []models.SourceMapEntry, error) {
-			return gui.ruinCmd.Parent.Compose(parentCopy)
-		}
+		gui.contexts.Compose.Requery = gui.helpers.Preview().ComposeRequery(parentCopy)
 		return nil
 	})
 }
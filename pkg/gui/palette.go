@@ -5,7 +5,10 @@ import (
 	"slices"
 	"sort"
 	"strings"
+	"time"
 
+	"github.com/donnellyk/lazyruin/pkg/frecency"
+	"github.com/donnellyk/lazyruin/pkg/fuzzy"
 	"github.com/donnellyk/lazyruin/pkg/gui/context"
 	"github.com/donnellyk/lazyruin/pkg/gui/types"
 
@@ -26,7 +29,6 @@ func (gui *Gui) paletteCommands() []types.PaletteCommand {
 	cmds = append(cmds, gui.paletteOnlyCommands()...)
 
 	// Controller bindings
-	opts := types.KeybindingsOpts{}
 	activePreview := gui.contexts.ActivePreviewKey
 
 	type previewCmd struct {
@@ -44,7 +46,7 @@ func (gui *Gui) paletteCommands() []types.PaletteCommand {
 			continue
 		}
 		isPreview := context.IsPreviewContextKey(ctxKey)
-		for _, b := range ctx.GetKeybindings(opts) {
+		for _, b := range gui.contextBindings(ctx) {
 			if b.Description == "" || b.Category == "Navigation" {
 				continue
 			}
@@ -113,6 +115,7 @@ func (gui *Gui) openPalette(g *gocui.Gui, v *gocui.View) error {
 	}
 
 	cmds := gui.paletteCommands()
+	gui.frecency = gui.loadFrecency()
 
 	gui.contexts.Palette.Palette = &types.PaletteState{
 		Commands: cmds,
@@ -123,6 +126,23 @@ func (gui *Gui) openPalette(g *gocui.Gui, v *gocui.View) error {
 	return nil
 }
 
+// ShowPaletteList opens the palette over a fixed list of entries instead
+// of the commands, such as the search history. Frecency doesn't apply, so
+// entries keep their given order until a filter is typed.
+func (gui *Gui) ShowPaletteList(title, footer string, items []types.PaletteCommand) {
+	if gui.popupActive() {
+		return
+	}
+	gui.frecency = nil
+	gui.contexts.Palette.Palette = &types.PaletteState{
+		Commands: items,
+		Title:    title,
+		Footer:   footer,
+	}
+	gui.filterPaletteCommands("")
+	gui.pushContextByKey("palette")
+}
+
 // closePalette tears down the palette popup and restores previous context.
 func (gui *Gui) closePalette() {
 	if gui.contexts.Palette.Palette == nil {
@@ -141,9 +161,13 @@ func (gui *Gui) openQuickOpen(g *gocui.Gui, v *gocui.View) error {
 }
 
 // Re-filter based on current text; ":" prefix switches to Quick Open mode
+// unless the palette is showing a fixed list
 func (gui *Gui) refreshPaletteFromBuffer(v *gocui.View) {
 	content := strings.TrimSpace(v.TextArea.GetContent())
-	if after, ok := strings.CutPrefix(content, ":"); ok {
+	if p := gui.contexts.Palette.Palette; p != nil && p.Title != "" {
+		gui.filterPaletteCommands(content)
+		gui.views.Palette.Title = " " + p.Title + " "
+	} else if after, ok := strings.CutPrefix(content, ":"); ok {
 		gui.filterQuickOpenItems(after)
 		gui.views.Palette.Title = " Open "
 	} else {
@@ -172,62 +196,116 @@ func (gui *Gui) executePaletteCommand() error {
 		return nil
 	}
 
+	gui.recordFrecency(cmd)
+
 	// Close palette first, then execute (so commands that open popups work)
 	gui.closePalette()
 	return cmd.OnRun()
 }
 
-// filterPaletteCommands filters commands by a case-insensitive substring match.
+// savePaletteEntry closes the palette and runs the selected entry's
+// OnSave, if it has one.
+func (gui *Gui) savePaletteEntry() error {
+	p := gui.contexts.Palette.Palette
+	if p == nil || p.SelectedIndex < 0 || p.SelectedIndex >= len(p.Filtered) {
+		return nil
+	}
+	cmd := p.Filtered[p.SelectedIndex]
+	if cmd.OnSave == nil {
+		return nil
+	}
+	gui.closePalette()
+	return cmd.OnSave()
+}
+
+// filterPaletteCommands fuzzy-matches commands against filter and ranks
+// them by match score plus frecency. An empty filter lists everything,
+// most used first.
 func (gui *Gui) filterPaletteCommands(filter string) {
 	if gui.contexts.Palette.Palette == nil {
 		return
 	}
 
 	gui.contexts.Palette.Palette.FilterText = filter
-	lower := strings.ToLower(filter)
 
-	var available, unavailable []types.PaletteCommand
+	var available, unavailable []rankedCommand
 	origin := gui.contextMgr.Previous()
 
 	for _, cmd := range gui.contexts.Palette.Palette.Commands {
-		match := lower == "" ||
-			strings.Contains(strings.ToLower(cmd.Name), lower) ||
-			strings.Contains(strings.ToLower(cmd.Category), lower)
-		if !match {
+		rc, ok := gui.rankPaletteCommand(filter, cmd)
+		if !ok {
 			continue
 		}
 		if isPaletteCommandAvailable(cmd, origin) {
-			available = append(available, cmd)
+			available = append(available, rc)
 		} else {
-			unavailable = append(unavailable, cmd)
+			unavailable = append(unavailable, rc)
 		}
 	}
 
-	sortCmds := func(cmds []types.PaletteCommand) {
-		if lower == "" {
-			sort.Slice(cmds, func(i, j int) bool {
-				return cmds[i].Name < cmds[j].Name
-			})
-			return
+	keepOrder := gui.contexts.Palette.Palette.Title != ""
+	sortRanked(available, keepOrder)
+	sortRanked(unavailable, keepOrder)
+	gui.setPaletteFiltered(append(available, unavailable...))
+}
+
+// rankedCommand is a palette entry that matched the filter.
+type rankedCommand struct {
+	cmd       types.PaletteCommand
+	score     int
+	positions []int
+}
+
+// paletteLabel is the text a palette entry is shown and matched as.
+func paletteLabel(cmd types.PaletteCommand) string {
+	return fmt.Sprintf("%s: %s", cmd.Category, cmd.Name)
+}
+
+// paletteMatchNameBonus puts matches within a command's name ahead of
+// ones that need its category.
+const paletteMatchNameBonus = 20
+
+// rankPaletteCommand matches filter against cmd's name, then its whole
+// label, and adds its frecency boost to the score.
+func (gui *Gui) rankPaletteCommand(filter string, cmd types.PaletteCommand) (rankedCommand, bool) {
+	score, positions, ok := fuzzy.Match(filter, cmd.Name)
+	if ok {
+		offset := len([]rune(cmd.Category)) + 2
+		for i := range positions {
+			positions[i] += offset
 		}
-		inputLen := len(lower)
-		sort.Slice(cmds, func(i, j int) bool {
-			di := len(cmds[i].Name) - inputLen
-			dj := len(cmds[j].Name) - inputLen
-			if di != dj {
-				return di < dj
-			}
-			return cmds[i].Name < cmds[j].Name
-		})
+		score += paletteMatchNameBonus
+	} else if score, positions, ok = fuzzy.Match(filter, paletteLabel(cmd)); !ok {
+		return rankedCommand{}, false
 	}
-	sortCmds(available)
-	sortCmds(unavailable)
+	return rankedCommand{cmd: cmd, score: score + gui.frecencyBoost(cmd), positions: positions}, true
+}
 
-	gui.contexts.Palette.Palette.Filtered = append(available, unavailable...)
+// sortRanked orders by score, then name, or with keepOrder leaves ties in
+// the order given.
+func sortRanked(cmds []rankedCommand, keepOrder bool) {
+	sort.SliceStable(cmds, func(i, j int) bool {
+		if cmds[i].score != cmds[j].score {
+			return cmds[i].score > cmds[j].score
+		}
+		return !keepOrder && cmds[i].cmd.Name < cmds[j].cmd.Name
+	})
+}
+
+// setPaletteFiltered stores ranked entries as the palette's filtered list
+// and clamps the selection.
+func (gui *Gui) setPaletteFiltered(ranked []rankedCommand) {
+	p := gui.contexts.Palette.Palette
+	p.Filtered = make([]types.PaletteCommand, len(ranked))
+	p.Matches = make([][]int, len(ranked))
+	for i, rc := range ranked {
+		p.Filtered[i] = rc.cmd
+		p.Matches[i] = rc.positions
+	}
 
 	// Clamp selection
-	if gui.contexts.Palette.Palette.SelectedIndex >= len(gui.contexts.Palette.Palette.Filtered) {
-		gui.contexts.Palette.Palette.SelectedIndex = max(0, len(gui.contexts.Palette.Palette.Filtered)-1)
+	if p.SelectedIndex >= len(p.Filtered) {
+		p.SelectedIndex = max(0, len(p.Filtered)-1)
 	}
 }
 
@@ -306,6 +384,10 @@ func (gui *Gui) renderPaletteList() {
 
 	filtered := gui.contexts.Palette.Palette.Filtered
 	if len(filtered) == 0 {
+		if gui.contexts.Palette.Palette.Title != "" {
+			fmt.Fprintln(v, " No matching entries.")
+			return
+		}
 		fmt.Fprintln(v, " No matching commands.")
 		return
 	}
@@ -322,24 +404,66 @@ func (gui *Gui) renderPaletteList() {
 		return s + strings.Repeat(" ", max(0, width-len([]rune(s))))
 	}
 
+	matches := gui.contexts.Palette.Palette.Matches
 	for i, cmd := range filtered {
 		avail := isPaletteCommandAvailable(cmd, originCtx)
 
 		key := cmd.Key
-		label := fmt.Sprintf("%s: %s", cmd.Category, cmd.Name)
+		label := paletteLabel(cmd)
 		keyPad := max(keyCol-len(key), 1)
+		var positions []int
+		if i < len(matches) {
+			positions = matches[i]
+		}
+		detail := ""
+		if cmd.Detail != "" {
+			detail = " · " + cmd.Detail
+		}
 
 		if i == gui.contexts.Palette.Palette.SelectedIndex {
-			line := " " + key + strings.Repeat(" ", keyPad) + label
-			fmt.Fprintf(v, "%s%s%s\n", AnsiBlueBgWhite, pad(line), AnsiReset)
+			line := pad(" " + key + strings.Repeat(" ", keyPad) + label + detail)
+			offset := 1 + len([]rune(key)) + keyPad
+			fmt.Fprintf(v, "%s%s%s\n", theme.Selected, highlightMatches(line, shiftPositions(positions, offset), AnsiBold, AnsiNoBold), AnsiReset)
 		} else if !avail {
-			fmt.Fprintf(v, "%s %s%-*s%s%s\n", AnsiDim, AnsiGreen, keyCol, key, AnsiReset+AnsiDim, label)
+			label = highlightMatches(label, positions, theme.Accent, AnsiReset+theme.Muted)
+			fmt.Fprintf(v, "%s %s%-*s%s%s%s%s\n", theme.Muted, theme.Key, keyCol, key, AnsiReset+theme.Muted, label, detail, AnsiReset)
 		} else {
-			fmt.Fprintf(v, " %s%-*s%s%s\n", AnsiGreen, keyCol, key, AnsiReset, label)
+			label = highlightMatches(label, positions, theme.Accent, AnsiReset)
+			fmt.Fprintf(v, " %s%-*s%s%s%s%s%s\n", theme.Key, keyCol, key, AnsiReset, label, theme.Muted, detail, AnsiReset)
 		}
 	}
 }
 
+// highlightMatches wraps the runes of s at positions in on/off.
+func highlightMatches(s string, positions []int, on, off string) string {
+	if len(positions) == 0 {
+		return s
+	}
+	hit := make(map[int]bool, len(positions))
+	for _, p := range positions {
+		hit[p] = true
+	}
+	var b strings.Builder
+	for i, r := range []rune(s) {
+		if hit[i] {
+			b.WriteString(on)
+			b.WriteRune(r)
+			b.WriteString(off)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func shiftPositions(positions []int, by int) []int {
+	out := make([]int, len(positions))
+	for i, p := range positions {
+		out[i] = p + by
+	}
+	return out
+}
+
 // quickOpenItems builds PaletteCommand entries from all navigable items in rank order.
 func (gui *Gui) quickOpenItems() []types.PaletteCommand {
 	var items []types.PaletteCommand
@@ -351,6 +475,7 @@ func (gui *Gui) quickOpenItems() []types.PaletteCommand {
 		items = append(items, types.PaletteCommand{
 			Name:     query.Name,
 			Category: "Query",
+			ID:       "query:" + query.Name,
 			OnRun: func() error {
 				gui.contexts.Queries.CurrentTab = context.QueriesTabQueries
 				gui.contexts.Queries.QueriesTrait().SetSelectedLineIdx(idx)
@@ -367,6 +492,7 @@ func (gui *Gui) quickOpenItems() []types.PaletteCommand {
 		items = append(items, types.PaletteCommand{
 			Name:     gui.contexts.Queries.Parents[idx].Name,
 			Category: "Parent",
+			ID:       "parent:" + gui.contexts.Queries.Parents[idx].UUID,
 			OnRun: func() error {
 				gui.contexts.Queries.CurrentTab = context.QueriesTabParents
 				gui.contexts.Queries.ParentsTrait().SetSelectedLineIdx(idx)
@@ -388,6 +514,7 @@ func (gui *Gui) quickOpenItems() []types.PaletteCommand {
 			items = append(items, types.PaletteCommand{
 				Name:     name,
 				Category: "Tag",
+				ID:       "tag:" + name,
 				OnRun: func() error {
 					gui.pushContextByKey("tags")
 					return gui.helpers.Tags().FilterByTagPick(&tag)
@@ -397,6 +524,7 @@ func (gui *Gui) quickOpenItems() []types.PaletteCommand {
 			items = append(items, types.PaletteCommand{
 				Name:     name,
 				Category: "Tag",
+				ID:       "tag:" + name,
 				OnRun: func() error {
 					gui.pushContextByKey("tags")
 					return gui.helpers.Tags().FilterByTagSearch(&tag)
@@ -416,6 +544,8 @@ func (gui *Gui) quickOpenItems() []types.PaletteCommand {
 		items = append(items, types.PaletteCommand{
 			Name:     n.Title,
 			Category: "Note",
+			ID:       "note:" + n.UUID,
+			Body:     n.Content,
 			OnRun: func() error {
 				gui.contexts.Notes.SetSelectedLineIdx(idx)
 				gui.pushContextByKey("notes")
@@ -429,28 +559,109 @@ func (gui *Gui) quickOpenItems() []types.PaletteCommand {
 	return items
 }
 
-// filterQuickOpenItems filters Quick Open items by case-insensitive substring match.
+// filterQuickOpenItems fuzzy-matches Quick Open items against filter,
+// ranked like palette commands. Notes whose title doesn't match are then
+// searched by content, and listed after the title matches with the
+// matching line beside them.
 func (gui *Gui) filterQuickOpenItems(filter string) {
 	if gui.contexts.Palette.Palette == nil {
 		return
 	}
 
 	gui.contexts.Palette.Palette.FilterText = filter
-	lower := strings.ToLower(filter)
 
-	var filtered []types.PaletteCommand
+	var matched, byContent []rankedCommand
 	for _, item := range gui.quickOpenItems() {
-		if lower == "" ||
-			strings.Contains(strings.ToLower(item.Name), lower) ||
-			strings.Contains(strings.ToLower(item.Category), lower) {
-			filtered = append(filtered, item)
+		if rc, ok := gui.rankPaletteCommand(filter, item); ok {
+			matched = append(matched, rc)
+			continue
 		}
+		if line := contentMatch(filter, item.Body); line != "" {
+			item.Detail = line
+			byContent = append(byContent, rankedCommand{cmd: item, score: gui.frecencyBoost(item)})
+		}
+	}
+	if filter == "" {
+		// Keep the section order (queries, parents, tags, notes) for
+		// unused items; frecency still floats the used ones up.
+		sort.SliceStable(matched, func(i, j int) bool { return matched[i].score > matched[j].score })
+	} else {
+		sortRanked(matched, false)
 	}
+	sortRanked(byContent, false)
 
-	gui.contexts.Palette.Palette.Filtered = filtered
+	gui.setPaletteFiltered(append(matched, byContent...))
+}
 
-	// Clamp selection
-	if gui.contexts.Palette.Palette.SelectedIndex >= len(gui.contexts.Palette.Palette.Filtered) {
-		gui.contexts.Palette.Palette.SelectedIndex = max(0, len(gui.contexts.Palette.Palette.Filtered)-1)
+// contentMatch returns the first line of body containing every word of
+// filter, case-insensitively, trimmed for display. Empty when none does.
+func contentMatch(filter, body string) string {
+	words := strings.Fields(strings.ToLower(filter))
+	if len(words) == 0 || body == "" {
+		return ""
+	}
+	for line := range strings.SplitSeq(body, "\n") {
+		lower := strings.ToLower(line)
+		all := true
+		for _, w := range words {
+			if !strings.Contains(lower, w) {
+				all = false
+				break
+			}
+		}
+		if all {
+			line = strings.TrimSpace(line)
+			if r := []rune(line); len(r) > 60 {
+				line = string(r[:59]) + "…"
+			}
+			return line
+		}
+	}
+	return ""
+}
+
+// Frecency boosts are frecency scores scaled into match-score points and
+// capped, so a command run a few times today outranks a slightly better
+// match without burying an exact one.
+const (
+	frecencyBoostScale = 4
+	maxFrecencyBoost   = 80
+)
+
+// loadFrecency reads the active vault's frecency store. Without a vault,
+// or when the file can't be read, ranking ignores frecency.
+func (gui *Gui) loadFrecency() *frecency.Store {
+	if gui.ruinCmd == nil {
+		return nil
+	}
+	store := frecency.NewStoreForVault(gui.ruinCmd.VaultPath())
+	if err := store.Load(); err != nil {
+		return nil
+	}
+	return store
+}
+
+// frecencyKey identifies a palette entry in the frecency store.
+func frecencyKey(cmd types.PaletteCommand) string {
+	if cmd.ID != "" {
+		return cmd.ID
+	}
+	return "cmd:" + cmd.Category + ":" + cmd.Name
+}
+
+func (gui *Gui) frecencyBoost(cmd types.PaletteCommand) int {
+	if gui.frecency == nil {
+		return 0
+	}
+	return min(int(gui.frecency.Score(frecencyKey(cmd), time.Now())*frecencyBoostScale), maxFrecencyBoost)
+}
+
+// recordFrecency counts a run of cmd. Saving is best-effort: a failure
+// only loses ranking history.
+func (gui *Gui) recordFrecency(cmd types.PaletteCommand) {
+	if gui.frecency == nil {
+		return
 	}
+	gui.frecency.Record(frecencyKey(cmd), time.Now())
+	_ = gui.frecency.Save()
 }
@@ -1,10 +1,13 @@
 package gui
 
 import (
+	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 	"testing"
 
+	"github.com/donnellyk/lazyruin/pkg/frecency"
 	"github.com/donnellyk/lazyruin/pkg/gui/context"
 	"github.com/donnellyk/lazyruin/pkg/gui/types"
 	"github.com/donnellyk/lazyruin/pkg/models"
@@ -788,6 +791,7 @@ func TestKeyDisplayString(t *testing.T) {
 		{gocui.KeyCtrlR, "<c-r>"},
 		{gocui.KeyCtrlC, "<c-c>"},
 		{gocui.KeyTab, "tab"},
+		{gocui.KeyF5, "f5"},
 	}
 	for _, tt := range tests {
 		got := keyDisplayString(tt.key)
@@ -796,3 +800,85 @@ func TestKeyDisplayString(t *testing.T) {
 		}
 	}
 }
+
+// --- Fuzzy ranking, frecency and content search ---
+
+func TestFilterPaletteCommands_FuzzyWords(t *testing.T) {
+	gui := &Gui{state: NewGuiState(), contextMgr: NewContextMgr(), contexts: &context.ContextTree{Palette: context.NewPaletteContext()}}
+	gui.contexts.Palette.Palette = &types.PaletteState{
+		Commands: []types.PaletteCommand{
+			{Name: "Meeting notes", Category: "Note"},
+			{Name: "Toggle Frontmatter", Category: "Preview"},
+			{Name: "Monthly targets and notes", Category: "Note"},
+		},
+	}
+
+	gui.filterPaletteCommands("mtg nts")
+
+	p := gui.contexts.Palette.Palette
+	if len(p.Filtered) != 2 || p.Filtered[0].Name != "Meeting notes" {
+		t.Fatalf("Filtered = %+v, want Meeting notes first of 2", p.Filtered)
+	}
+	// "Note: " precedes the name in the label.
+	if want := []int{6, 9, 12, 14, 16, 18}; !slices.Equal(p.Matches[0], want) {
+		t.Errorf("Matches[0] = %v, want %v", p.Matches[0], want)
+	}
+}
+
+func TestFilterPaletteCommands_FrecencyBoost(t *testing.T) {
+	gui := &Gui{state: NewGuiState(), contextMgr: NewContextMgr(), contexts: &context.ContextTree{Palette: context.NewPaletteContext()}}
+	gui.frecency = frecency.NewStoreWithPath(filepath.Join(t.TempDir(), "f.json"))
+	gui.contexts.Palette.Palette = &types.PaletteState{
+		Commands: []types.PaletteCommand{
+			{Name: "Search", Category: "Global"},
+			{Name: "Search Filter", Category: "Global"},
+			{Name: "Quit", Category: "Global"},
+		},
+	}
+	for range 3 {
+		gui.recordFrecency(types.PaletteCommand{Name: "Search Filter", Category: "Global"})
+	}
+
+	gui.filterPaletteCommands("")
+	if got := gui.contexts.Palette.Palette.Filtered[0].Name; got != "Search Filter" {
+		t.Errorf("empty filter: first = %q, want the most used command", got)
+	}
+	gui.filterPaletteCommands("search")
+	if got := gui.contexts.Palette.Palette.Filtered[0].Name; got != "Search Filter" {
+		t.Errorf("filtered: first = %q, want the used command over the shorter match", got)
+	}
+}
+
+func TestQuickOpen_MatchesNoteContent(t *testing.T) {
+	mock := defaultMock().WithNotes(
+		models.Note{UUID: "1", Title: "Planning", Content: "# Planning\nQ3 budget review with finance\n"},
+		models.Note{UUID: "2", Title: "Budget", Content: "numbers"},
+	)
+	tg := newTestGui(t, mock)
+	defer tg.Close()
+
+	if err := tg.gui.openQuickOpen(tg.g, nil); err != nil {
+		t.Fatal(err)
+	}
+	tg.gui.filterQuickOpenItems("budget review")
+
+	var found *types.PaletteCommand
+	for i, item := range tg.gui.contexts.Palette.Palette.Filtered {
+		if item.Name == "Planning" {
+			found = &tg.gui.contexts.Palette.Palette.Filtered[i]
+		}
+	}
+	if found == nil {
+		t.Fatalf("Planning should match by content, got %+v", tg.gui.contexts.Palette.Palette.Filtered)
+	}
+	if found.Detail != "Q3 budget review with finance" {
+		t.Errorf("Detail = %q, want the matching line", found.Detail)
+	}
+}
+
+func TestHighlightMatches(t *testing.T) {
+	got := highlightMatches("Quit", []int{0, 2}, "<", ">")
+	if got != "<Q>u<i>t" {
+		t.Errorf("highlightMatches = %q", got)
+	}
+}
@@ -0,0 +1,162 @@
+package gui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/donnellyk/lazyruin/pkg/gui/types"
+	"github.com/donnellyk/lazyruin/pkg/models"
+	"github.com/donnellyk/lazyruin/pkg/testutil"
+
+	"github.com/jesseduffield/gocui"
+)
+
+// parentTreeMock is a vault with one bookmarked parent:
+//
+//	Root
+//	├─ Alpha
+//	│  └─ Alpha One
+//	└─ Beta
+func parentTreeMock() *testutil.MockExecutor {
+	return testutil.NewMockExecutor().
+		WithNotes(
+			models.Note{UUID: "root", Title: "Root", Path: "root.md"},
+			models.Note{UUID: "alpha", Title: "Alpha", Path: "alpha.md", Parent: "root"},
+			models.Note{UUID: "alpha-1", Title: "Alpha One", Path: "alpha-1.md", Parent: "alpha"},
+			models.Note{UUID: "beta", Title: "Beta", Path: "beta.md", Parent: "root"},
+		).
+		WithParents(models.ParentBookmark{Name: "root", UUID: "root", Title: "Root"})
+}
+
+// pressTreeKey runs the parent tree binding for key.
+func pressTreeKey(t *testing.T, tg *testGui, key any) {
+	t.Helper()
+	for _, b := range tg.gui.contexts.ParentTree.GetKeybindings(types.KeybindingsOpts{}) {
+		if b.Key == key {
+			if err := b.Handler(); err != nil {
+				t.Fatalf("key %v: %v", key, err)
+			}
+			return
+		}
+	}
+	t.Fatalf("no parent tree binding for %v", key)
+}
+
+func treeTitles(tg *testGui) []string {
+	var titles []string
+	for _, r := range tg.gui.contexts.ParentTree.Rows() {
+		titles = append(titles, r.Node.Title)
+	}
+	return titles
+}
+
+func TestParentTree_ExpandCollapseAndHover(t *testing.T) {
+	tg := newTestGui(t, parentTreeMock())
+	defer tg.Close()
+
+	if err := tg.gui.helpers.ParentTree().OpenBookmarks(); err != nil {
+		t.Fatal(err)
+	}
+	if tg.gui.contextMgr.Current() != "parentTree" {
+		t.Fatalf("current context = %v, want parentTree", tg.gui.contextMgr.Current())
+	}
+	if got := strings.Join(treeTitles(tg), ","); got != "Root,Alpha,Beta" {
+		t.Fatalf("rows = %s, want the single root expanded", got)
+	}
+
+	pressTreeKey(t, tg, 'j')
+	if cards := tg.gui.contexts.CardList.Cards; len(cards) != 1 || cards[0].UUID != "alpha" {
+		t.Errorf("preview cards = %+v, want the hovered Alpha", cards)
+	}
+	if tg.gui.helpers.Navigator().IsCurrentCommitted() {
+		t.Error("hovering a tree node should not commit a history entry")
+	}
+
+	pressTreeKey(t, tg, 'l')
+	if got := strings.Join(treeTitles(tg), ","); got != "Root,Alpha,Alpha One,Beta" {
+		t.Errorf("rows after l = %s", got)
+	}
+	pressTreeKey(t, tg, 'l')
+	if row := tg.gui.contexts.ParentTree.SelectedRow(); row.Node.UUID != "alpha-1" {
+		t.Errorf("l on an open node should step into it, selected %q", row.Node.Title)
+	}
+	pressTreeKey(t, tg, 'h')
+	if row := tg.gui.contexts.ParentTree.SelectedRow(); row.Node.UUID != "alpha" {
+		t.Errorf("h on a leaf should step out to its parent, selected %q", row.Node.Title)
+	}
+	pressTreeKey(t, tg, 'h')
+	if got := strings.Join(treeTitles(tg), ","); got != "Root,Alpha,Beta" {
+		t.Errorf("rows after h = %s", got)
+	}
+
+	tg.g.ForceLayoutAndRedraw()
+	v := tg.gui.GetView(ParentTreeView)
+	if v == nil {
+		t.Fatal("parent tree view not created")
+	}
+	if buf := v.Buffer(); !strings.Contains(buf, "▾ Root") || !strings.Contains(buf, "├─ ▸ Alpha") || !strings.Contains(buf, "└─   Beta") {
+		t.Errorf("tree render =\n%s", buf)
+	}
+
+	pressTreeKey(t, tg, 'k')
+	pressTreeKey(t, tg, 'j')
+	pressTreeKey(t, tg, 'j')
+	pressTreeKey(t, tg, gocui.KeyEnter)
+	if tg.gui.contextMgr.Current() == "parentTree" {
+		t.Error("Enter should close the tree")
+	}
+	if cards := tg.gui.contexts.CardList.Cards; len(cards) != 1 || cards[0].UUID != "beta" {
+		t.Errorf("preview cards = %+v, want Beta opened", cards)
+	}
+	if !tg.gui.helpers.Navigator().IsCurrentCommitted() {
+		t.Error("opening a node should commit it to history")
+	}
+}
+
+func TestParentTree_CutAndPasteReparents(t *testing.T) {
+	mock := parentTreeMock()
+	tg := newTestGui(t, mock)
+	defer tg.Close()
+
+	if err := tg.gui.helpers.ParentTree().OpenBookmarks(); err != nil {
+		t.Fatal(err)
+	}
+	tree := tg.gui.contexts.ParentTree
+
+	// Moving Alpha under its own child is refused.
+	tree.Select("alpha")
+	pressTreeKey(t, tg, 'x')
+	pressTreeKey(t, tg, 'l')
+	pressTreeKey(t, tg, 'l')
+	mock.Calls = nil
+	pressTreeKey(t, tg, 'p')
+	if hasCall(mock.Calls, "note", "set", "alpha", "--parent", "alpha-1", "-f") {
+		t.Error("pasting a node under its descendant should be refused")
+	}
+	pressTreeKey(t, tg, gocui.KeyEsc)
+	if tree.CutUUID != "" || tg.gui.contextMgr.Current() != "parentTree" {
+		t.Fatal("Esc with a pending cut should cancel the cut and keep the tree open")
+	}
+
+	// Beta moves under Alpha One.
+	tree.Select("beta")
+	pressTreeKey(t, tg, 'x')
+	tree.Select("alpha-1")
+	pressTreeKey(t, tg, 'p')
+	if !hasCall(mock.Calls, "note", "set", "beta", "--parent", "alpha-1", "-f") {
+		t.Fatalf("expected beta to be reparented; calls=%v", mock.Calls)
+	}
+	if got := strings.Join(treeTitles(tg), ","); got != "Root,Alpha,Alpha One,Beta" {
+		t.Errorf("rows after paste = %s", got)
+	}
+	if row := tree.SelectedRow(); row == nil || row.Node.UUID != "beta" || row.Parent.UUID != "alpha-1" {
+		t.Errorf("selection after paste = %+v, want Beta under Alpha One", row)
+	}
+
+	if err := tg.gui.helpers.Undo().Undo(); err != nil {
+		t.Fatal(err)
+	}
+	if !hasCall(mock.Calls, "note", "set", "beta", "--parent", "root", "-f") {
+		t.Errorf("undo should restore the previous parent; calls=%v", mock.Calls)
+	}
+}
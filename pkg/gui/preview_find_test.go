@@ -0,0 +1,137 @@
+package gui
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/donnellyk/lazyruin/pkg/gui/context"
+	"github.com/jesseduffield/gocui"
+)
+
+func TestFindPatternSpans_RuneColumnsSkippingEmptyMatches(t *testing.T) {
+	got := findPatternSpans("héllo hello", regexp.MustCompile(`l+|x*`))
+	want := []textSpan{{2, 2}, {8, 2}}
+	if !slices.Equal(got, want) {
+		t.Errorf("spans = %v, want %v", got, want)
+	}
+}
+
+func TestMarkFindMatches_HighlightsAndRecords(t *testing.T) {
+	ns := &context.PreviewNavState{Find: context.PreviewFind{Re: regexp.MustCompile("(?i)sam")}}
+	got := markFindMatches("  \x1b[2mL02:\x1b[0m call Sam", 4, ns)
+	if !strings.Contains(got, theme.SearchMatch+"Sam"+AnsiReset) {
+		t.Errorf("line = %q, want Sam highlighted", got)
+	}
+	want := []context.PreviewLinkSegment{{Line: 4, Col: 12, Len: 3}}
+	if !slices.Equal(ns.Matches, want) {
+		t.Errorf("matches = %+v, want %+v", ns.Matches, want)
+	}
+}
+
+func TestPreviewFind_FindsAsYouTypeAndCyclesWithN(t *testing.T) {
+	tg := newTestGui(t, todosMock(t))
+	defer tg.Close()
+
+	if err := tg.gui.helpers.Pick().OpenPick(); err != nil {
+		t.Fatal(err)
+	}
+	if err := tg.gui.helpers.Pick().ExecutePick("#work"); err != nil {
+		t.Fatal(err)
+	}
+	pr := tg.gui.contexts.PickResults
+	if err := tg.g.ForceLayoutAndRedraw(); err != nil {
+		t.Fatal(err)
+	}
+	ns := pr.NavState()
+
+	open := keyBinding(tg.gui.contextBindings(pr), '/')
+	if open == nil {
+		t.Fatal("/ not bound in pick results")
+	}
+	if err := open.Handler(); err != nil {
+		t.Fatal(err)
+	}
+	if err := tg.g.ForceLayoutAndRedraw(); err != nil {
+		t.Fatal(err)
+	}
+	if tg.gui.contextMgr.Current() != "previewFind" {
+		t.Fatalf("current context = %v, want previewFind", tg.gui.contextMgr.Current())
+	}
+	v, _ := tg.g.View(PreviewFindView)
+	find := tg.gui.contextBindings(tg.gui.contexts.PreviewFind)
+	typeText := func(s string) {
+		for _, ch := range s {
+			v.Editor.Edit(v, 0, ch, gocui.ModNone)
+		}
+	}
+	clearText := func() {
+		for v.TextArea.GetUnwrappedContent() != "" {
+			v.Editor.Edit(v, gocui.KeyBackspace2, 0, gocui.ModNone)
+		}
+	}
+
+	typeText("sam")
+	if len(ns.Matches) != 1 {
+		t.Fatalf("matches = %+v, want 1 for sam", ns.Matches)
+	}
+	if line := ns.Lines[ns.CursorLine].Text; ns.CursorLine != ns.Matches[0].Line || !strings.Contains(line, "call Sam") {
+		t.Errorf("cursor should be on the match, line %d %q", ns.CursorLine, line)
+	}
+
+	if err := keyBinding(find, gocui.KeyCtrlA).Handler(); err != nil {
+		t.Fatal(err)
+	}
+	if len(ns.Matches) != 0 || tg.gui.previewFindSubtitle() != " no matches " {
+		t.Errorf("case-sensitive sam should not match Sam, got %+v", ns.Matches)
+	}
+	if err := keyBinding(find, gocui.KeyCtrlA).Handler(); err != nil {
+		t.Fatal(err)
+	}
+
+	clearText()
+	if err := keyBinding(find, gocui.KeyCtrlR).Handler(); err != nil {
+		t.Fatal(err)
+	}
+	typeText("rent|ship")
+	if len(ns.Matches) != 2 {
+		t.Fatalf("matches = %+v, want 2 for the regex", ns.Matches)
+	}
+	if err := keyBinding(find, gocui.KeyEnter).Handler(); err != nil {
+		t.Fatal(err)
+	}
+	if tg.gui.contextMgr.Current() != "pickResults" || len(ns.Matches) != 2 {
+		t.Fatalf("Enter should return to the preview keeping the matches, context %v", tg.gui.contextMgr.Current())
+	}
+
+	next := bindingByID(tg.gui.contextBindings(pr), "preview.next_match")
+	for _, want := range []string{"match 2/2", "match 1/2"} {
+		if err := next.Handler(); err != nil {
+			t.Fatal(err)
+		}
+		if got := tg.gui.searchMatchStatus(); got != want {
+			t.Errorf("status = %q, want %q", got, want)
+		}
+	}
+
+	origin := ns.CursorLine
+	if err := open.Handler(); err != nil {
+		t.Fatal(err)
+	}
+	typeText("thing")
+	if err := keyBinding(find, gocui.KeyEsc).Handler(); err != nil {
+		t.Fatal(err)
+	}
+	if len(ns.Matches) != 0 || ns.Find.Active() {
+		t.Errorf("Esc should clear the find, matches %+v", ns.Matches)
+	}
+	if ns.CursorLine != origin {
+		t.Errorf("cursor = %d, want it back where the bar opened", ns.CursorLine)
+	}
+	// With the find cleared, n goes back to its global binding.
+	if err := next.Handler(); !errors.Is(err, gocui.ErrKeybindingNotHandled) {
+		t.Errorf("n after clearing the find = %v, want ErrKeybindingNotHandled", err)
+	}
+}
@@ -0,0 +1,85 @@
+package gui
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/donnellyk/lazyruin/pkg/remote"
+
+	"github.com/jesseduffield/gocui"
+)
+
+// startRemote listens on the active vault's remote-control socket. When
+// another instance already holds it (remote.ErrAlreadyRunning), this one
+// runs without remote control and says so at startup.
+func (gui *Gui) startRemote() {
+	s, err := remote.Listen(remote.SocketPath(gui.ruinCmd.VaultPath()), gui.handleRemote)
+	if err != nil {
+		gui.appendStartupWarning("remote control disabled: " + err.Error())
+		return
+	}
+	gui.remoteServer = s
+}
+
+// stopRemote closes the socket opened by startRemote, if any.
+func (gui *Gui) stopRemote() {
+	if gui.remoteServer != nil {
+		_ = gui.remoteServer.Close()
+		gui.remoteServer = nil
+	}
+}
+
+// handleRemote runs a request on the main loop and waits for its result,
+// so the client hears whether it worked. stopRemote runs on the main loop
+// and waits for handlers, so once the server is closing this stops
+// waiting, and the queued request is dropped instead of running against
+// whatever vault comes next.
+func (gui *Gui) handleRemote(req remote.Request, closing <-chan struct{}) error {
+	done := make(chan error, 1)
+	gui.g.Update(func(g *gocui.Gui) error {
+		select {
+		case <-closing:
+			return nil
+		default:
+		}
+		done <- gui.runRemote(req)
+		return nil
+	})
+	select {
+	case err := <-done:
+		return err
+	case <-closing:
+		return remote.ErrClosing
+	case <-time.After(5 * time.Second):
+		return errors.New("lazyruin did not respond")
+	}
+}
+
+// runRemote carries out a remote command. Commands that change the view
+// are refused while a popup is open rather than pulling the preview out
+// from under it.
+func (gui *Gui) runRemote(req remote.Request) error {
+	if req.Command != remote.CommandRefresh && gui.overlayActive() {
+		return errors.New("a popup is open in lazyruin")
+	}
+	switch req.Command {
+	case remote.CommandOpen:
+		if !gui.openRef(req.Arg) {
+			return fmt.Errorf("no note or parent bookmark matches %q", req.Arg)
+		}
+	case remote.CommandSearch:
+		gui.helpers.Search().ExecuteSearch(req.Arg)
+	case remote.CommandCapture:
+		if req.Arg == "" {
+			return gui.helpers.Capture().OpenCapture()
+		}
+		return gui.helpers.Capture().CaptureText(req.Arg)
+	case remote.CommandDate:
+		gui.helpers.DatePreview().LoadDatePreview(req.Arg)
+	case remote.CommandRefresh:
+		gui.RefreshAll()
+		gui.helpers.Preview().ReloadActivePreview()
+	}
+	return nil
+}
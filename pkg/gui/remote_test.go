@@ -0,0 +1,74 @@
+package gui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/donnellyk/lazyruin/pkg/remote"
+)
+
+func TestRunRemote_OpenShowsNote(t *testing.T) {
+	tg := newTestGui(t, defaultMock())
+	defer tg.Close()
+
+	if err := tg.gui.runRemote(remote.Request{Command: remote.CommandOpen, Arg: "Note Two"}); err != nil {
+		t.Fatal(err)
+	}
+	cl := tg.gui.contexts.CardList
+	if len(cl.Cards) != 1 || cl.Cards[0].UUID != "2" {
+		t.Errorf("preview cards = %+v, want Note Two", cl.Cards)
+	}
+
+	err := tg.gui.runRemote(remote.Request{Command: remote.CommandOpen, Arg: "Missing"})
+	if err == nil || !strings.Contains(err.Error(), `"Missing"`) {
+		t.Errorf("open of an unknown ref = %v, want an error naming it", err)
+	}
+}
+
+func TestRunRemote_CaptureSavesNote(t *testing.T) {
+	mock := defaultMock()
+	tg := newTestGui(t, mock)
+	defer tg.Close()
+
+	if err := tg.gui.runRemote(remote.Request{Command: remote.CommandCapture, Arg: "from a hotkey"}); err != nil {
+		t.Fatal(err)
+	}
+	var logged bool
+	for _, call := range mock.Calls {
+		if call[0] == "log" && strings.Contains(strings.Join(call, " "), "from a hotkey") {
+			logged = true
+		}
+	}
+	if !logged {
+		t.Errorf("capture should create a note, calls = %v", mock.Calls)
+	}
+	if tg.gui.contextMgr.Current() == "capture" {
+		t.Error("capture with text should not open the popup")
+	}
+}
+
+func TestRunRemote_RefusedWhilePopupOpen(t *testing.T) {
+	tg := newTestGui(t, defaultMock())
+	defer tg.Close()
+
+	if err := tg.gui.helpers.Capture().OpenCapture(); err != nil {
+		t.Fatal(err)
+	}
+	if err := tg.gui.runRemote(remote.Request{Command: remote.CommandDate, Arg: "2026-01-02"}); err == nil {
+		t.Error("date should be refused while the capture popup is open")
+	}
+	if err := tg.gui.runRemote(remote.Request{Command: remote.CommandRefresh}); err != nil {
+		t.Errorf("refresh should run under a popup: %v", err)
+	}
+}
+
+func TestRemoteRequest_RunsOnLaunch(t *testing.T) {
+	tg := newTestGuiWithOpts(t, defaultMock(), testGuiOpts{
+		RemoteRequest: &remote.Request{Command: remote.CommandSearch, Arg: "#daily"},
+	})
+	defer tg.Close()
+
+	if tg.gui.contexts.Search.Query != "#daily" {
+		t.Errorf("search query = %q, want the --remote search", tg.gui.contexts.Search.Query)
+	}
+}
@@ -9,6 +9,9 @@ import (
 	"github.com/jesseduffield/gocui"
 )
 
+// selectionMark prefixes the title of a multi-selected note or card.
+const selectionMark = "● "
+
 // listItem holds the formatted lines for a single list item.
 // Lines[0] is always rendered plain when selected, Lines[1:] are dim when unselected.
 // Lines must not contain ANSI codes that would conflict with selection highlighting.
@@ -43,14 +46,14 @@ func renderList(v *gocui.View, itemCount int, selectedIndex int, isActive bool,
 
 		if selected {
 			for _, line := range item.Lines {
-				fmt.Fprintf(v, "%s%s%s\n", AnsiBlueBgWhite, pad(line), AnsiReset)
+				fmt.Fprintf(v, "%s%s%s\n", theme.Selected, pad(line), AnsiReset)
 			}
 		} else {
 			for j, line := range item.Lines {
 				if j == 0 {
 					fmt.Fprintln(v, line)
 				} else {
-					fmt.Fprintf(v, "%s%s%s\n", AnsiDim, line, AnsiReset)
+					fmt.Fprintf(v, "%s%s%s\n", theme.Muted, line, AnsiReset)
 				}
 			}
 		}
@@ -89,11 +92,11 @@ func (gui *Gui) renderNotesHome(v *gocui.View) {
 		case row.Blank:
 			fmt.Fprintln(v)
 		case row.IsHeader:
-			fmt.Fprintf(v, "%s%s%s\n", AnsiBoldWhite, row.Title, AnsiReset)
+			fmt.Fprintf(v, "%s%s%s\n", theme.Title, row.Title, AnsiReset)
 		default:
 			line := "  " + row.Title
 			if isActive && i == homeCtx.SelectedIdx {
-				fmt.Fprintf(v, "%s%s%s\n", AnsiBlueBgWhite, pad(line), AnsiReset)
+				fmt.Fprintf(v, "%s%s%s\n", theme.Selected, pad(line), AnsiReset)
 			} else {
 				fmt.Fprintln(v, line)
 			}
@@ -131,6 +134,9 @@ func (gui *Gui) RenderNotes() {
 			if title == "" {
 				title = note.Path
 			}
+			if notesCtx.Selection.Contains(i, note.UUID, len(notesCtx.Items), notesCtx.GetSelectedLineIdx()) {
+				title = selectionMark + title
+			}
 			titleRunes := []rune(title)
 			if len(titleRunes) > width-1 {
 				title = strings.TrimRight(string(titleRunes[:width-4]), " ") + "..."
@@ -248,7 +254,7 @@ func (gui *Gui) RenderTags() {
 				}}
 			}
 			return listItem{Lines: []string{
-				fmt.Sprintf(" %s %s%s%s", name, AnsiDim, count, AnsiReset),
+				fmt.Sprintf(" %s %s%s%s", name, theme.Muted, count, AnsiReset),
 			}}
 		})
 }
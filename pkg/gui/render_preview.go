@@ -3,9 +3,13 @@ package gui
 import (
 	"fmt"
 	"os"
+	"regexp"
+	"slices"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/donnellyk/lazyruin/pkg/gui/context"
+	"github.com/donnellyk/lazyruin/pkg/gui/helpers"
 	"github.com/donnellyk/lazyruin/pkg/gui/types"
 	"github.com/donnellyk/lazyruin/pkg/models"
 
@@ -96,11 +100,11 @@ func (gui *Gui) applyPreviewPadding(width, contentWidth int) (string, int, int)
 	return strings.Repeat(" ", pad), width, contentWidth
 }
 
-// dimLine wraps a rendered line in AnsiDim. Re-applies dim after every
-// ANSI reset so chroma's mid-line resets don't cancel the effect.
+// dimLine wraps a completed line in the theme's Done style. Re-applies it
+// after every ANSI reset so chroma's mid-line resets don't cancel the effect.
 func dimLine(text string) string {
-	patched := strings.ReplaceAll(text, AnsiReset, AnsiReset+AnsiDim)
-	return AnsiDim + patched + AnsiReset
+	patched := strings.ReplaceAll(text, AnsiReset, AnsiReset+theme.Done)
+	return theme.Done + patched + AnsiReset
 }
 
 // lineIdentity pairs a source note's UUID, content line number, and file path.
@@ -119,6 +123,11 @@ func (gui *Gui) RenderPreview() {
 
 	v.Clear()
 
+	if gui.contextMgr.Current() == "noteHistory" {
+		gui.renderNoteHistoryDiff(v)
+		return
+	}
+
 	ctx := gui.contexts.ActivePreview()
 	ns := ctx.NavState()
 
@@ -128,13 +137,14 @@ func (gui *Gui) RenderPreview() {
 	// subsequent render triggered by other navigation.
 	ns.RenderedLink = ns.HighlightedLink
 	ns.HighlightedLink = -1
+	ns.Matches = ns.Matches[:0]
 
 	switch gui.contexts.ActivePreviewKey {
 	case "pickResults":
 		pr := gui.contexts.PickResults
 		gui.renderPickResults(v, pr.Results, ns, pr.SelectedCardIdx, gui.isPreviewActive())
 	case "compose":
-		gui.renderSeparatorCards(v, []models.Note{gui.contexts.Compose.Note}, ns, nil)
+		gui.renderSeparatorCards(v, []models.Note{gui.contexts.Compose.Note}, ns, nil, nil)
 	case "datePreview":
 		dp := gui.contexts.DatePreview
 		gui.renderDatePreview(v, dp, ns, gui.isPreviewActive())
@@ -151,7 +161,14 @@ func (gui *Gui) RenderPreview() {
 				}
 			}
 		}
-		gui.renderSeparatorCards(v, cards, ns, cl.TemporarilyMoved)
+		var marked map[int]bool
+		if cl.Selection.Active() {
+			marked = make(map[int]bool)
+			for _, i := range cl.Selection.Indices(context.NoteUUIDs(cl.Cards), cl.SelectedCardIdx) {
+				marked[i] = true
+			}
+		}
+		gui.renderSeparatorCards(v, cards, ns, cl.TemporarilyMoved, marked)
 	}
 }
 
@@ -219,6 +236,10 @@ func isHeaderLine(line string) bool {
 // When a link is highlighted (HighlightedLink >= 0), only the link span is highlighted
 // instead of the full line.
 func (gui *Gui) fprintPreviewLine(v *gocui.View, line string, lineNum int, highlight bool, ns *context.PreviewNavState) {
+	if ns.Find.Active() {
+		line = markFindMatches(line, lineNum, ns)
+	}
+
 	// Link highlight applies to every visual line the link covers — not
 	// just the cursor line — because wordwrap may have broken the link
 	// across several visual lines. Check for a link segment on this line
@@ -249,25 +270,38 @@ func (gui *Gui) fprintPreviewLine(v *gocui.View, line string, lineNum int, highl
 		width-visibleWidth(line)-1, 0)
 	// Re-apply background after every ANSI reset so chroma formatting
 	// doesn't clear our highlight mid-line.
-	patched := strings.ReplaceAll(hlLine, AnsiReset, AnsiReset+AnsiDimBg)
-	// Use AnsiBgReset (not AnsiReset) so we only clear the background we
+	patched := strings.ReplaceAll(hlLine, AnsiReset, AnsiReset+theme.CursorLine)
+	// Use cursorLineEnd (not AnsiReset) so we only clear the background we
 	// added.  A full reset would wipe foreground colors that chroma leaves
 	// active across line boundaries, causing subsequent lines to lose color.
-	fmt.Fprintf(v, "%s%s%s%s%s\n", leading, AnsiDimBg, patched, strings.Repeat(" ", pad), AnsiBgReset)
+	fmt.Fprintf(v, "%s%s%s%s%s\n", leading, theme.CursorLine, patched, strings.Repeat(" ", pad), cursorLineEnd)
 }
 
-// highlightSpan applies AnsiDimBg to a span of visible characters in an ANSI-decorated
-// string. col and length are in visible-character units (ignoring ANSI escapes).
+// highlightSpan applies the theme's LinkHighlight to a span of visible
+// characters in an ANSI-decorated string. col and length are in
+// visible-character units (ignoring ANSI escapes).
 func highlightSpan(line string, col, length int) string {
+	return highlightSpans(line, []textSpan{{col: col, len: length}}, theme.LinkHighlight)
+}
+
+// textSpan is a run of visible characters in a line.
+type textSpan struct {
+	col, len int
+}
+
+// highlightSpans wraps each span of visible characters in an ANSI-decorated
+// string in style. Spans must be sorted and must not overlap.
+func highlightSpans(line string, spans []textSpan, style string) string {
+	if len(spans) == 0 {
+		return line
+	}
 	var sb strings.Builder
-	runes := []rune(line)
 	visPos := 0
 	inEsc := false
-	spanStart := col
-	spanEnd := col + length
+	k := 0
+	open := false
 
-	for i := range runes {
-		r := runes[i]
+	for _, r := range line {
 		if inEsc {
 			sb.WriteRune(r)
 			if (r >= 'A' && r <= 'Z') || (r >= 'a' && r <= 'z') {
@@ -282,22 +316,111 @@ func highlightSpan(line string, col, length int) string {
 		}
 
 		// Visible character
-		if visPos == spanStart {
-			sb.WriteString(AnsiDimBg)
+		if k < len(spans) && visPos == spans[k].col {
+			sb.WriteString(style)
+			open = true
 		}
 		sb.WriteRune(r)
 		visPos++
-		if visPos == spanEnd {
+		if open && visPos == spans[k].col+spans[k].len {
 			sb.WriteString(AnsiReset)
+			open = false
+			k++
 		}
 	}
-	// Safety: close highlight if line ended before spanEnd
-	if visPos < spanEnd && visPos >= spanStart {
+	// Safety: close highlight if line ended before the span did
+	if open {
 		sb.WriteString(AnsiReset)
 	}
 	return sb.String()
 }
 
+// searchMatchTerms returns the text terms of the search behind the card
+// list, to highlight in its cards. Other previews have none, and an active
+// find replaces them.
+func (gui *Gui) searchMatchTerms() []string {
+	if gui.contexts.ActivePreviewKey != "cardList" || gui.contexts.CardList.NavState().Find.Active() {
+		return nil
+	}
+	src := gui.contexts.CardList.Source
+	if src.Descriptor.Kind != context.SourceSearch && src.Descriptor.Kind != context.SourceSearchRaw {
+		return nil
+	}
+	return helpers.SearchTerms(src.Query)
+}
+
+// markSearchMatches highlights terms in text, a card line drawn at lineNum
+// after col columns of padding, and records each match in ns.Matches.
+func markSearchMatches(text string, terms []string, lineNum, col int, ns *context.PreviewNavState) string {
+	spans := findTermSpans(stripAnsi(text), terms)
+	for _, sp := range spans {
+		ns.Matches = append(ns.Matches, context.PreviewLinkSegment{Line: lineNum, Col: col + sp.col, Len: sp.len})
+	}
+	return highlightSpans(text, spans, theme.SearchMatch)
+}
+
+// markFindMatches highlights the preview find's matches in a rendered
+// line and records each in ns.Matches.
+func markFindMatches(line string, lineNum int, ns *context.PreviewNavState) string {
+	spans := findPatternSpans(stripAnsi(line), ns.Find.Re)
+	for _, sp := range spans {
+		ns.Matches = append(ns.Matches, context.PreviewLinkSegment{Line: lineNum, Col: sp.col, Len: sp.len})
+	}
+	return highlightSpans(line, spans, theme.SearchMatch)
+}
+
+// findPatternSpans returns the non-empty matches of re in plain as spans
+// of visible characters.
+func findPatternSpans(plain string, re *regexp.Regexp) []textSpan {
+	var spans []textSpan
+	for _, loc := range re.FindAllStringIndex(plain, -1) {
+		if loc[0] == loc[1] {
+			continue
+		}
+		spans = append(spans, textSpan{
+			col: utf8.RuneCountInString(plain[:loc[0]]),
+			len: utf8.RuneCountInString(plain[loc[0]:loc[1]]),
+		})
+	}
+	return spans
+}
+
+// findTermSpans finds every case-insensitive occurrence of terms in plain,
+// merging overlapping ones.
+func findTermSpans(plain string, terms []string) []textSpan {
+	lower := []rune(strings.ToLower(plain))
+	if len(lower) != len([]rune(plain)) {
+		// Lowercasing changed the length; columns would no longer line up.
+		return nil
+	}
+	covered := make([]bool, len(lower))
+	for _, term := range terms {
+		t := []rune(strings.ToLower(term))
+		if len(t) == 0 {
+			continue
+		}
+		for i := 0; i+len(t) <= len(lower); i++ {
+			if slices.Equal(lower[i:i+len(t)], t) {
+				for j := i; j < i+len(t); j++ {
+					covered[j] = true
+				}
+			}
+		}
+	}
+	var spans []textSpan
+	for i := 0; i < len(covered); i++ {
+		if !covered[i] {
+			continue
+		}
+		start := i
+		for i < len(covered) && covered[i] {
+			i++
+		}
+		spans = append(spans, textSpan{col: start, len: i - start})
+	}
+	return spans
+}
+
 // collapseConsecutiveBlanks returns lines with runs of visually-blank
 // entries (ANSI stripped) at index ≥ start collapsed to a single blank.
 // Used only when HideDone strips source lines; without this the blank
@@ -390,11 +513,11 @@ func (gui *Gui) BuildCardContent(note models.Note, contentWidth int) []types.Sou
 	// Frontmatter display lines — non-content (LineNum=0)
 	if ds.ShowFrontmatter {
 		if fm, err := gui.loadNoteFrontmatter(note.Path); err == nil && fm != "" {
-			lines = append(lines, types.SourceLine{Text: " " + AnsiDim + "---" + AnsiReset})
+			lines = append(lines, types.SourceLine{Text: " " + theme.Muted + "---" + AnsiReset})
 			for fl := range strings.SplitSeq(fm, "\n") {
-				lines = append(lines, types.SourceLine{Text: " " + AnsiDim + fl + AnsiReset})
+				lines = append(lines, types.SourceLine{Text: " " + theme.Muted + fl + AnsiReset})
 			}
-			lines = append(lines, types.SourceLine{Text: " " + AnsiDim + "---" + AnsiReset})
+			lines = append(lines, types.SourceLine{Text: " " + theme.Muted + "---" + AnsiReset})
 		}
 	}
 
@@ -688,7 +811,7 @@ func stripTaskCheckbox(s string) string {
 // Returns the updated currentLine.
 func (gui *Gui) renderCardInto(v *gocui.View, note models.Note, cardIdx int,
 	ns *context.PreviewNavState, currentLine int, isActive bool,
-	selectedIdx int, temporarilyMoved map[int]bool, width int, contentWidth int) int {
+	selectedIdx int, temporarilyMoved, marked map[int]bool, width int, contentWidth int) int {
 
 	selected := isActive && cardIdx == selectedIdx
 	ns.CardLineRanges[cardIdx][0] = currentLine
@@ -709,17 +832,26 @@ func (gui *Gui) renderCardInto(v *gocui.View, note models.Note, cardIdx int,
 	if title == "" {
 		title = "Untitled"
 	}
+	if marked[cardIdx] {
+		title = selectionMark + title
+	}
 	upperRight := ""
 	if temporarilyMoved != nil && temporarilyMoved[cardIdx] {
 		upperRight = " Temporarily Moved "
 	}
 	emit(gui.buildSeparatorLine(true, " "+title+" ", upperRight, width, selected), types.SourceLine{})
 
+	terms := gui.searchMatchTerms()
+	padCols := visibleWidth(prefix)
 	for _, sl := range gui.BuildCardContent(note, contentWidth) {
 		if isHeaderLine(sl.Text) {
 			ns.HeaderLines = append(ns.HeaderLines, currentLine)
 		}
-		emit(sl.Text, sl)
+		text := sl.Text
+		if len(terms) > 0 {
+			text = markSearchMatches(text, terms, currentLine, padCols, ns)
+		}
+		emit(text, sl)
 	}
 
 	var parentLabel string
@@ -777,7 +909,7 @@ func (gui *Gui) renderPickGroupInto(v *gocui.View, result models.PickResult, car
 		for j, line := range strings.Split(strings.TrimRight(wrapped, "\n"), "\n") {
 			var formatted string
 			if j == 0 {
-				formatted = fmt.Sprintf("  %sL%s:%s %s", AnsiDim, lineNum, AnsiReset, line)
+				formatted = fmt.Sprintf("  %sL%s:%s %s", theme.Muted, lineNum, AnsiReset, line)
 			} else {
 				formatted = indent + line
 			}
@@ -796,7 +928,7 @@ func (gui *Gui) renderPickGroupInto(v *gocui.View, result models.PickResult, car
 }
 
 // renderSeparatorCards renders cards using separator lines instead of frames
-func (gui *Gui) renderSeparatorCards(v *gocui.View, cards []models.Note, ns *context.PreviewNavState, temporarilyMoved map[int]bool) {
+func (gui *Gui) renderSeparatorCards(v *gocui.View, cards []models.Note, ns *context.PreviewNavState, temporarilyMoved, marked map[int]bool) {
 	if len(cards) == 0 {
 		fmt.Fprintln(v, "No matching notes.")
 		return
@@ -816,7 +948,7 @@ func (gui *Gui) renderSeparatorCards(v *gocui.View, cards []models.Note, ns *con
 	ns.Lines = ns.Lines[:0]
 
 	for i, note := range cards {
-		currentLine = gui.renderCardInto(v, note, i, ns, currentLine, isActive, ctx.SelectedCardIndex(), temporarilyMoved, width, contentWidth)
+		currentLine = gui.renderCardInto(v, note, i, ns, currentLine, isActive, ctx.SelectedCardIndex(), temporarilyMoved, marked, width, contentWidth)
 		if i < len(cards)-1 {
 			gui.fprintPreviewLine(v, "", currentLine, isActive, ns)
 			ns.Lines = append(ns.Lines, types.SourceLine{Text: ""})
@@ -894,7 +1026,7 @@ func (gui *Gui) renderDatePreview(v *gocui.View, dp *context.DatePreviewContext,
 
 	totalCards := len(dp.TagPicks) + len(dp.TodoPicks) + len(dp.Notes)
 	if totalCards == 0 {
-		fmt.Fprintln(v, " "+AnsiDim+"No activity on "+dp.TargetDate+AnsiReset)
+		fmt.Fprintln(v, " "+theme.Muted+"No activity on "+dp.TargetDate+AnsiReset)
 		ns.CardLineRanges = nil
 		ns.Lines = []types.SourceLine{{Text: "No activity on " + dp.TargetDate}}
 		return
@@ -913,7 +1045,7 @@ func (gui *Gui) renderDatePreview(v *gocui.View, dp *context.DatePreviewContext,
 	currentLine = gui.renderSectionHeader(v, "Inline Tags", width, currentLine, ns, dp.DatePreviewState, isActive)
 	tagStart := cardIdx
 	if len(dp.TagPicks) == 0 {
-		gui.fprintPreviewLine(v, " "+AnsiDim+"No tagged lines"+AnsiReset, currentLine, isActive, ns)
+		gui.fprintPreviewLine(v, " "+theme.Muted+"No tagged lines"+AnsiReset, currentLine, isActive, ns)
 		ns.Lines = append(ns.Lines, types.SourceLine{Text: " No tagged lines"})
 		currentLine++
 	} else {
@@ -939,7 +1071,7 @@ func (gui *Gui) renderDatePreview(v *gocui.View, dp *context.DatePreviewContext,
 	currentLine = gui.renderSectionHeader(v, "Todos", width, currentLine, ns, dp.DatePreviewState, isActive)
 	todoStart := cardIdx
 	if len(dp.TodoPicks) == 0 {
-		gui.fprintPreviewLine(v, " "+AnsiDim+"No todos"+AnsiReset, currentLine, isActive, ns)
+		gui.fprintPreviewLine(v, " "+theme.Muted+"No todos"+AnsiReset, currentLine, isActive, ns)
 		ns.Lines = append(ns.Lines, types.SourceLine{Text: " No todos"})
 		currentLine++
 	} else {
@@ -964,12 +1096,12 @@ func (gui *Gui) renderDatePreview(v *gocui.View, dp *context.DatePreviewContext,
 	currentLine = gui.renderSectionHeader(v, "Notes", width, currentLine, ns, dp.DatePreviewState, isActive)
 	noteStart := cardIdx
 	if len(dp.Notes) == 0 {
-		gui.fprintPreviewLine(v, " "+AnsiDim+"No notes"+AnsiReset, currentLine, isActive, ns)
+		gui.fprintPreviewLine(v, " "+theme.Muted+"No notes"+AnsiReset, currentLine, isActive, ns)
 		ns.Lines = append(ns.Lines, types.SourceLine{Text: " No notes"})
 		currentLine++
 	} else {
 		for i, note := range dp.Notes {
-			currentLine = gui.renderCardInto(v, note, cardIdx, ns, currentLine, isActive, dp.SelectedCardIdx, nil, width, contentWidth)
+			currentLine = gui.renderCardInto(v, note, cardIdx, ns, currentLine, isActive, dp.SelectedCardIdx, nil, nil, width, contentWidth)
 			if i < len(dp.Notes)-1 {
 				gui.fprintPreviewLine(v, "", currentLine, isActive, ns)
 				ns.Lines = append(ns.Lines, types.SourceLine{Text: ""})
@@ -1043,7 +1175,7 @@ func (gui *Gui) buildStraightSeparator(label string, width int) string {
 	labelLen := len([]rune(label))
 	fillLen := max(width-labelLen-2, 0)
 	var sb strings.Builder
-	sb.WriteString(AnsiDim)
+	sb.WriteString(theme.Separator)
 	sb.WriteString(sep)
 	sb.WriteString(label)
 	for i := 0; i < fillLen; i++ {
@@ -1055,8 +1187,6 @@ func (gui *Gui) buildStraightSeparator(label string, width int) string {
 }
 
 func (gui *Gui) buildSeparatorLine(upper bool, leftText, rightText string, width int, highlight bool) string {
-	dim := AnsiDim
-	green := AnsiGreen
 	reset := AnsiReset
 
 	sep := "─"
@@ -1076,9 +1206,10 @@ func (gui *Gui) buildSeparatorLine(upper bool, leftText, rightText string, width
 	var sb strings.Builder
 	sb.WriteString(reset) // Clear any leftover foreground color from content lines
 	if highlight {
-		sb.WriteString(green)
+		sb.WriteString(theme.ActiveSeparator)
+	} else {
+		sb.WriteString(theme.Separator)
 	}
-	sb.WriteString(dim)
 	if upper {
 		sb.WriteString("╭")
 	} else {
@@ -1101,6 +1232,37 @@ func (gui *Gui) buildSeparatorLine(upper bool, leftText, rightText string, width
 	return sb.String()
 }
 
+// renderNoteHistoryDiff shows the diff of the revision selected in the
+// note history popup, colored by line kind.
+func (gui *Gui) renderNoteHistoryDiff(v *gocui.View) {
+	nh := gui.contexts.NoteHistory
+	if nh.DiffErr != nil {
+		fmt.Fprintln(v, theme.Warning+nh.DiffErr.Error()+AnsiReset)
+		return
+	}
+	for _, line := range strings.Split(strings.TrimRight(nh.Diff, "\n"), "\n") {
+		fmt.Fprintln(v, colorDiffLine(line))
+	}
+	v.SetOrigin(0, nh.DiffScroll)
+}
+
+// colorDiffLine colors one line of a unified diff: additions, removals,
+// hunk headers and file headers.
+func colorDiffLine(line string) string {
+	switch {
+	case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"),
+		strings.HasPrefix(line, "diff "), strings.HasPrefix(line, "index "):
+		return theme.Muted + line + AnsiReset
+	case strings.HasPrefix(line, "@@"):
+		return theme.Accent + line + AnsiReset
+	case strings.HasPrefix(line, "+"):
+		return theme.Success + line + AnsiReset
+	case strings.HasPrefix(line, "-"):
+		return theme.Warning + line + AnsiReset
+	}
+	return line
+}
+
 // resolveParentLabel returns a display name for a parent UUID by checking
 // loaded parent bookmarks, then loaded notes, then the title cache, then
 // falling back to a truncated UUID.
@@ -0,0 +1,124 @@
+package gui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/donnellyk/lazyruin/pkg/gui/types"
+	"github.com/jesseduffield/gocui"
+)
+
+func keyBinding(bindings []*types.Binding, key any) *types.Binding {
+	for _, b := range bindings {
+		if b.Key == key {
+			return b
+		}
+	}
+	return nil
+}
+
+func TestSearchHistory_UpDownRecallsEarlierSearches(t *testing.T) {
+	tg := newTestGui(t, defaultMock())
+	defer tg.Close()
+
+	for _, q := range []string{"#work", "meeting"} {
+		tg.gui.helpers.Search().OpenSearch()
+		tg.gui.helpers.Search().ExecuteSearch(q)
+	}
+
+	tg.gui.helpers.Search().OpenSearch()
+	if err := tg.g.ForceLayoutAndRedraw(); err != nil {
+		t.Fatalf("layout failed: %v", err)
+	}
+	v := tg.gui.views.Search
+	v.TextArea.TypeString("draft")
+
+	press := func(key gocui.Key) string {
+		v.Editor.Edit(v, key, 0, gocui.ModNone)
+		return v.TextArea.GetUnwrappedContent()
+	}
+	for i, step := range []struct {
+		key  gocui.Key
+		want string
+	}{
+		{gocui.KeyArrowUp, "meeting"},
+		{gocui.KeyArrowUp, "#work"},
+		{gocui.KeyArrowUp, "#work"},
+		{gocui.KeyArrowDown, "meeting"},
+		{gocui.KeyArrowDown, "draft"},
+	} {
+		if got := press(step.key); got != step.want {
+			t.Errorf("step %d: input = %q, want %q", i, got, step.want)
+		}
+	}
+}
+
+func TestSearchHistory_BrowserSavesPickAsQuery(t *testing.T) {
+	mock := defaultMock()
+	tg := newTestGui(t, mock)
+	defer tg.Close()
+
+	tg.gui.helpers.Pick().OpenPick()
+	tg.gui.helpers.Pick().TogglePickAny()
+	if err := tg.gui.helpers.Pick().ExecutePick("#work @2026-01-02"); err != nil {
+		t.Fatal(err)
+	}
+
+	tg.gui.helpers.Search().OpenSearch()
+	b := keyBinding(tg.gui.contextBindings(tg.gui.contexts.Search), gocui.KeyCtrlR)
+	if b == nil {
+		t.Fatal("<c-r> not bound in search")
+	}
+	if err := b.Handler(); err != nil {
+		t.Fatal(err)
+	}
+	if tg.gui.contextMgr.Current() != "palette" {
+		t.Fatalf("current context = %v, want palette", tg.gui.contextMgr.Current())
+	}
+	p := tg.gui.contexts.Palette.Palette
+	if len(p.Filtered) != 1 || paletteLabel(p.Filtered[0]) != "Pick: #work @2026-01-02 --any" {
+		t.Fatalf("history = %+v, want the pick with its toggled flag", p.Filtered)
+	}
+
+	if err := tg.gui.savePaletteEntry(); err != nil {
+		t.Fatal(err)
+	}
+	if tg.gui.contextMgr.Current() != "inputPopup" {
+		t.Fatalf("expected the name prompt, current context %v", tg.gui.contextMgr.Current())
+	}
+	if err := tg.gui.helpers.InputPopup().HandleEnter("work", nil); err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, call := range mock.Calls {
+		if strings.Join(call, " ") == "query save work #work @2026-01-02 -f" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected the pick saved as a search, calls: %v", mock.Calls)
+	}
+}
+
+func TestSearchHistory_BrowserRerunsSearch(t *testing.T) {
+	mock := defaultMock()
+	tg := newTestGui(t, mock)
+	defer tg.Close()
+
+	tg.gui.helpers.Search().OpenSearch()
+	tg.gui.helpers.Search().ExecuteSearch("#daily")
+	tg.gui.contexts.Search.Query = ""
+
+	if err := tg.gui.helpers.SearchHistory().OpenBrowser(); err != nil {
+		t.Fatal(err)
+	}
+	if err := tg.gui.executePaletteCommand(); err != nil {
+		t.Fatal(err)
+	}
+	if tg.gui.contexts.Search.Query != "#daily" {
+		t.Errorf("search query = %q, want the entry re-run", tg.gui.contexts.Search.Query)
+	}
+	if tg.gui.popupActive() {
+		t.Error("the browser should close after running an entry")
+	}
+}
@@ -0,0 +1,117 @@
+package gui
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	helperspkg "github.com/donnellyk/lazyruin/pkg/gui/helpers"
+	"github.com/donnellyk/lazyruin/pkg/models"
+	"github.com/jesseduffield/gocui"
+)
+
+func TestSearchTerms(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"meeting notes", []string{"meeting", "notes"}},
+		{"#work meeting @today sort:created:desc", []string{"meeting"}},
+		{`"weekly sync" -draft OR plan`, []string{"weekly sync", "plan"}},
+		{`title:"two words" body`, []string{"body"}},
+		{"created:2026-01-01 #tag", nil},
+	}
+	for _, tc := range tests {
+		if got := helperspkg.SearchTerms(tc.query); !slices.Equal(got, tc.want) {
+			t.Errorf("SearchTerms(%q) = %q, want %q", tc.query, got, tc.want)
+		}
+	}
+}
+
+func TestFindTermSpans_MergesOverlapsCaseInsensitively(t *testing.T) {
+	got := findTermSpans("Meeting meet MEET", []string{"meet", "meeting"})
+	want := []textSpan{{0, 7}, {8, 4}, {13, 4}}
+	if !slices.Equal(got, want) {
+		t.Errorf("spans = %v, want %v", got, want)
+	}
+}
+
+func TestSearchMatches_HighlightedAndSteppedWithN(t *testing.T) {
+	dir := t.TempDir()
+	note := func(uuid, content string) models.Note {
+		path := writeNote(t, dir, uuid+".md", "---\nuuid: "+uuid+"\n---\n"+content)
+		return models.Note{UUID: uuid, Title: strings.ToUpper(uuid), Path: path, Content: content}
+	}
+	mock := defaultMock().WithVaultPath(dir).WithNotes(
+		note("a", "Weekly meeting\nnothing here\nmeeting again\n"),
+		note("b", "Standup MEETING\n"),
+	)
+	tg := newTestGui(t, mock)
+	defer tg.Close()
+
+	tg.gui.helpers.Search().OpenSearch()
+	tg.gui.helpers.Search().ExecuteSearch("meeting #work sort:created")
+	cl := tg.gui.contexts.CardList
+	cl.DisplayState().ShowCompose = false
+	if err := tg.g.ForceLayoutAndRedraw(); err != nil {
+		t.Fatal(err)
+	}
+	tg.gui.RenderPreview()
+
+	ns := cl.NavState()
+	if len(ns.Matches) != 3 {
+		t.Fatalf("matches = %+v, want 3", ns.Matches)
+	}
+	for _, m := range ns.Matches {
+		if !strings.Contains(ns.Lines[m.Line].Text, theme.SearchMatch) {
+			t.Errorf("line %d should be highlighted: %q", m.Line, ns.Lines[m.Line].Text)
+		}
+	}
+	if got := tg.gui.searchMatchStatus(); got != "3 matches" {
+		t.Errorf("status = %q, want 3 matches", got)
+	}
+
+	next := bindingByID(tg.gui.contextBindings(cl), "preview.next_match")
+	prev := bindingByID(tg.gui.contextBindings(cl), "preview.prev_match")
+	if next == nil || prev == nil {
+		t.Fatal("n/N not bound in the card list")
+	}
+	ns.CursorLine = 0
+	if err := next.Handler(); err != nil {
+		t.Fatal(err)
+	}
+	if ns.CursorLine != ns.Matches[0].Line || tg.gui.searchMatchStatus() != "match 1/3" {
+		t.Errorf("n should move to the first match, cursor %d, status %q", ns.CursorLine, tg.gui.searchMatchStatus())
+	}
+	if err := prev.Handler(); err != nil {
+		t.Fatal(err)
+	}
+	if ns.CursorLine != ns.Matches[2].Line || tg.gui.searchMatchStatus() != "match 3/3" {
+		t.Errorf("N should wrap to the last match, cursor %d, status %q", ns.CursorLine, tg.gui.searchMatchStatus())
+	}
+	if cl.SelectedCardIdx != 1 {
+		t.Errorf("the card under the match should be selected, got %d", cl.SelectedCardIdx)
+	}
+}
+
+func TestSearchMatches_NFallsThroughWithoutMatches(t *testing.T) {
+	tg := newTestGui(t, defaultMock())
+	defer tg.Close()
+
+	tg.gui.helpers.Search().OpenSearch()
+	tg.gui.helpers.Search().ExecuteSearch("#work")
+	if err := tg.g.ForceLayoutAndRedraw(); err != nil {
+		t.Fatal(err)
+	}
+	// The key is left to gocui, which runs the global binding for it.
+	for _, id := range []string{"preview.next_match", "preview.prev_match"} {
+		b := bindingByID(tg.gui.contextBindings(tg.gui.contexts.CardList), id)
+		if err := b.Handler(); !errors.Is(err, gocui.ErrKeybindingNotHandled) {
+			t.Errorf("%s without matches = %v, want ErrKeybindingNotHandled", id, err)
+		}
+	}
+	if tg.gui.contextMgr.Current() != "cardList" {
+		t.Errorf("current context = %v, want the card list untouched", tg.gui.contextMgr.Current())
+	}
+}
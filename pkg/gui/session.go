@@ -0,0 +1,50 @@
+package gui
+
+import (
+	"github.com/donnellyk/lazyruin/pkg/gui/types"
+	"github.com/donnellyk/lazyruin/pkg/session"
+)
+
+// saveSession writes the active vault's session: the helper captures tabs,
+// preview and history, and the focused panel is taken from the context
+// stack. Quick capture and quick link never restored a session, so they
+// don't overwrite one either.
+func (gui *Gui) saveSession() {
+	if gui.QuickCapture || gui.QuickLink {
+		return
+	}
+	sess := gui.helpers.Session().Capture()
+	sess.Focus = string(gui.sessionFocus())
+	_ = gui.helpers.Session().Save(sess)
+}
+
+// sessionFocus returns the topmost panel on the context stack, skipping
+// popups and dialogs open at quit time.
+func (gui *Gui) sessionFocus() types.ContextKey {
+	stack := gui.contextMgr.Stack()
+	for i := len(stack) - 1; i >= 0; i-- {
+		ctx := gui.contextMgr.ContextByKey(stack[i])
+		if ctx == nil {
+			continue
+		}
+		if kind := ctx.GetKind(); kind == types.SIDE_CONTEXT || kind == types.MAIN_CONTEXT {
+			return stack[i]
+		}
+	}
+	return ""
+}
+
+// restoreSessionView restores sess's preview and history, then returns
+// focus to the panel that had it. Reports false when there was no preview
+// to restore; the caller then loads today's date preview.
+func (gui *Gui) restoreSessionView(sess *session.Session) bool {
+	if !gui.helpers.Session().RestorePreview(sess) {
+		return false
+	}
+	focus := types.ContextKey(sess.Focus)
+	if ctx := gui.contextMgr.ContextByKey(focus); ctx != nil && ctx.GetKind() == types.SIDE_CONTEXT {
+		gui.contextMgr.SetStack([]types.ContextKey{focus})
+		gui.activateContext(focus)
+	}
+	return true
+}
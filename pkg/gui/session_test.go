@@ -0,0 +1,112 @@
+package gui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/donnellyk/lazyruin/pkg/gui/context"
+	"github.com/donnellyk/lazyruin/pkg/session"
+)
+
+// relaunch closes from and copies its saved session into a fresh headless
+// gui for the same vault, then runs its first layout again, as the next
+// launch would.
+func relaunch(t *testing.T, from *testGui) *testGui {
+	t.Helper()
+	vault := from.gui.ruinCmd.VaultPath()
+	data, err := os.ReadFile(session.PathForVault(vault))
+	if err != nil {
+		t.Fatalf("no session saved: %v", err)
+	}
+	from.Close()
+
+	tg := newTestGui(t, defaultMock())
+	path := session.PathForVault(vault)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tg.gui.state.Initialized = false
+	if err := tg.g.ForceLayoutAndRedraw(); err != nil {
+		t.Fatal(err)
+	}
+	return tg
+}
+
+func TestSession_RestoresTabsPreviewHistoryAndFocus(t *testing.T) {
+	tg := newTestGui(t, defaultMock())
+
+	tg.gui.globalController.FocusQueries()
+	tg.gui.globalController.FocusQueries() // Queries tab
+	tg.gui.contexts.Tags.CurrentTab = context.TagsTabInline
+	if err := tg.gui.helpers.Queries().RunQuery(); err != nil {
+		t.Fatal(err)
+	}
+	cl := tg.gui.contexts.CardList
+	cl.SelectedCardIdx = 1
+	cl.NavState().CursorLine = 3
+	tg.gui.pushContextByKey("tags")
+	tg.gui.saveSession()
+
+	next := relaunch(t, tg)
+	defer next.Close()
+	gui := next.gui
+
+	if gui.contexts.Queries.CurrentTab != context.QueriesTabQueries {
+		t.Errorf("queries tab = %q, want queries", gui.contexts.Queries.CurrentTab)
+	}
+	if gui.contexts.Tags.CurrentTab != context.TagsTabInline {
+		t.Errorf("tags tab = %q, want inline", gui.contexts.Tags.CurrentTab)
+	}
+	if gui.contexts.ActivePreviewKey != "cardList" {
+		t.Fatalf("ActivePreviewKey = %q, want cardList", gui.contexts.ActivePreviewKey)
+	}
+	restored := gui.contexts.CardList
+	if restored.Title() != "Query: daily-notes" {
+		t.Errorf("title = %q", restored.Title())
+	}
+	if len(restored.Cards) == 0 {
+		t.Error("the query should be re-run on restore")
+	}
+	if restored.SelectedCardIdx != 1 || restored.NavState().CursorLine != 3 {
+		t.Errorf("selection/cursor = %d/%d, want 1/3", restored.SelectedCardIdx, restored.NavState().CursorLine)
+	}
+	mgr := gui.helpers.Navigator().Manager()
+	if mgr.Len() != 2 || mgr.Index() != 1 {
+		t.Errorf("history len/index = %d/%d, want 2/1", mgr.Len(), mgr.Index())
+	}
+	if got := gui.contextMgr.Current(); got != "tags" {
+		t.Errorf("focus = %q, want tags", got)
+	}
+
+	// Back still works through the restored history.
+	if err := gui.helpers.Navigator().Back(); err != nil {
+		t.Fatal(err)
+	}
+	if gui.contexts.ActivePreviewKey != "datePreview" {
+		t.Errorf("Back landed on %q, want datePreview", gui.contexts.ActivePreviewKey)
+	}
+}
+
+func TestSession_CorruptFileStartsFresh(t *testing.T) {
+	tg := newTestGui(t, defaultMock())
+	defer tg.Close()
+
+	path := session.PathForVault(tg.gui.ruinCmd.VaultPath())
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tg.gui.state.Initialized = false
+	if err := tg.g.ForceLayoutAndRedraw(); err != nil {
+		t.Fatal(err)
+	}
+	if tg.gui.contexts.ActivePreviewKey != "datePreview" {
+		t.Errorf("ActivePreviewKey = %q, want the date preview", tg.gui.contexts.ActivePreviewKey)
+	}
+}
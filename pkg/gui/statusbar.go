@@ -15,7 +15,25 @@ func (gui *Gui) ShowError(err error) {
 		return
 	}
 	gui.views.Status.Clear()
-	fmt.Fprintf(gui.views.Status, " %sError: %s%s", AnsiYellow, err.Error(), AnsiReset)
+	fmt.Fprintf(gui.views.Status, " %sError: %s%s", theme.Warning, err.Error(), AnsiReset)
+
+	go func() {
+		time.Sleep(3 * time.Second)
+		gui.g.Update(func(g *gocui.Gui) error {
+			gui.UpdateStatusBar()
+			return nil
+		})
+	}()
+}
+
+// ShowStatus displays an informational message in the status bar for 3
+// seconds, then restores the keybinding hints.
+func (gui *Gui) ShowStatus(msg string) {
+	if gui.views.Status == nil || msg == "" {
+		return
+	}
+	gui.views.Status.Clear()
+	fmt.Fprintf(gui.views.Status, " %s%s%s", theme.Success, msg, AnsiReset)
 
 	go func() {
 		time.Sleep(3 * time.Second)
@@ -34,8 +52,16 @@ func (gui *Gui) UpdateStatusBar() {
 	gui.views.Status.Clear()
 
 	hints := gui.statusBarHints()
+	// With several vaults configured, lead with the active one so it's
+	// clear which vault an action will touch.
+	if len(gui.helpers.Vault().Vaults()) > 0 {
+		fmt.Fprintf(gui.views.Status, " %s%s%s |", theme.Title, gui.helpers.Vault().ActiveName(), AnsiReset)
+		if gui.state.StartupWarning == "" {
+			fmt.Fprint(gui.views.Status, " ")
+		}
+	}
 	if gui.state.StartupWarning != "" {
-		fmt.Fprintf(gui.views.Status, " %s⚠ %s%s", AnsiYellow, gui.state.StartupWarning, AnsiReset)
+		fmt.Fprintf(gui.views.Status, " %s⚠ %s%s", theme.Warning, gui.state.StartupWarning, AnsiReset)
 		if len(hints) > 0 {
 			fmt.Fprint(gui.views.Status, " | ")
 		}
@@ -45,8 +71,31 @@ func (gui *Gui) UpdateStatusBar() {
 		if i > 0 {
 			fmt.Fprint(gui.views.Status, " | ")
 		}
-		fmt.Fprintf(gui.views.Status, "%s: %s%s%s", h.action, AnsiCyan, h.key, AnsiReset)
+		fmt.Fprintf(gui.views.Status, "%s: %s%s%s", h.action, theme.Accent, h.key, AnsiReset)
+	}
+
+	if m := gui.searchMatchStatus(); m != "" {
+		if len(hints) > 0 {
+			fmt.Fprint(gui.views.Status, " | ")
+		}
+		fmt.Fprintf(gui.views.Status, "%s%s%s", theme.Title, m, AnsiReset)
+	}
+}
+
+// searchMatchStatus describes the search or find matches highlighted in
+// the preview: "match 3/17" once n/N has moved to one, "17 matches" before.
+func (gui *Gui) searchMatchStatus() string {
+	ns := gui.contexts.ActivePreview().NavState()
+	if len(ns.Matches) == 0 {
+		return ""
+	}
+	if cur := ns.ActiveMatch(); cur >= 0 {
+		return fmt.Sprintf("match %d/%d", cur+1, len(ns.Matches))
+	}
+	if len(ns.Matches) == 1 {
+		return "1 match"
 	}
+	return fmt.Sprintf("%d matches", len(ns.Matches))
 }
 
 // notesTabIndex returns the index for the current notes tab. In
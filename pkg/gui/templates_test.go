@@ -0,0 +1,111 @@
+package gui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/donnellyk/lazyruin/pkg/config"
+)
+
+// chooseTemplate opens "New from Template" and runs the named entry.
+func chooseTemplate(t *testing.T, tg *testGui, name string) {
+	t.Helper()
+	b := bindingByID(tg.gui.contextBindings(tg.gui.contexts.Global), "global.new_from_template")
+	if b == nil {
+		t.Fatal("global.new_from_template not bound")
+	}
+	if err := b.Handler(); err != nil {
+		t.Fatal(err)
+	}
+	d := tg.gui.state.Dialog
+	if d == nil || d.Title != "New from Template" {
+		t.Fatalf("expected the template menu, got %+v", d)
+	}
+	for _, item := range d.MenuItems {
+		if item.Label == name {
+			tg.gui.closeDialog()
+			if err := item.OnRun(); err != nil {
+				t.Fatal(err)
+			}
+			return
+		}
+	}
+	t.Fatalf("template %q not in menu %+v", name, d.MenuItems)
+}
+
+func TestNewFromTemplate_PromptsAndPrefillsCapture(t *testing.T) {
+	tg := newTestGui(t, defaultMock())
+	defer tg.Close()
+	tg.gui.config.Templates = []config.NoteTemplate{{
+		Name:    "Meeting",
+		Body:    "# {{.Title}}\n{{.Weekday}} {{.Date}} with {{.Form.Who}}\n",
+		Parent:  "journal",
+		Tags:    []string{"meeting", "#work"},
+		Prompts: []config.CustomCommandPrompt{{Key: "Who", Title: "Attendees"}},
+	}}
+
+	chooseTemplate(t, tg, "Meeting")
+	for _, answer := range []struct{ title, value string }{{"Title", "Standup"}, {"Attendees", "Sam"}} {
+		if tg.gui.contextMgr.Current() != "inputPopup" || tg.gui.contexts.InputPopup.Config.Title != answer.title {
+			t.Fatalf("expected the %q prompt, current context %v", answer.title, tg.gui.contextMgr.Current())
+		}
+		if err := tg.gui.helpers.InputPopup().HandleEnter(answer.value, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if tg.gui.contextMgr.Current() != "capture" {
+		t.Fatalf("expected the capture popup, got %v", tg.gui.contextMgr.Current())
+	}
+	ctx := tg.gui.contexts.Capture
+	now := time.Now()
+	want := "# Standup\n" + now.Weekday().String() + " " + now.Format("2006-01-02") + " with Sam\n\n#meeting #work"
+	if ctx.PrefillContent != want {
+		t.Errorf("prefill = %q, want %q", ctx.PrefillContent, want)
+	}
+	if ctx.CursorLine != 2 {
+		t.Errorf("CursorLine = %d, want the blank line above the tags", ctx.CursorLine)
+	}
+	if ctx.Parent == nil || ctx.Parent.UUID != "parent-1" {
+		t.Errorf("Parent = %+v, want the journal bookmark", ctx.Parent)
+	}
+}
+
+func TestNewFromTemplate_LoadsVaultTemplatesFolder(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, config.TemplatesDir), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeNote(t, filepath.Join(dir, config.TemplatesDir), "weekly.md", "---\ntags: [review]\n---\n\nWeek of {{.Date}}\n")
+
+	tg := newTestGui(t, defaultMock().WithVaultPath(dir))
+	defer tg.Close()
+
+	chooseTemplate(t, tg, "weekly")
+	ctx := tg.gui.contexts.Capture
+	want := "Week of " + time.Now().Format("2006-01-02") + "\n\n#review"
+	if tg.gui.contextMgr.Current() != "capture" || ctx.PrefillContent != want {
+		t.Errorf("context %v, prefill = %q, want %q", tg.gui.contextMgr.Current(), ctx.PrefillContent, want)
+	}
+	if ctx.Parent != nil {
+		t.Errorf("Parent = %+v, want none", ctx.Parent)
+	}
+}
+
+func TestNewFromTemplate_NoTemplates(t *testing.T) {
+	tg := newTestGui(t, defaultMock().WithVaultPath(t.TempDir()))
+	defer tg.Close()
+
+	if err := tg.gui.helpers.Templates().Open(); err != nil {
+		t.Fatal(err)
+	}
+	if tg.gui.state.Dialog != nil {
+		t.Error("no menu should open without templates")
+	}
+	if status := tg.gui.views.Status.Buffer(); !strings.Contains(status, "No templates") {
+		t.Errorf("status = %q", status)
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/donnellyk/lazyruin/pkg/commands"
 	"github.com/donnellyk/lazyruin/pkg/config"
+	"github.com/donnellyk/lazyruin/pkg/remote"
 	"github.com/donnellyk/lazyruin/pkg/testutil"
 
 	"github.com/jesseduffield/gocui"
@@ -21,9 +22,12 @@ type testGui struct {
 
 // testGuiOpts allows configuring the test GUI before layout runs.
 type testGuiOpts struct {
-	OpenRef      string
-	QuickLink    bool
-	QuickLinkURL string
+	OpenRef        string
+	QuickLink      bool
+	QuickLinkURL   string
+	Keybindings    map[string]map[string]string
+	CustomCommands []config.CustomCommand
+	RemoteRequest  *remote.Request
 }
 
 // newTestGui creates a headless GUI with mock data.
@@ -43,9 +47,10 @@ func newTestGuiWithOpts(t *testing.T, mock *testutil.MockExecutor, opts testGuiO
 	ruin := commands.NewRuinCommandWithExecutor(mock, mock.VaultPath())
 	// Suppress the empty-vault onboarding prompt in tests. Tests that want to
 	// exercise the prompt flow should override this flag before layout runs.
-	cfg := &config.Config{OnboardingOffered: true}
+	cfg := &config.Config{OnboardingOffered: true, Keybindings: opts.Keybindings, CustomCommands: opts.CustomCommands}
 	gui := NewGui(cfg, ruin)
 	gui.OpenRef = opts.OpenRef
+	gui.RemoteRequest = opts.RemoteRequest
 	gui.QuickLink = opts.QuickLink
 	gui.QuickLinkURL = opts.QuickLinkURL
 
@@ -0,0 +1,386 @@
+package gui
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/donnellyk/lazyruin/pkg/config"
+
+	"github.com/jesseduffield/gocui"
+)
+
+// Theme assigns a style to each semantic color role. Text roles are ANSI
+// sequences written into views; frame roles are gocui attributes. Tag,
+// Date and Link are chroma style entries layered over the chroma theme in
+// the preview; empty keeps the chroma theme's own colors.
+type Theme struct {
+	Selected        string    // selected line in lists, menus, palette and calendar
+	Muted           string    // secondary text: dates, counts, hints, empty states
+	Title           string    // section titles, days of the shown month
+	Key             string    // key column in the help menu and palette
+	Accent          string    // menu headers, status-bar key hints, palette matches
+	Success         string    // status-bar confirmations
+	Warning         string    // status-bar errors and warnings
+	Separator       string    // card borders in the preview
+	ActiveSeparator string    // border of the card under the cursor
+	Done            string    // completed todo lines
+	CursorLine      string    // preview cursor line; background or reverse only
+	LinkHighlight   string    // link under the cursor in the preview
+	SearchMatch     string    // search terms in the cards of a search
+	Activity        [3]string // contribution grid: 1, 2 and 3+ notes
+
+	Tag  string
+	Date string
+	Link string
+
+	FrameActive gocui.Attribute // focused panel and popup frames, selected tab
+	FrameAlert  gocui.Attribute // active search filter, completion and migration popups
+
+	// ChromaStyle is the preset's chroma style, used when chroma_theme is
+	// unset. Empty follows the terminal background.
+	ChromaStyle string
+}
+
+// cursorLineEnd closes a CursorLine style without a full reset, which
+// would wipe foreground colors chroma leaves active across lines.
+const cursorLineEnd = "\x1b[27;49m"
+
+// theme is the active theme. Package state like the ANSI constants it is
+// built from: set once by applyTheme before the main loop starts.
+var theme = darkTheme
+
+var darkTheme = Theme{
+	Selected:        AnsiBlueBgWhite,
+	Muted:           AnsiDim,
+	Title:           AnsiBoldWhite,
+	Key:             AnsiGreen,
+	Accent:          AnsiCyan,
+	Success:         AnsiGreen,
+	Warning:         AnsiYellow,
+	Separator:       AnsiDim,
+	ActiveSeparator: AnsiGreen + AnsiDim,
+	Done:            AnsiDim,
+	CursorLine:      AnsiDimBg,
+	LinkHighlight:   AnsiDimBg,
+	SearchMatch:     "\x1b[30;43m",
+	Activity:        [3]string{AnsiGreen1, AnsiGreen2, AnsiGreen3},
+	FrameActive:     gocui.ColorGreen,
+	FrameAlert:      gocui.ColorYellow,
+	ChromaStyle:     "catppuccin-mocha",
+}
+
+var lightTheme = Theme{
+	Selected:        AnsiBlueBgWhite,
+	Muted:           AnsiDim,
+	Title:           "\x1b[1m",
+	Key:             AnsiGreen,
+	Accent:          AnsiCyan,
+	Success:         AnsiGreen,
+	Warning:         AnsiYellow,
+	Separator:       AnsiDim,
+	ActiveSeparator: AnsiGreen + AnsiDim,
+	Done:            AnsiDim,
+	CursorLine:      "\x1b[48;5;254m",
+	LinkHighlight:   "\x1b[48;5;252m",
+	SearchMatch:     "\x1b[48;5;229m",
+	Activity:        [3]string{"\x1b[38;5;114m", "\x1b[38;5;34m", "\x1b[38;5;22m"},
+	FrameActive:     gocui.ColorGreen,
+	FrameAlert:      gocui.ColorYellow,
+	ChromaStyle:     "catppuccin-latte",
+}
+
+// highContrastTheme avoids dim text and mid-gray backgrounds, which wash
+// out on low-contrast terminals.
+var highContrastTheme = Theme{
+	Selected:        "\x1b[1;30;43m",
+	Muted:           "\x1b[37m",
+	Title:           "\x1b[1;97m",
+	Key:             "\x1b[1;92m",
+	Accent:          "\x1b[1;96m",
+	Success:         "\x1b[1;92m",
+	Warning:         "\x1b[1;93m",
+	Separator:       "\x1b[37m",
+	ActiveSeparator: "\x1b[1;92m",
+	Done:            "\x1b[3;37m",
+	CursorLine:      "\x1b[44m",
+	LinkHighlight:   "\x1b[1;30;43m",
+	SearchMatch:     "\x1b[1;30;106m",
+	Activity:        [3]string{"\x1b[32m", "\x1b[92m", "\x1b[1;92m"},
+	Tag:             "bold #ff87ff",
+	Date:            "bold #ffff5f",
+	Link:            "bold underline #5fd7ff",
+	FrameActive:     gocui.ColorGreen | gocui.AttrBold,
+	FrameAlert:      gocui.ColorYellow | gocui.AttrBold,
+}
+
+// monochromeTheme uses attributes only. Selected when NO_COLOR is set.
+var monochromeTheme = Theme{
+	Selected:        "\x1b[7m",
+	Muted:           AnsiDim,
+	Title:           "\x1b[1m",
+	Key:             "\x1b[1m",
+	Accent:          "\x1b[1m",
+	Success:         "",
+	Warning:         "\x1b[1m",
+	Separator:       AnsiDim,
+	ActiveSeparator: "\x1b[1m",
+	Done:            AnsiDim,
+	CursorLine:      "\x1b[7m",
+	LinkHighlight:   "\x1b[4m",
+	SearchMatch:     "\x1b[1;4m",
+	Activity:        [3]string{"", "\x1b[1m", "\x1b[1;7m"},
+	FrameActive:     gocui.ColorDefault | gocui.AttrBold,
+	FrameAlert:      gocui.ColorDefault | gocui.AttrBold,
+	ChromaStyle:     "bw",
+}
+
+var themePresets = map[string]Theme{
+	"dark":          darkTheme,
+	"light":         lightTheme,
+	"high-contrast": highContrastTheme,
+	"monochrome":    monochromeTheme,
+}
+
+// themeRoles maps each `theme:` config key to the field it sets.
+var themeRoles = map[string]func(t *Theme, s colorSpec){
+	"selected":         func(t *Theme, s colorSpec) { t.Selected = s.ansi() },
+	"muted":            func(t *Theme, s colorSpec) { t.Muted = s.ansi() },
+	"title":            func(t *Theme, s colorSpec) { t.Title = s.ansi() },
+	"key":              func(t *Theme, s colorSpec) { t.Key = s.ansi() },
+	"accent":           func(t *Theme, s colorSpec) { t.Accent = s.ansi() },
+	"success":          func(t *Theme, s colorSpec) { t.Success = s.ansi() },
+	"warning":          func(t *Theme, s colorSpec) { t.Warning = s.ansi() },
+	"separator":        func(t *Theme, s colorSpec) { t.Separator = s.ansi() },
+	"separator_active": func(t *Theme, s colorSpec) { t.ActiveSeparator = s.ansi() },
+	"done":             func(t *Theme, s colorSpec) { t.Done = s.ansi() },
+	"cursor_line":      func(t *Theme, s colorSpec) { t.CursorLine = s.background().ansi() },
+	"link_highlight":   func(t *Theme, s colorSpec) { t.LinkHighlight = s.ansi() },
+	"search_match":     func(t *Theme, s colorSpec) { t.SearchMatch = s.ansi() },
+	"activity_low":     func(t *Theme, s colorSpec) { t.Activity[0] = s.ansi() },
+	"activity_mid":     func(t *Theme, s colorSpec) { t.Activity[1] = s.ansi() },
+	"activity_high":    func(t *Theme, s colorSpec) { t.Activity[2] = s.ansi() },
+	"tag":              func(t *Theme, s colorSpec) { t.Tag = s.chroma() },
+	"date":             func(t *Theme, s colorSpec) { t.Date = s.chroma() },
+	"link":             func(t *Theme, s colorSpec) { t.Link = s.chroma() },
+	"frame_active":     func(t *Theme, s colorSpec) { t.FrameActive = s.attribute() },
+	"frame_alert":      func(t *Theme, s colorSpec) { t.FrameAlert = s.attribute() },
+}
+
+// resolveTheme builds the theme for cfg. NO_COLOR forces the monochrome
+// preset; roles set explicitly in cfg still apply on top. An empty preset
+// follows the terminal background. Problems are returned for the startup
+// warning; the offending entries are skipped.
+func resolveTheme(cfg config.ThemeConfig, darkBackground bool) (Theme, []string) {
+	var problems []string
+
+	preset := cfg.Preset
+	if preset == "" {
+		preset = "dark"
+		if !darkBackground {
+			preset = "light"
+		}
+	}
+	t, ok := themePresets[preset]
+	if !ok {
+		problems = append(problems, fmt.Sprintf("theme: unknown preset %q", cfg.Preset))
+		t = darkTheme
+	}
+	if os.Getenv("NO_COLOR") != "" {
+		t = monochromeTheme
+	}
+
+	roles := make([]string, 0, len(cfg.Roles))
+	for role := range cfg.Roles {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+	for _, role := range roles {
+		set, ok := themeRoles[role]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("theme: unknown role %q", role))
+			continue
+		}
+		spec, err := parseColorSpec(cfg.Roles[role])
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("theme.%s: %v", role, err))
+			continue
+		}
+		set(&t, spec)
+	}
+	return t, problems
+}
+
+// applyTheme activates the configured theme and reports config problems
+// as a startup warning. Called from Run once the terminal background is
+// known.
+func (gui *Gui) applyTheme() {
+	var cfg config.ThemeConfig
+	if gui.config != nil {
+		cfg = gui.config.Theme
+	}
+	t, problems := resolveTheme(cfg, gui.darkBackground)
+	theme = t
+	gui.chromaStyle = nil
+	if len(problems) > 0 {
+		gui.appendStartupWarning(strings.Join(problems, "; "))
+	}
+}
+
+// colorSpec is a parsed theme value such as "bold white on blue": any of
+// bold, dim, italic, underline and reverse, then an optional foreground and
+// an optional background introduced by "on". A color is a name (black, red,
+// green, yellow, blue, magenta, cyan, white, optionally prefixed with
+// "bright"), "default", a 256-color index, or #rrggbb.
+type colorSpec struct {
+	fg, bg                                *gocui.Attribute
+	bold, dim, italic, underline, reverse bool
+}
+
+var colorNames = []string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
+
+func parseColorSpec(spec string) (colorSpec, error) {
+	var s colorSpec
+	onBg := false
+	for _, word := range strings.Fields(strings.ToLower(spec)) {
+		switch word {
+		case "bold":
+			s.bold = true
+		case "dim":
+			s.dim = true
+		case "italic":
+			s.italic = true
+		case "underline":
+			s.underline = true
+		case "reverse":
+			s.reverse = true
+		case "on":
+			onBg = true
+		default:
+			c, err := parseColor(word)
+			if err != nil {
+				return colorSpec{}, err
+			}
+			if onBg {
+				s.bg = &c
+			} else {
+				s.fg = &c
+			}
+		}
+	}
+	return s, nil
+}
+
+func parseColor(word string) (gocui.Attribute, error) {
+	if word == "default" {
+		return gocui.ColorDefault, nil
+	}
+	name, bright := strings.CutPrefix(word, "bright")
+	for i, n := range colorNames {
+		if name == n {
+			if bright {
+				i += 8
+			}
+			return gocui.Get256Color(int32(i)), nil
+		}
+	}
+	if hex, ok := strings.CutPrefix(word, "#"); ok && len(hex) == 6 {
+		if rgb, err := strconv.ParseUint(hex, 16, 32); err == nil {
+			return gocui.NewRGBColor(int32(rgb>>16), int32(rgb>>8&0xff), int32(rgb&0xff)), nil
+		}
+	}
+	if n, err := strconv.Atoi(word); err == nil && n >= 0 && n <= 255 {
+		return gocui.Get256Color(int32(n)), nil
+	}
+	return 0, fmt.Errorf("unknown color or attribute %q", word)
+}
+
+// background keeps only what a CursorLine style may use, so closing it
+// with cursorLineEnd leaves nothing behind.
+func (s colorSpec) background() colorSpec {
+	return colorSpec{bg: s.bg, reverse: s.reverse}
+}
+
+// ansi renders s as an SGR sequence, or "" when s sets nothing.
+func (s colorSpec) ansi() string {
+	var parts []string
+	for _, a := range []struct {
+		on   bool
+		code string
+	}{{s.bold, "1"}, {s.dim, "2"}, {s.italic, "3"}, {s.underline, "4"}, {s.reverse, "7"}} {
+		if a.on {
+			parts = append(parts, a.code)
+		}
+	}
+	if s.fg != nil {
+		parts = append(parts, sgrColor(*s.fg, 30))
+	}
+	if s.bg != nil {
+		parts = append(parts, sgrColor(*s.bg, 40))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "\x1b[" + strings.Join(parts, ";") + "m"
+}
+
+// sgrColor renders c for base 30 (foreground) or 40 (background).
+func sgrColor(c gocui.Attribute, base int) string {
+	switch {
+	case c == gocui.ColorDefault:
+		return strconv.Itoa(base + 9)
+	case c&gocui.AttrIsRGBColor != 0:
+		r, g, b := c.RGB()
+		return fmt.Sprintf("%d;2;%d;%d;%d", base+8, r, g, b)
+	}
+	n := int(c - gocui.AttrIsValidColor)
+	switch {
+	case n < 8:
+		return strconv.Itoa(base + n)
+	case n < 16:
+		return strconv.Itoa(base + 60 + n - 8)
+	}
+	return fmt.Sprintf("%d;5;%d", base+8, n)
+}
+
+// attribute renders s as a gocui frame attribute. Only the foreground and
+// bold, underline and reverse are meaningful for frames.
+func (s colorSpec) attribute() gocui.Attribute {
+	a := gocui.ColorDefault
+	if s.fg != nil {
+		a = *s.fg
+	}
+	if s.bold {
+		a |= gocui.AttrBold
+	}
+	if s.underline {
+		a |= gocui.AttrUnderline
+	}
+	if s.reverse {
+		a |= gocui.AttrReverse
+	}
+	return a
+}
+
+// chroma renders s as a chroma style entry. Chroma has no dim or reverse.
+func (s colorSpec) chroma() string {
+	var parts []string
+	if s.bold {
+		parts = append(parts, "bold")
+	}
+	if s.italic {
+		parts = append(parts, "italic")
+	}
+	if s.underline {
+		parts = append(parts, "underline")
+	}
+	if s.fg != nil && *s.fg != gocui.ColorDefault {
+		parts = append(parts, fmt.Sprintf("#%06x", s.fg.Hex()))
+	}
+	if s.bg != nil && *s.bg != gocui.ColorDefault {
+		parts = append(parts, fmt.Sprintf("bg:#%06x", s.bg.Hex()))
+	}
+	return strings.Join(parts, " ")
+}
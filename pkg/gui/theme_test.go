@@ -0,0 +1,122 @@
+package gui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/donnellyk/lazyruin/pkg/config"
+
+	"github.com/jesseduffield/gocui"
+)
+
+func TestParseColorSpec_ANSI(t *testing.T) {
+	tests := []struct {
+		spec string
+		want string
+	}{
+		{"", ""},
+		{"bold", "\x1b[1m"},
+		{"white on blue", "\x1b[37;44m"},
+		{"bold brightyellow", "\x1b[1;93m"},
+		{"238", "\x1b[38;5;238m"},
+		{"on 238", "\x1b[48;5;238m"},
+		{"#ff8800", "\x1b[38;2;255;136;0m"},
+		{"reverse default", "\x1b[7;39m"},
+	}
+	for _, tt := range tests {
+		s, err := parseColorSpec(tt.spec)
+		if err != nil {
+			t.Errorf("parseColorSpec(%q) error: %v", tt.spec, err)
+			continue
+		}
+		if got := s.ansi(); got != tt.want {
+			t.Errorf("parseColorSpec(%q).ansi() = %q, want %q", tt.spec, got, tt.want)
+		}
+	}
+	if _, err := parseColorSpec("blurple"); err == nil {
+		t.Error("parseColorSpec(blurple) should fail")
+	}
+}
+
+func TestColorSpec_FrameAndChroma(t *testing.T) {
+	s, err := parseColorSpec("bold #ff8800")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := s.attribute(), gocui.NewRGBColor(255, 136, 0)|gocui.AttrBold; got != want {
+		t.Errorf("attribute() = %v, want %v", got, want)
+	}
+	if got := s.chroma(); got != "bold #ff8800" {
+		t.Errorf("chroma() = %q, want %q", got, "bold #ff8800")
+	}
+}
+
+func TestResolveTheme_PresetFollowsBackground(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	if got, _ := resolveTheme(config.ThemeConfig{}, true); got.CursorLine != darkTheme.CursorLine {
+		t.Error("empty preset on a dark terminal should use the dark theme")
+	}
+	if got, _ := resolveTheme(config.ThemeConfig{}, false); got.CursorLine != lightTheme.CursorLine {
+		t.Error("empty preset on a light terminal should use the light theme")
+	}
+	if got, _ := resolveTheme(config.ThemeConfig{Preset: "high-contrast"}, false); got.Selected != highContrastTheme.Selected {
+		t.Error("explicit preset should win over the terminal background")
+	}
+}
+
+func TestResolveTheme_RolesAndProblems(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	got, problems := resolveTheme(config.ThemeConfig{
+		Preset: "solarized",
+		Roles: map[string]string{
+			"selected":    "black on yellow",
+			"cursor_line": "bold red on 236",
+			"sparkle":     "red",
+			"muted":       "grey",
+		},
+	}, true)
+
+	if got.Selected != "\x1b[30;43m" {
+		t.Errorf("Selected = %q", got.Selected)
+	}
+	if got.CursorLine != "\x1b[48;5;236m" {
+		t.Errorf("CursorLine = %q, want background only", got.CursorLine)
+	}
+	if got.Muted != darkTheme.Muted {
+		t.Errorf("Muted = %q, want the preset value after a bad spec", got.Muted)
+	}
+	want := []string{
+		`theme: unknown preset "solarized"`,
+		`theme.muted: unknown color or attribute "grey"`,
+		`theme: unknown role "sparkle"`,
+	}
+	if strings.Join(problems, "\n") != strings.Join(want, "\n") {
+		t.Errorf("problems = %q, want %q", problems, want)
+	}
+}
+
+func TestResolveTheme_NoColorIsMonochrome(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	got, _ := resolveTheme(config.ThemeConfig{
+		Preset: "dark",
+		Roles:  map[string]string{"warning": "red"},
+	}, true)
+	if got.Selected != monochromeTheme.Selected || got.ChromaStyle != "bw" {
+		t.Error("NO_COLOR should select the monochrome theme")
+	}
+	if got.Warning != "\x1b[31m" {
+		t.Errorf("explicit roles should still apply under NO_COLOR, got %q", got.Warning)
+	}
+}
+
+func TestHighlightStyle_ThemeTagColor(t *testing.T) {
+	saved := theme
+	t.Cleanup(func() { theme = saved })
+	theme.Tag = "#ff0000"
+
+	gui := &Gui{config: &config.Config{}, darkBackground: true}
+	out := gui.highlightMarkdown("see #urgent")
+	if !strings.Contains(out, "\x1b[38;5;196m#urgent") {
+		t.Errorf("tag not colored by theme: %q", out)
+	}
+}
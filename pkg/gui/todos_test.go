@@ -0,0 +1,195 @@
+package gui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/donnellyk/lazyruin/pkg/config"
+	"github.com/donnellyk/lazyruin/pkg/donelog"
+	"github.com/donnellyk/lazyruin/pkg/gui/context"
+	"github.com/donnellyk/lazyruin/pkg/gui/types"
+	"github.com/donnellyk/lazyruin/pkg/models"
+	"github.com/donnellyk/lazyruin/pkg/testutil"
+)
+
+// todosMock returns a vault whose pick results hold an overdue todo, a
+// dated one, an undated one and a done one.
+func todosMock(t *testing.T) *testutil.MockExecutor {
+	t.Helper()
+	dir := t.TempDir()
+	writeNote(t, dir, "one.md", "---\nuuid: 1\n---\n- [ ] pay rent @2020-01-01\n- [ ] call Sam\n")
+	writeNote(t, dir, "two.md", "---\nuuid: 2\n---\n- [ ] ship it @2999-01-01 #work\n- [x] old thing\n")
+	return defaultMock().WithVaultPath(dir).WithPickResults(
+		models.PickResult{UUID: "1", Title: "Note One", File: "one.md", Matches: []models.PickMatch{
+			{Line: 1, Content: "- [ ] pay rent @2020-01-01"},
+			{Line: 2, Content: "- [ ] call Sam"},
+		}},
+		models.PickResult{UUID: "2", Title: "Note Two", File: "two.md", Matches: []models.PickMatch{
+			{Line: 1, Content: "- [ ] ship it @2999-01-01 #work", Tags: []string{"#work"}},
+			{Line: 2, Content: "- [x] old thing", Done: true},
+		}},
+	)
+}
+
+func openTodos(t *testing.T, tg *testGui) *context.TodosContext {
+	t.Helper()
+	b := bindingByID(tg.gui.contextBindings(tg.gui.contexts.Global), "global.todos")
+	if b == nil {
+		t.Fatal("global.todos not bound")
+	}
+	if err := b.Handler(); err != nil {
+		t.Fatal(err)
+	}
+	if tg.gui.contextMgr.Current() != "todos" {
+		t.Fatalf("current context = %v, want todos", tg.gui.contextMgr.Current())
+	}
+	return tg.gui.contexts.Todos
+}
+
+// todoRows renders rows as "# header" and item content, for comparison.
+func todoRows(ctx *context.TodosContext) []string {
+	var out []string
+	for _, r := range ctx.Rows {
+		if r.Item == nil {
+			out = append(out, "# "+r.Header)
+		} else {
+			out = append(out, r.Item.Content)
+		}
+	}
+	return out
+}
+
+func TestTodos_OverdueFirstThenGroupedByNote(t *testing.T) {
+	tg := newTestGui(t, todosMock(t))
+	defer tg.Close()
+
+	ctx := openTodos(t, tg)
+	want := []string{
+		"# Overdue", "pay rent @2020-01-01",
+		"# Note One", "call Sam",
+		"# Note Two", "ship it @2999-01-01 #work",
+	}
+	if got := todoRows(ctx); strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("rows = %q, want %q", got, want)
+	}
+	if item := ctx.Selected(); item == nil || item.Content != "pay rent @2020-01-01" {
+		t.Errorf("cursor should start on the first todo, got %+v", item)
+	}
+
+	ctx.GroupBy = context.TodoGroupByDate
+	tg.gui.helpers.Todos().Reload()
+	want = []string{
+		"# Overdue", "pay rent @2020-01-01",
+		"# 2999-01-01", "ship it @2999-01-01 #work",
+		"# No date", "call Sam",
+	}
+	if got := todoRows(ctx); strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("rows by date = %q, want %q", got, want)
+	}
+}
+
+func TestTodos_ToggleActsOnSelectedTodo(t *testing.T) {
+	mock := todosMock(t)
+	tg := newTestGui(t, mock)
+	defer tg.Close()
+
+	ctx := openTodos(t, tg)
+	ctx.SelectedIdx = ctx.NextItem(ctx.SelectedIdx) // call Sam
+	var toggle *types.Binding
+	for _, b := range tg.gui.contextBindings(ctx) {
+		if b.Key == 'x' {
+			toggle = b
+		}
+	}
+	if toggle == nil {
+		t.Fatal("x not bound in todos")
+	}
+	if err := toggle.Handler(); err != nil {
+		t.Fatal(err)
+	}
+
+	found := false
+	for _, call := range mock.Calls {
+		if strings.Contains(strings.Join(call, " "), "note set 1 --toggle-todo --line 2") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected a toggle of note 1 line 2, calls: %v", mock.Calls)
+	}
+	if tg.gui.contextMgr.Current() != "todos" {
+		t.Error("the dashboard should stay open after toggling")
+	}
+}
+
+func TestTodos_FiltersPersist(t *testing.T) {
+	tg := newTestGui(t, todosMock(t))
+	defer tg.Close()
+
+	ctx := openTodos(t, tg)
+	tg.gui.helpers.Todos().ToggleNoDate()
+	if got := todoRows(ctx); strings.Join(got, "|") != "# Note One|call Sam" {
+		t.Errorf("no-date rows = %q", got)
+	}
+	tg.gui.helpers.Todos().ToggleHasDate()
+	if opts := tg.gui.config.ViewOptions.Todos; !opts.HasDate || opts.NoDate {
+		t.Errorf("has date should replace no date, got %+v", opts)
+	}
+
+	saved, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !saved.ViewOptions.Todos.HasDate {
+		t.Error("filters should be saved to config")
+	}
+}
+
+func TestTodos_RecentDone(t *testing.T) {
+	mock := todosMock(t)
+	tg := newTestGui(t, mock)
+	defer tg.Close()
+
+	ctx := openTodos(t, tg)
+	tg.gui.helpers.Todos().ToggleRecentDone()
+	if got := strings.Join(todoRows(ctx), "|"); strings.Contains(got, "old thing") {
+		t.Errorf("a todo with no recorded completion should be hidden, rows %q", got)
+	}
+
+	store := donelog.NewStoreForVault(mock.VaultPath())
+	store.Mark("2", "old thing", time.Now().Add(-30*24*time.Hour))
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+	tg.gui.helpers.Todos().Reload()
+	if got := strings.Join(todoRows(ctx), "|"); strings.Contains(got, "old thing") {
+		t.Errorf("a todo done a month ago should be hidden, rows %q", got)
+	}
+
+	tg.gui.helpers.Todos().RecordDone("2", "- [x] old thing #done", false)
+	tg.gui.helpers.Todos().RecordDone("2", "- [x] old thing", true)
+	tg.gui.helpers.Todos().Reload()
+	if got := strings.Join(todoRows(ctx), "|"); !strings.Contains(got, "old thing") {
+		t.Errorf("a todo done today should be listed, rows %q", got)
+	}
+}
+
+func TestTodos_ToggleRecordsCompletion(t *testing.T) {
+	mock := todosMock(t)
+	tg := newTestGui(t, mock)
+	defer tg.Close()
+
+	ctx := openTodos(t, tg)
+	ctx.SelectedIdx = ctx.NextItem(ctx.SelectedIdx) // call Sam
+	if err := tg.gui.helpers.PreviewLineOps().ToggleTodo(); err != nil {
+		t.Fatal(err)
+	}
+	store := donelog.NewStoreForVault(mock.VaultPath())
+	if err := store.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := store.DoneAt("1", "call Sam"); !ok {
+		t.Error("checking a todo off should record when it was done")
+	}
+}
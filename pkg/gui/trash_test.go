@@ -0,0 +1,67 @@
+package gui
+
+import (
+	"os"
+	"testing"
+)
+
+func TestTrashNote_CopiesThenDeletes(t *testing.T) {
+	mock := defaultMock()
+	tg := newTestGui(t, mock)
+	defer tg.Close()
+
+	content := "---\nuuid: tr-1\ntags: [\"#x\"]\n---\n\nbody\n"
+	path := writeNote(t, t.TempDir(), "gone.md", content)
+
+	trash := tg.gui.helpers.Trash()
+	mock.Calls = nil
+	if err := trash.TrashNote("tr-1", path, "Gone"); err != nil {
+		t.Fatal(err)
+	}
+	if !hasCall(mock.Calls, "note", "delete", "tr-1", "-f") {
+		t.Errorf("note should be deleted after copying; calls=%v", mock.Calls)
+	}
+
+	if err := trash.OpenBrowser(); err != nil {
+		t.Fatal(err)
+	}
+	items := tg.gui.contexts.TrashBrowser.Items
+	if len(items) != 1 || items[0].UUID != "tr-1" || items[0].Title != "Gone" {
+		t.Fatalf("trash items = %+v, want the deleted note", items)
+	}
+
+	// The mock doesn't touch the filesystem; remove the file as ruin would.
+	os.Remove(path)
+	if err := trash.RestoreItem(items[0]); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("restored file missing: %v", err)
+	}
+	if string(got) != content {
+		t.Errorf("restored content = %q, want frontmatter intact %q", got, content)
+	}
+	if n := len(tg.gui.contexts.TrashBrowser.Items); n != 0 {
+		t.Errorf("trash should be empty after restore, has %d items", n)
+	}
+}
+
+func TestTrashNote_UndoRestoresFromTrash(t *testing.T) {
+	mock := defaultMock()
+	tg := newTestGui(t, mock)
+	defer tg.Close()
+
+	path := writeNote(t, t.TempDir(), "undo.md", "---\nuuid: tr-2\n---\n\nbody\n")
+	if err := tg.gui.helpers.Trash().TrashNote("tr-2", path, "Undo Me"); err != nil {
+		t.Fatal(err)
+	}
+	os.Remove(path)
+
+	if err := tg.gui.helpers.Undo().Undo(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("undo should restore the note: %v", err)
+	}
+}
@@ -61,9 +61,14 @@ type IGuiCommon interface {
 	ShowConfirm(title, message string, onConfirm func() error)
 	ShowInput(title, message string, onConfirm func(string) error)
 	ShowError(err error)
+	ShowStatus(msg string)
 	ShowMenuDialog(title string, items []MenuItem)
+	ShowPaletteList(title, footer string, items []PaletteCommand)
 	ShowAbout()
 
+	// Vaults
+	SwitchVault(path string) error
+
 	// Search
 	SetCursorEnabled(enabled bool)
 
@@ -7,12 +7,19 @@ type PaletteCommand struct {
 	Key      string
 	OnRun    func() error
 	Contexts []ContextKey // nil = always available
+	ID       string       // frecency key; empty = derived from Category and Name
+	Body     string       // searched line by line when the name doesn't match (a note's content)
+	Detail   string       // shown after the label, e.g. the Body line that matched
+	OnSave   func() error // <c-s> in a palette list; nil = nothing to save
 }
 
 // PaletteState holds the runtime state of the command palette.
 type PaletteState struct {
 	Commands      []PaletteCommand
 	Filtered      []PaletteCommand
+	Matches       [][]int // matched rune positions in each Filtered label
 	SelectedIndex int
 	FilterText    string
+	Title         string // set for a fixed list such as the search history; "" = commands and Quick Open
+	Footer        string
 }
@@ -11,5 +11,6 @@ type InputPopupConfig struct {
 	OnCtrlX    func() error                                 // Ctrl-X handler (nil = no Ctrl-X action) — used for "remove/clear" actions
 	OnCancel   func()                                       // called when Esc is pressed while Locked (e.g. cancel async work)
 	Locked     bool                                         // when true, input is disabled (spinner/waiting state)
+	SpinnerKey string                                       // async task key; while pending, its spinner frame is appended to Title
 	DeferClose bool                                         // when true, OnAccept is responsible for closing the popup
 }
@@ -0,0 +1,118 @@
+package gui
+
+import (
+	"slices"
+	"testing"
+)
+
+func hasCall(calls [][]string, want ...string) bool {
+	for _, c := range calls {
+		if slices.Equal(c, want) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestUndoRedo_GlobalTag(t *testing.T) {
+	mock := defaultMock()
+	tg := newTestGui(t, mock)
+	defer tg.Close()
+
+	undo := tg.gui.helpers.Undo()
+	undo.RecordAddTag("abc", "#urgent")
+
+	mock.Calls = nil
+	if err := undo.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	if !hasCall(mock.Calls, "note", "set", "abc", "--remove-tag", "#urgent", "-f") {
+		t.Errorf("undo should remove the tag; calls=%v", mock.Calls)
+	}
+	if undo.Position() != 0 {
+		t.Errorf("Position after undo = %d, want 0", undo.Position())
+	}
+
+	mock.Calls = nil
+	if err := undo.Redo(); err != nil {
+		t.Fatal(err)
+	}
+	if !hasCall(mock.Calls, "note", "set", "abc", "--add-tag", "#urgent", "-f") {
+		t.Errorf("redo should add the tag back; calls=%v", mock.Calls)
+	}
+	if undo.Position() != 1 {
+		t.Errorf("Position after redo = %d, want 1", undo.Position())
+	}
+}
+
+func TestUndo_SetParentRestoresPrevious(t *testing.T) {
+	mock := defaultMock()
+	tg := newTestGui(t, mock)
+	defer tg.Close()
+
+	undo := tg.gui.helpers.Undo()
+	undo.RecordSetParent("abc", "old-parent", "new-parent")
+	undo.RecordSetParent("def", "", "new-parent")
+
+	mock.Calls = nil
+	_ = undo.Undo()
+	_ = undo.Undo()
+	if !hasCall(mock.Calls, "note", "set", "def", "--no-parent", "-f") {
+		t.Errorf("undoing a first parent should clear it; calls=%v", mock.Calls)
+	}
+	if !hasCall(mock.Calls, "note", "set", "abc", "--parent", "old-parent", "-f") {
+		t.Errorf("undo should restore the previous parent; calls=%v", mock.Calls)
+	}
+}
+
+func TestUndo_EmptyJournalIsNoop(t *testing.T) {
+	mock := defaultMock()
+	tg := newTestGui(t, mock)
+	defer tg.Close()
+
+	mock.Calls = nil
+	_ = tg.gui.helpers.Undo().Undo()
+	_ = tg.gui.helpers.Undo().Redo()
+	if len(mock.Calls) != 0 {
+		t.Errorf("empty journal should not run commands; calls=%v", mock.Calls)
+	}
+}
+
+func TestUndoHistory_JumpsToChosenEntry(t *testing.T) {
+	tg := newTestGui(t, defaultMock())
+	defer tg.Close()
+
+	var undone, redone []string
+	undo := tg.gui.helpers.Undo()
+	for _, label := range []string{"one", "two", "three"} {
+		undo.Record(label,
+			func() error { undone = append(undone, label); return nil },
+			func() error { redone = append(redone, label); return nil })
+	}
+
+	if err := undo.ShowHistory(); err != nil {
+		t.Fatal(err)
+	}
+	items := tg.gui.state.Dialog.MenuItems
+	if len(items) != 3 {
+		t.Fatalf("history items = %d, want 3", len(items))
+	}
+	// Newest first; picking "one" undoes three and two.
+	if err := items[2].OnRun(); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(undone, []string{"three", "two"}) {
+		t.Errorf("undone = %v, want [three two]", undone)
+	}
+	if undo.Position() != 1 {
+		t.Errorf("Position = %d, want 1", undo.Position())
+	}
+
+	// Picking "three" again redoes forward.
+	if err := items[0].OnRun(); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(redone, []string{"two", "three"}) {
+		t.Errorf("redone = %v, want [two three]", redone)
+	}
+}
@@ -0,0 +1,64 @@
+package gui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/donnellyk/lazyruin/pkg/config"
+	"github.com/donnellyk/lazyruin/pkg/gui/types"
+)
+
+// SwitchVault makes the vault at path the active one without restarting.
+// The ruin command, the helpers' per-vault state and the watcher are
+// rebuilt, the panels reload onto the new vault's saved session (or
+// today's notes), and any migrations pending for the new vault are
+// offered. Nothing changes if path isn't an initialized ruin vault.
+func (gui *Gui) SwitchVault(path string) error {
+	path = config.ExpandPath(path)
+	if path == gui.ruinCmd.VaultPath() {
+		return nil
+	}
+	cmd := gui.ruinCmd.ForVault(path)
+	if !cmd.IsInitialized() {
+		return fmt.Errorf("%s is not a ruin vault — run `ruin init %s` first", path, path)
+	}
+
+	gui.saveSession()
+	watching := gui.stopWatch != nil
+	gui.stopWatcher()
+	serving := gui.remoteServer != nil
+	gui.stopRemote()
+
+	gui.ruinCmd = cmd
+	gui.controllerCommon.SetRuinCmd(cmd)
+	gui.helpers.Vault().Load(cmd)
+	gui.VaultSource = "vault switcher"
+	gui.migrations = nil
+
+	if watching {
+		gui.startWatcher()
+	}
+	if serving {
+		gui.startRemote()
+	}
+
+	// Start over from the same stack as a fresh launch; whatever was
+	// focused belonged to the old vault. The new vault's saved session,
+	// if any, then picks up where it was left.
+	gui.contextMgr.SetStack([]types.ContextKey{"notes"})
+	gui.activateContext("notes")
+	sess := gui.helpers.Session().Load()
+	gui.helpers.Session().RestoreTabs(sess)
+	gui.RefreshAll()
+	if !gui.restoreSessionView(sess) {
+		gui.helpers.DatePreview().LoadDatePreview(time.Now().Format("2006-01-02"))
+	}
+
+	if gui.OnVaultSwitch != nil {
+		gui.OnVaultSwitch(cmd)
+	}
+	if gui.migrations == nil || !gui.migrations.Start() {
+		gui.ShowStatus("Switched to " + gui.helpers.Vault().ActiveName())
+	}
+	return nil
+}
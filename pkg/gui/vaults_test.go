@@ -0,0 +1,124 @@
+package gui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/donnellyk/lazyruin/pkg/commands"
+	"github.com/donnellyk/lazyruin/pkg/config"
+)
+
+// newVaultDir creates an initialized (or, with init false, bare) vault
+// directory.
+func newVaultDir(t *testing.T, init bool) string {
+	t.Helper()
+	dir := t.TempDir()
+	if init {
+		if err := os.Mkdir(filepath.Join(dir, ".ruin"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestSwitchVault_RebindsCommandAndDropsVaultState(t *testing.T) {
+	mock := defaultMock()
+	tg := newTestGui(t, mock)
+	defer tg.Close()
+
+	other := newVaultDir(t, true)
+	tg.gui.config.Vaults = []config.VaultConfig{
+		{Name: "work", Path: mock.VaultPath()},
+		{Name: "personal", Path: other},
+	}
+
+	h := tg.gui.helpers
+	h.Undo().Record("Tag note", func() error { return nil }, func() error { return nil })
+	h.TitleCache().Put("uuid-x", "Old Vault Note")
+	tg.gui.contexts.Notes.Selection.Toggle("uuid-x")
+	tg.gui.pushContextByKey("tags")
+
+	var switched string
+	tg.gui.OnVaultSwitch = func(cmd *commands.RuinCommand) { switched = cmd.VaultPath() }
+
+	if err := tg.gui.SwitchVault(other); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := tg.gui.ruinCmd.VaultPath(); got != other {
+		t.Errorf("gui vault = %q, want %q", got, other)
+	}
+	if tg.gui.controllerCommon.RuinCmd() != tg.gui.ruinCmd {
+		t.Error("controllers should see the new ruin command")
+	}
+	if got := h.Vault().ActiveName(); got != "personal" {
+		t.Errorf("ActiveName = %q, want personal", got)
+	}
+	if switched != other {
+		t.Errorf("OnVaultSwitch got %q, want %q", switched, other)
+	}
+	if n := len(h.Undo().Entries()); n != 0 {
+		t.Errorf("undo journal should be cleared, has %d entries", n)
+	}
+	if _, ok := h.TitleCache().Get("uuid-x"); ok {
+		t.Error("title cache should be cleared")
+	}
+	if tg.gui.contexts.Notes.Selection.Active() {
+		t.Error("selection should be cleared")
+	}
+	if tg.gui.contextMgr.Contains("tags") {
+		t.Error("context stack should start over after a switch")
+	}
+
+	tg.gui.UpdateStatusBar()
+	if status := tg.gui.views.Status.Buffer(); !strings.Contains(status, "personal") {
+		t.Errorf("status bar should name the active vault, got %q", status)
+	}
+}
+
+func TestSwitchVault_RejectsUninitializedVault(t *testing.T) {
+	mock := defaultMock()
+	tg := newTestGui(t, mock)
+	defer tg.Close()
+
+	bare := newVaultDir(t, false)
+	if err := tg.gui.SwitchVault(bare); err == nil {
+		t.Fatal("switching to a directory without .ruin should fail")
+	}
+	if got := tg.gui.ruinCmd.VaultPath(); got != mock.VaultPath() {
+		t.Errorf("vault changed to %q after a failed switch", got)
+	}
+}
+
+func TestVaultSwitcher_ListsVaultsAndMarksActive(t *testing.T) {
+	mock := defaultMock()
+	tg := newTestGui(t, mock)
+	defer tg.Close()
+
+	other := newVaultDir(t, true)
+	tg.gui.config.Vaults = []config.VaultConfig{
+		{Name: "work", Path: mock.VaultPath()},
+		{Name: "personal", Path: other},
+	}
+
+	if err := tg.gui.helpers.Vault().OpenSwitcher(); err != nil {
+		t.Fatal(err)
+	}
+	d := tg.gui.state.Dialog
+	if d == nil || d.Type != "menu" || len(d.MenuItems) != 2 {
+		t.Fatalf("dialog = %+v, want a two-item menu", d)
+	}
+	if d.MenuItems[0].Label != "> work" || d.MenuItems[1].Label != "personal" {
+		t.Errorf("labels = %q, %q", d.MenuItems[0].Label, d.MenuItems[1].Label)
+	}
+
+	tg.gui.closeDialog()
+	if err := d.MenuItems[1].OnRun(); err != nil {
+		t.Fatal(err)
+	}
+	if got := tg.gui.ruinCmd.VaultPath(); got != other {
+		t.Errorf("vault = %q after choosing personal, want %q", got, other)
+	}
+}
@@ -28,6 +28,12 @@ const (
 	ContribNotesView      = "contribNotes"
 	PickDialogView        = "pickDialog"
 	ScratchpadBrowserView = "scratchpadBrowser"
+	TrashBrowserView      = "trashBrowser"
+	ParentTreeView        = "parentTree"
+	NoteHistoryView       = "noteHistory"
+	MergeConflictView     = "mergeConflict"
+	TodosView             = "todos"
+	PreviewFindView       = "previewFind"
 )
 
 // Views holds references to all views.
@@ -0,0 +1,54 @@
+package gui
+
+import (
+	"testing"
+
+	"github.com/donnellyk/lazyruin/pkg/models"
+)
+
+func composeCalls(calls [][]string) int {
+	n := 0
+	for _, c := range calls {
+		if len(c) > 0 && c[0] == "compose" {
+			n++
+		}
+	}
+	return n
+}
+
+func TestShowsAnyPath_ResolvesRelativeNotePaths(t *testing.T) {
+	mock := defaultMock()
+	tg := newTestGui(t, mock)
+	defer tg.Close()
+
+	tg.gui.helpers.Preview().ShowCardList("A", []models.Note{{UUID: "a", Title: "A", Path: "a.md"}})
+
+	preview := tg.gui.helpers.Preview()
+	if !preview.ShowsAnyPath([]string{mock.VaultPath() + "/a.md"}) {
+		t.Error("relative card path should match its absolute vault path")
+	}
+	if preview.ShowsAnyPath([]string{mock.VaultPath() + "/b.md"}) {
+		t.Error("unrelated path should not match")
+	}
+}
+
+func TestRefreshChangedFiles_ReloadsPreviewOnlyWhenShown(t *testing.T) {
+	mock := defaultMock().WithCompose([]byte(
+		`{"uuid":"1","title":"Note One","path":"one.md","composed_content":"body\n","source_map":[]}`))
+	tg := newTestGui(t, mock)
+	defer tg.Close()
+
+	tg.gui.helpers.Preview().ShowCardList("One", []models.Note{{UUID: "1", Title: "Note One", Path: "one.md"}})
+
+	mock.Calls = nil
+	tg.gui.refreshChangedFiles([]string{mock.VaultPath() + "/unrelated.md"})
+	if n := composeCalls(mock.Calls); n != 0 {
+		t.Errorf("preview recomposed %d times for an unrelated file, want 0", n)
+	}
+
+	mock.Calls = nil
+	tg.gui.refreshChangedFiles([]string{mock.VaultPath() + "/one.md"})
+	if n := composeCalls(mock.Calls); n == 0 {
+		t.Error("preview should reload when a shown note's file changes")
+	}
+}
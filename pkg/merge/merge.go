@@ -0,0 +1,212 @@
+// Package merge does line-based three-way merges of note bodies: the
+// version an edit started from (base), the edited text (ours) and the
+// version now on disk (theirs). Regions changed on only one side merge
+// cleanly; regions changed differently on both sides are conflicts.
+package merge
+
+import "strings"
+
+// Side picks how a conflict is resolved.
+type Side int
+
+const (
+	Unresolved Side = iota
+	Ours
+	Theirs
+	Both // ours, then theirs
+)
+
+// Chunk is a run of merged lines, or a conflict between two sides.
+type Chunk struct {
+	Lines    []string // merged lines when not a conflict
+	Conflict bool
+	Ours     []string
+	Theirs   []string
+}
+
+// Resolve returns the chunk's lines with a conflict settled by s. An
+// unresolved conflict is written out between conflict markers.
+func (c Chunk) Resolve(s Side) []string {
+	if !c.Conflict {
+		return c.Lines
+	}
+	switch s {
+	case Ours:
+		return c.Ours
+	case Theirs:
+		return c.Theirs
+	case Both:
+		return append(append([]string(nil), c.Ours...), c.Theirs...)
+	}
+	lines := []string{"<<<<<<< edited"}
+	lines = append(lines, c.Ours...)
+	lines = append(lines, "=======")
+	lines = append(lines, c.Theirs...)
+	return append(lines, ">>>>>>> on disk")
+}
+
+// Result is a merge split into clean and conflicting chunks.
+type Result struct {
+	Chunks []Chunk
+}
+
+// Conflicts returns the number of conflicting chunks.
+func (r Result) Conflicts() int {
+	n := 0
+	for _, c := range r.Chunks {
+		if c.Conflict {
+			n++
+		}
+	}
+	return n
+}
+
+// Text joins the merge back into a body. sides settles the conflicts in
+// order; conflicts past the end of sides are left unresolved.
+func (r Result) Text(sides []Side) string {
+	var lines []string
+	n := 0
+	for _, c := range r.Chunks {
+		s := Unresolved
+		if c.Conflict {
+			if n < len(sides) {
+				s = sides[n]
+			}
+			n++
+		}
+		lines = append(lines, c.Resolve(s)...)
+	}
+	return joinLines(lines)
+}
+
+// Merge three-way merges ours and theirs against their common base.
+func Merge(base, ours, theirs string) Result {
+	b, o, t := splitLines(base), splitLines(ours), splitLines(theirs)
+	mo, mt := matches(b, o), matches(b, t)
+
+	var r Result
+	emit := func(lines []string) {
+		if len(lines) == 0 {
+			return
+		}
+		if n := len(r.Chunks); n > 0 && !r.Chunks[n-1].Conflict {
+			r.Chunks[n-1].Lines = append(r.Chunks[n-1].Lines, lines...)
+			return
+		}
+		r.Chunks = append(r.Chunks, Chunk{Lines: append([]string(nil), lines...)})
+	}
+
+	ib, io, it := 0, 0, 0
+	for {
+		// Lines unchanged on both sides.
+		for ib < len(b) && mo[ib] == io && mt[ib] == it {
+			emit(b[ib : ib+1])
+			ib, io, it = ib+1, io+1, it+1
+		}
+		if ib == len(b) && io == len(o) && it == len(t) {
+			return r
+		}
+
+		// The next base line both sides kept ends the changed region.
+		j := ib
+		for j < len(b) && (mo[j] < 0 || mt[j] < 0) {
+			j++
+		}
+		oEnd, tEnd := len(o), len(t)
+		if j < len(b) {
+			oEnd, tEnd = mo[j], mt[j]
+		}
+
+		bc, oc, tc := b[ib:j], o[io:oEnd], t[it:tEnd]
+		switch {
+		case equal(oc, bc):
+			emit(tc)
+		case equal(tc, bc), equal(oc, tc):
+			emit(oc)
+		default:
+			r.Chunks = append(r.Chunks, Chunk{Conflict: true, Ours: oc, Theirs: tc})
+		}
+		ib, io, it = j, oEnd, tEnd
+	}
+}
+
+// maxCells bounds the LCS table. Past it, the differing middle of the two
+// texts is treated as one changed region.
+const maxCells = 4_000_000
+
+// matches pairs lines of a with lines of b along a longest common
+// subsequence: m[i] is the index in b matched to a[i], or -1.
+func matches(a, b []string) []int {
+	m := make([]int, len(a))
+	for i := range m {
+		m[i] = -1
+	}
+	// Common prefix and suffix match trivially.
+	pre := 0
+	for pre < len(a) && pre < len(b) && a[pre] == b[pre] {
+		m[pre] = pre
+		pre++
+	}
+	suf := 0
+	for suf < len(a)-pre && suf < len(b)-pre && a[len(a)-1-suf] == b[len(b)-1-suf] {
+		m[len(a)-1-suf] = len(b) - 1 - suf
+		suf++
+	}
+	a2, b2 := a[pre:len(a)-suf], b[pre:len(b)-suf]
+	if len(a2) == 0 || len(b2) == 0 || len(a2)*len(b2) > maxCells {
+		return m
+	}
+
+	// lcs[i][j] is the LCS length of a2[i:] and b2[j:].
+	w := len(b2) + 1
+	lcs := make([]int, (len(a2)+1)*w)
+	for i := len(a2) - 1; i >= 0; i-- {
+		for j := len(b2) - 1; j >= 0; j-- {
+			if a2[i] == b2[j] {
+				lcs[i*w+j] = lcs[(i+1)*w+j+1] + 1
+			} else {
+				lcs[i*w+j] = max(lcs[(i+1)*w+j], lcs[i*w+j+1])
+			}
+		}
+	}
+	for i, j := 0, 0; i < len(a2) && j < len(b2); {
+		switch {
+		case a2[i] == b2[j]:
+			m[pre+i] = pre + j
+			i++
+			j++
+		case lcs[(i+1)*w+j] >= lcs[i*w+j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return m
+}
+
+func splitLines(s string) []string {
+	s = strings.TrimSuffix(s, "\n")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+func joinLines(lines []string) string {
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,60 @@
+package merge
+
+import "testing"
+
+func TestMergeClean(t *testing.T) {
+	tests := []struct {
+		name, base, ours, theirs, want string
+	}{
+		{"unchanged", "a\nb\n", "a\nb\n", "a\nb\n", "a\nb\n"},
+		{"ours only", "a\nb\nc\n", "a\nB\nc\n", "a\nb\nc\n", "a\nB\nc\n"},
+		{"theirs only", "a\nb\nc\n", "a\nb\nc\n", "a\nb\nC\n", "a\nb\nC\n"},
+		{"separate regions", "a\nb\nc\nd\n", "A\nb\nc\nd\n", "a\nb\nc\nD\n", "A\nb\nc\nD\n"},
+		{"same change", "a\nb\n", "a\nX\n", "a\nX\n", "a\nX\n"},
+		{"insert and append", "a\nb\n", "a\nnew\nb\n", "a\nb\nend\n", "a\nnew\nb\nend\n"},
+		{"delete and edit", "a\nb\nc\nd\n", "a\nc\nd\n", "a\nb\nc\nD\n", "a\nc\nD\n"},
+		{"from empty", "", "mine\n", "", "mine\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Merge(tt.base, tt.ours, tt.theirs)
+			if r.Conflicts() != 0 {
+				t.Fatalf("conflicts = %d, want 0 (%+v)", r.Conflicts(), r.Chunks)
+			}
+			if got := r.Text(nil); got != tt.want {
+				t.Errorf("Text = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeConflict(t *testing.T) {
+	r := Merge("a\nb\nc\n", "a\nmine\nc\n", "a\ntheirs\nc\n")
+	if r.Conflicts() != 1 {
+		t.Fatalf("conflicts = %d, want 1 (%+v)", r.Conflicts(), r.Chunks)
+	}
+
+	for _, tt := range []struct {
+		side Side
+		want string
+	}{
+		{Ours, "a\nmine\nc\n"},
+		{Theirs, "a\ntheirs\nc\n"},
+		{Both, "a\nmine\ntheirs\nc\n"},
+		{Unresolved, "a\n<<<<<<< edited\nmine\n=======\ntheirs\n>>>>>>> on disk\nc\n"},
+	} {
+		if got := r.Text([]Side{tt.side}); got != tt.want {
+			t.Errorf("Text(%v) = %q, want %q", tt.side, got, tt.want)
+		}
+	}
+}
+
+func TestMergeBothAppend(t *testing.T) {
+	r := Merge("a\n", "a\nmine\n", "a\ntheirs\n")
+	if r.Conflicts() != 1 {
+		t.Fatalf("conflicts = %d, want 1", r.Conflicts())
+	}
+	if got := r.Text([]Side{Both}); got != "a\nmine\ntheirs\n" {
+		t.Errorf("Text = %q", got)
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !unix
+
+package remote
+
+import "os"
+
+// fileOwner reports no owner where the platform has no uids.
+func fileOwner(os.FileInfo) (int, bool) {
+	return 0, false
+}
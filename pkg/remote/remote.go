@@ -0,0 +1,234 @@
+// Package remote lets other programs drive a running lazyruin. Each
+// instance listens on a Unix domain socket named after its vault; a client
+// connects, writes one JSON request and reads one JSON reply.
+package remote
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/donnellyk/lazyruin/pkg/configpath"
+)
+
+// Commands a running instance accepts.
+const (
+	CommandOpen    = "open"    // open <path|title|parent bookmark>
+	CommandSearch  = "search"  // search <query>
+	CommandCapture = "capture" // capture [text]; no text opens the capture popup
+	CommandDate    = "date"    // date <yyyy-mm-dd>
+	CommandRefresh = "refresh" // refresh
+)
+
+var (
+	// ErrNotRunning is returned by Send when no instance is listening on
+	// the socket.
+	ErrNotRunning = errors.New("no running lazyruin for this vault")
+	// ErrAlreadyRunning is returned by Listen when another instance is
+	// already listening on the socket.
+	ErrAlreadyRunning = errors.New("another lazyruin is already listening for this vault")
+)
+
+// timeout bounds how long either side waits on the other.
+const timeout = 10 * time.Second
+
+// Request is one command sent to a running instance.
+type Request struct {
+	Command string `json:"command"`
+	Arg     string `json:"arg,omitempty"`
+}
+
+type response struct {
+	Error string `json:"error,omitempty"`
+}
+
+// ParseArgs builds a request from command-line words: the command, then
+// its argument. Remaining words are joined with spaces, so the argument
+// need not be quoted.
+func ParseArgs(args []string) (Request, error) {
+	if len(args) == 0 {
+		return Request{}, errors.New("missing remote command (open, search, capture, date or refresh)")
+	}
+	req := Request{Command: args[0], Arg: strings.Join(args[1:], " ")}
+	return req, req.Validate()
+}
+
+// Validate reports an unknown command or a missing or malformed argument.
+func (r Request) Validate() error {
+	switch r.Command {
+	case CommandOpen, CommandSearch:
+		if strings.TrimSpace(r.Arg) == "" {
+			return fmt.Errorf("%s needs an argument", r.Command)
+		}
+	case CommandDate:
+		if _, err := time.Parse("2006-01-02", r.Arg); err != nil {
+			return fmt.Errorf("date needs a yyyy-mm-dd date, got %q", r.Arg)
+		}
+	case CommandCapture, CommandRefresh:
+	default:
+		return fmt.Errorf("unknown remote command %q", r.Command)
+	}
+	return nil
+}
+
+// SocketPath returns the socket for a vault, under $XDG_RUNTIME_DIR when
+// set and a per-user directory in the system temp dir otherwise.
+func SocketPath(vaultPath string) string {
+	if abs, err := filepath.Abs(vaultPath); err == nil {
+		vaultPath = abs
+	}
+	dir := filepath.Join(os.TempDir(), fmt.Sprintf("lazyruin-%d", os.Getuid()))
+	if runtime := os.Getenv("XDG_RUNTIME_DIR"); runtime != "" {
+		dir = filepath.Join(runtime, "lazyruin")
+	}
+	return filepath.Join(dir, configpath.VaultFileName(vaultPath, "sock"))
+}
+
+// ErrClosing is reported to a client whose request was still waiting when
+// the server closed.
+var ErrClosing = errors.New("lazyruin is shutting down")
+
+// Handler runs a validated request and returns the error to report back
+// to the client. closing is closed when the server starts shutting down;
+// a handler that waits on something else, such as the UI's main loop,
+// must stop waiting then, since Close waits for handlers to return.
+type Handler func(req Request, closing <-chan struct{}) error
+
+// Server accepts requests on a socket until closed.
+type Server struct {
+	ln      net.Listener
+	path    string
+	wg      sync.WaitGroup
+	closing chan struct{}
+	once    sync.Once
+}
+
+// checkSocketDir refuses a socket directory another user could have
+// planted: without $XDG_RUNTIME_DIR it lives in the shared temp dir, and
+// MkdirAll keeps whatever directory is already there. The directory must
+// be a real directory, not a symlink, owned by this user with mode 0700.
+func checkSocketDir(dir string) error {
+	info, err := os.Lstat(dir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("socket directory %s is not a directory", dir)
+	}
+	if uid, ok := fileOwner(info); ok && uid != os.Getuid() {
+		return fmt.Errorf("socket directory %s is owned by another user", dir)
+	}
+	if perm := info.Mode().Perm(); perm != 0o700 {
+		return fmt.Errorf("socket directory %s has mode %04o, want 0700", dir, perm)
+	}
+	return nil
+}
+
+// Listen starts serving requests on path. A socket left behind by an
+// instance that exited without cleaning up is replaced. Listen refuses a
+// socket directory that isn't private to this user.
+func Listen(path string, handle Handler) (*Server, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	if err := checkSocketDir(filepath.Dir(path)); err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(path); err == nil {
+		if conn, err := net.DialTimeout("unix", path, time.Second); err == nil {
+			conn.Close()
+			return nil, ErrAlreadyRunning
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+	}
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	s := &Server{ln: ln, path: path, closing: make(chan struct{})}
+	s.wg.Add(1)
+	go s.serve(handle)
+	return s, nil
+}
+
+// Path returns the socket the server listens on.
+func (s *Server) Path() string { return s.path }
+
+// Close stops accepting requests, tells in-flight handlers to give up
+// waiting, waits for them to reply and removes the socket.
+func (s *Server) Close() error {
+	s.once.Do(func() { close(s.closing) })
+	err := s.ln.Close()
+	s.wg.Wait()
+	return err
+}
+
+func (s *Server) serve(handle Handler) {
+	defer s.wg.Done()
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			serveConn(conn, handle, s.closing)
+		}()
+	}
+}
+
+func serveConn(conn net.Conn, handle Handler, closing <-chan struct{}) {
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(timeout))
+
+	var req Request
+	var resp response
+	if err := json.NewDecoder(conn).Decode(&req); err != nil {
+		resp.Error = "malformed request: " + err.Error()
+	} else if err := req.Validate(); err != nil {
+		resp.Error = err.Error()
+	} else if err := handle(req, closing); err != nil {
+		resp.Error = err.Error()
+	}
+	_ = json.NewEncoder(conn).Encode(resp)
+}
+
+// Send delivers req to the instance listening on path and returns the
+// error it reported. It returns ErrNotRunning when nothing is listening,
+// and refuses to send through a socket directory that isn't private to
+// this user.
+func Send(path string, req Request) error {
+	if err := checkSocketDir(filepath.Dir(path)); errors.Is(err, fs.ErrNotExist) {
+		return ErrNotRunning
+	} else if err != nil {
+		return err
+	}
+	conn, err := net.DialTimeout("unix", path, time.Second)
+	if err != nil {
+		return ErrNotRunning
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(timeout))
+
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return err
+	}
+	var resp response
+	if err := json.NewDecoder(conn).Decode(&resp); err != nil {
+		return fmt.Errorf("reading reply: %w", err)
+	}
+	if resp.Error != "" {
+		return errors.New(resp.Error)
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package remote
+
+import (
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// socketPath returns a short socket path; t.TempDir() can exceed the
+// sun_path limit on macOS.
+func socketPath(t *testing.T) string {
+	t.Helper()
+	dir, err := os.MkdirTemp("", "lr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return filepath.Join(dir, "s.sock")
+}
+
+func TestParseArgs(t *testing.T) {
+	req, err := ParseArgs([]string{"open", "Meeting", "notes"})
+	if err != nil || req != (Request{Command: CommandOpen, Arg: "Meeting notes"}) {
+		t.Errorf("ParseArgs = %+v, %v", req, err)
+	}
+	if _, err := ParseArgs([]string{"capture"}); err != nil {
+		t.Errorf("capture without text should be valid: %v", err)
+	}
+	for _, args := range [][]string{nil, {"open"}, {"date", "tomorrow"}, {"explode"}} {
+		if _, err := ParseArgs(args); err == nil {
+			t.Errorf("ParseArgs(%q) should fail", args)
+		}
+	}
+}
+
+func TestSendRoundTrip(t *testing.T) {
+	path := socketPath(t)
+	var got []Request
+	s, err := Listen(path, func(req Request, _ <-chan struct{}) error {
+		got = append(got, req)
+		if req.Command == CommandOpen {
+			return errors.New("no note named " + req.Arg)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Send(path, Request{Command: CommandDate, Arg: "2026-01-02"}); err != nil {
+		t.Errorf("Send(date) = %v", err)
+	}
+	if err := Send(path, Request{Command: CommandOpen, Arg: "Nope"}); err == nil || err.Error() != "no note named Nope" {
+		t.Errorf("Send(open) = %v, want the handler's error", err)
+	}
+	if err := Send(path, Request{Command: "explode"}); err == nil || !strings.Contains(err.Error(), "unknown remote command") {
+		t.Errorf("Send(explode) = %v, want a validation error", err)
+	}
+	if len(got) != 2 {
+		t.Errorf("handler saw %+v, want only the two valid requests", got)
+	}
+
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("socket should be removed on Close, stat = %v", err)
+	}
+	if err := Send(path, Request{Command: CommandRefresh}); !errors.Is(err, ErrNotRunning) {
+		t.Errorf("Send after Close = %v, want ErrNotRunning", err)
+	}
+}
+
+func TestListen_ReplacesStaleSocketButNotLiveOne(t *testing.T) {
+	path := socketPath(t)
+
+	// A socket file nobody listens on, as left by a crashed instance.
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ln.(*net.UnixListener).SetUnlinkOnClose(false)
+	ln.Close()
+
+	s, err := Listen(path, func(Request, <-chan struct{}) error { return nil })
+	if err != nil {
+		t.Fatalf("Listen over a stale socket: %v", err)
+	}
+	defer s.Close()
+
+	if _, err := Listen(path, func(Request, <-chan struct{}) error { return nil }); !errors.Is(err, ErrAlreadyRunning) {
+		t.Errorf("second Listen = %v, want ErrAlreadyRunning", err)
+	}
+}
+
+func TestClose_StopsHandlersWaiting(t *testing.T) {
+	path := socketPath(t)
+	started := make(chan struct{})
+	s, err := Listen(path, func(_ Request, closing <-chan struct{}) error {
+		close(started)
+		// Stands in for a main loop that is blocked on Close itself.
+		<-closing
+		return ErrClosing
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sent := make(chan error, 1)
+	go func() { sent <- Send(path, Request{Command: CommandRefresh}) }()
+	<-started
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-sent; err == nil || err.Error() != ErrClosing.Error() {
+		t.Errorf("Send = %v, want %v", err, ErrClosing)
+	}
+}
+
+func TestSocketPath_PerVault(t *testing.T) {
+	t.Setenv("XDG_RUNTIME_DIR", "/run/user/1000")
+	a, b := SocketPath("/notes/work"), SocketPath("/notes/home")
+	if a == b {
+		t.Error("different vaults should get different sockets")
+	}
+	if !strings.HasPrefix(a, "/run/user/1000/lazyruin/") || !strings.HasSuffix(a, ".sock") {
+		t.Errorf("SocketPath = %q", a)
+	}
+	if SocketPath("/notes/work/") != a {
+		t.Error("equivalent vault paths should share a socket")
+	}
+}
+
+func TestListenAndSend_RefuseUnsafeSocketDir(t *testing.T) {
+	handle := func(Request, <-chan struct{}) error { return nil }
+
+	open := filepath.Dir(socketPath(t))
+	if err := os.Chmod(open, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Listen(filepath.Join(open, "s.sock"), handle); err == nil {
+		t.Error("Listen in a world-readable directory should fail")
+	}
+	if err := Send(filepath.Join(open, "s.sock"), Request{Command: CommandRefresh}); err == nil || errors.Is(err, ErrNotRunning) {
+		t.Errorf("Send through a world-readable directory = %v, want a refusal", err)
+	}
+
+	private := filepath.Dir(socketPath(t))
+	link := private + "-link"
+	if err := os.Symlink(private, link); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Remove(link) })
+	if _, err := Listen(filepath.Join(link, "s.sock"), handle); err == nil {
+		t.Error("Listen through a symlinked directory should fail")
+	}
+
+	missing := filepath.Join(private, "missing", "s.sock")
+	if err := Send(missing, Request{Command: CommandRefresh}); !errors.Is(err, ErrNotRunning) {
+		t.Errorf("Send with no socket directory = %v, want ErrNotRunning", err)
+	}
+}
@@ -0,0 +1,105 @@
+// Package searchhistory remembers the searches and picks run in each
+// vault, most recent first, so they can be recalled and re-run.
+package searchhistory
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/donnellyk/lazyruin/pkg/configpath"
+)
+
+// Entry kinds: which popup the query was run from.
+const (
+	KindSearch = "search"
+	KindPick   = "pick"
+)
+
+// maxEntries bounds the file; the oldest entries are dropped beyond it.
+const maxEntries = 200
+
+// Entry is one executed query.
+type Entry struct {
+	Kind  string    `json:"kind"`
+	Query string    `json:"query"`
+	Time  time.Time `json:"time"`
+}
+
+// Store reads and writes one vault's history file.
+type Store struct {
+	path    string
+	entries []Entry
+}
+
+func NewStoreForVault(vaultPath string) *Store {
+	return NewStoreWithPath(PathForVault(vaultPath))
+}
+
+func NewStoreWithPath(path string) *Store {
+	return &Store{path: path}
+}
+
+// PathForVault returns the history file path for a given vault, stored
+// under the lazyruin config directory keyed by a hash of the vault path.
+func PathForVault(vaultPath string) string {
+	return filepath.Join(configpath.Dir(), "history", configpath.VaultFileName(vaultPath, "json"))
+}
+
+// Load reads the saved entries. A missing file leaves the store empty.
+func (s *Store) Load() error {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	var entries []Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+	s.entries = entries
+	return nil
+}
+
+// Save writes the entries.
+func (s *Store) Save() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s.entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0o644)
+}
+
+// Add puts query at the top of the history. Running a query again moves
+// it up rather than repeating it.
+func (s *Store) Add(kind, query string, now time.Time) {
+	entries := []Entry{{Kind: kind, Query: query, Time: now}}
+	for _, e := range s.entries {
+		if e.Kind == kind && e.Query == query {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	if len(entries) > maxEntries {
+		entries = entries[:maxEntries]
+	}
+	s.entries = entries
+}
+
+// Entries returns the entries of kind, most recent first. An empty kind
+// returns all of them.
+func (s *Store) Entries(kind string) []Entry {
+	var out []Entry
+	for _, e := range s.entries {
+		if kind == "" || e.Kind == kind {
+			out = append(out, e)
+		}
+	}
+	return out
+}
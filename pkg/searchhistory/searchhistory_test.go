@@ -0,0 +1,59 @@
+package searchhistory
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAdd_MostRecentFirstWithoutDuplicates(t *testing.T) {
+	s := NewStoreWithPath(filepath.Join(t.TempDir(), "h.json"))
+	now := time.Now()
+	s.Add(KindSearch, "#work", now)
+	s.Add(KindPick, "#work", now)
+	s.Add(KindSearch, "meeting", now)
+	s.Add(KindSearch, "#work", now)
+
+	got := s.Entries(KindSearch)
+	if len(got) != 2 || got[0].Query != "#work" || got[1].Query != "meeting" {
+		t.Errorf("search entries = %+v, want #work then meeting", got)
+	}
+	if all := s.Entries(""); len(all) != 3 || all[2].Kind != KindPick {
+		t.Errorf("a pick of the same text is a separate entry, got %+v", all)
+	}
+}
+
+func TestSaveLoad_RoundTripAndCap(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dir", "h.json")
+	s := NewStoreWithPath(path)
+	now := time.Now()
+	for i := range maxEntries + 10 {
+		s.Add(KindSearch, string(rune('a'+i%26))+string(rune(i)), now)
+	}
+	s.Add(KindPick, "#latest", now)
+	if err := s.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := NewStoreWithPath(path)
+	if err := loaded.Load(); err != nil {
+		t.Fatal(err)
+	}
+	all := loaded.Entries("")
+	if len(all) != maxEntries {
+		t.Errorf("entries = %d, want capped at %d", len(all), maxEntries)
+	}
+	if all[0].Kind != KindPick || all[0].Query != "#latest" {
+		t.Errorf("first entry = %+v, want the latest pick", all[0])
+	}
+}
+
+func TestLoad_MissingFileIsEmpty(t *testing.T) {
+	s := NewStoreWithPath(filepath.Join(t.TempDir(), "missing.json"))
+	if err := s.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Entries("")) != 0 {
+		t.Error("a missing file should leave the store empty")
+	}
+}
@@ -0,0 +1,86 @@
+// Package session persists the per-vault UI session — focused panel, tab
+// choices, the active preview and recent navigation history — so the next
+// launch can pick up where the last one left off.
+package session
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/donnellyk/lazyruin/pkg/configpath"
+)
+
+// Session is the saved state of one vault's UI.
+type Session struct {
+	Focus         string `json:"focus,omitempty"`
+	NotesTab      string `json:"notes_tab,omitempty"`
+	NotesOuterTab string `json:"notes_outer_tab,omitempty"`
+	QueriesTab    string `json:"queries_tab,omitempty"`
+	TagsTab       string `json:"tags_tab,omitempty"`
+
+	// Preview is the view in the preview pane when the session was saved.
+	// It matches History[HistoryIndex] unless it was a hover.
+	Preview          *Entry  `json:"preview,omitempty"`
+	PreviewCommitted bool    `json:"preview_committed,omitempty"`
+	History          []Entry `json:"history,omitempty"`
+	HistoryIndex     int     `json:"history_index"`
+}
+
+// Entry is a serialized navigation history entry. Snapshot holds the
+// preview context's view parameters and view state; its shape is owned by
+// the context named in Context.
+type Entry struct {
+	Context  string          `json:"context"`
+	Title    string          `json:"title,omitempty"`
+	ID       string          `json:"id,omitempty"`
+	Time     time.Time       `json:"time"`
+	Snapshot json.RawMessage `json:"snapshot,omitempty"`
+}
+
+// Store reads and writes one vault's session file.
+type Store struct {
+	path string
+}
+
+func NewStoreForVault(vaultPath string) *Store {
+	return &Store{path: PathForVault(vaultPath)}
+}
+
+func NewStoreWithPath(path string) *Store {
+	return &Store{path: path}
+}
+
+// PathForVault returns the session file path for a given vault, stored under
+// the lazyruin config directory keyed by a hash of the vault path.
+func PathForVault(vaultPath string) string {
+	return filepath.Join(configpath.Dir(), "sessions", configpath.VaultFileName(vaultPath, "json"))
+}
+
+// Load reads the saved session. It returns nil, nil when none was saved.
+func (s *Store) Load() (*Session, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var sess Session
+	if err := json.Unmarshal(data, &sess); err != nil {
+		return nil, err
+	}
+	return &sess, nil
+}
+
+func (s *Store) Save(sess *Session) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(sess, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0o644)
+}
@@ -0,0 +1,65 @@
+package session
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadMissingReturnsNil(t *testing.T) {
+	s := NewStoreWithPath(filepath.Join(t.TempDir(), "session.json"))
+	sess, err := s.Load()
+	if err != nil || sess != nil {
+		t.Fatalf("Load = %+v, %v; want nil, nil", sess, err)
+	}
+}
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions", "vault.json")
+	s := NewStoreWithPath(path)
+	want := &Session{
+		Focus:    "tags",
+		NotesTab: "recent",
+		TagsTab:  "inline",
+		History: []Entry{
+			{Context: "cardList", Title: "Search: #work", Snapshot: json.RawMessage(`{"title":"Search: #work"}`)},
+			{Context: "datePreview", Title: "Today"},
+		},
+		HistoryIndex: 1,
+	}
+	if err := s.Save(want); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	got, err := NewStoreWithPath(path).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Focus != "tags" || got.NotesTab != "recent" || got.TagsTab != "inline" || got.HistoryIndex != 1 {
+		t.Errorf("fields not restored: %+v", got)
+	}
+	if len(got.History) != 2 || got.History[1].Context != "datePreview" {
+		t.Fatalf("history not restored: %+v", got.History)
+	}
+	var snap struct{ Title string }
+	if err := json.Unmarshal(got.History[0].Snapshot, &snap); err != nil || snap.Title != "Search: #work" {
+		t.Errorf("snapshot = %s, %v", got.History[0].Snapshot, err)
+	}
+}
+
+func TestLoadCorruptFileErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewStoreWithPath(path).Load(); err == nil {
+		t.Error("expected an error for a corrupt session file")
+	}
+}
+
+func TestPathForVaultDiffersPerVault(t *testing.T) {
+	if PathForVault("/a") == PathForVault("/b") {
+		t.Error("different vaults should get different session files")
+	}
+}
@@ -1,6 +1,7 @@
 package testutil
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -16,10 +17,11 @@ type MockExecutor struct {
 	queries       []models.Query
 	parents       []models.ParentBookmark
 	pickResults   []models.PickResult
-	compose       []byte // raw JSON for compose tree
-	linkJSON      []byte // raw JSON for link command responses
-	embedJSON     []byte // raw JSON envelope for `ruin embed eval`
-	versionOutput string // raw output for `ruin --version`
+	created       *models.Note // returned for `ruin log`
+	compose       []byte       // raw JSON for compose tree
+	linkJSON      []byte       // raw JSON for link command responses
+	embedJSON     []byte       // raw JSON envelope for `ruin embed eval`
+	versionOutput string       // raw output for `ruin --version`
 	err           error
 	Calls         [][]string // recorded argument lists from Execute calls
 }
@@ -31,6 +33,13 @@ func NewMockExecutor() *MockExecutor {
 	}
 }
 
+// WithVaultPath sets the vault path reported to the GUI, for tests that
+// need the vault to be a real directory.
+func (m *MockExecutor) WithVaultPath(path string) *MockExecutor {
+	m.vaultPath = path
+	return m
+}
+
 // WithNotes sets the notes to return for search commands.
 func (m *MockExecutor) WithNotes(notes ...models.Note) *MockExecutor {
 	m.notes = notes
@@ -92,10 +101,20 @@ func (m *MockExecutor) WithPickResults(results ...models.PickResult) *MockExecut
 	return m
 }
 
+// WithCreatedNote sets the note returned when `ruin log` creates one.
+func (m *MockExecutor) WithCreatedNote(note models.Note) *MockExecutor {
+	m.created = &note
+	return m
+}
+
 // Execute returns canned JSON responses based on the command.
-func (m *MockExecutor) Execute(args ...string) ([]byte, error) {
+func (m *MockExecutor) Execute(ctx context.Context, args ...string) ([]byte, error) {
 	m.Calls = append(m.Calls, args)
 
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	if m.err != nil {
 		return nil, m.err
 	}
@@ -184,6 +203,10 @@ func (m *MockExecutor) Execute(args ...string) ([]byte, error) {
 				return json.Marshal(m.parents)
 			case "delete":
 				return []byte("{}"), nil
+			case "tree":
+				if len(args) > 2 {
+					return m.handleTree(args[2])
+				}
 			}
 		}
 		return []byte("{}"), nil
@@ -201,6 +224,9 @@ func (m *MockExecutor) Execute(args ...string) ([]byte, error) {
 		return m.handleLink(args)
 
 	case "log":
+		if m.created != nil {
+			return json.Marshal(m.created)
+		}
 		return []byte("{}"), nil
 
 	case "pick":
@@ -208,7 +234,11 @@ func (m *MockExecutor) Execute(args ...string) ([]byte, error) {
 
 	case "note":
 		// Handles note delete / note set / note append — returns empty
-		// success. Tests assert on m.Calls for behavior.
+		// success. Tests assert on m.Calls for behavior. Parent changes
+		// are applied so `parent tree` reflects them.
+		if len(args) > 2 && args[1] == "set" {
+			m.applyParent(args[2], args[3:])
+		}
 		return []byte("{}"), nil
 
 	case "embed":
@@ -222,6 +252,51 @@ func (m *MockExecutor) Execute(args ...string) ([]byte, error) {
 	}
 }
 
+// applyParent records a `note set --parent` / `--no-parent` on the note.
+func (m *MockExecutor) applyParent(uuid string, args []string) {
+	for i := range m.notes {
+		if m.notes[i].UUID != uuid {
+			continue
+		}
+		for j, a := range args {
+			switch {
+			case a == "--parent" && j+1 < len(args):
+				m.notes[i].Parent = args[j+1]
+			case a == "--no-parent":
+				m.notes[i].Parent = ""
+			}
+		}
+	}
+}
+
+// handleTree builds `parent tree` output from the notes' Parent fields.
+func (m *MockExecutor) handleTree(ref string) ([]byte, error) {
+	type node struct {
+		UUID     string `json:"uuid"`
+		Title    string `json:"title"`
+		Children []node `json:"children,omitempty"`
+	}
+	var build func(n models.Note, depth int) node
+	build = func(n models.Note, depth int) node {
+		out := node{UUID: n.UUID, Title: n.Title}
+		if depth > len(m.notes) {
+			return out
+		}
+		for _, c := range m.notes {
+			if c.Parent == n.UUID {
+				out.Children = append(out.Children, build(c, depth+1))
+			}
+		}
+		return out
+	}
+	for _, n := range m.notes {
+		if n.UUID == ref {
+			return json.Marshal(build(n, 0))
+		}
+	}
+	return nil, fmt.Errorf("note not found: %s", ref)
+}
+
 func (m *MockExecutor) handleGet(args []string) ([]byte, error) {
 	for i, a := range args {
 		switch a {
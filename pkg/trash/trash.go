@@ -0,0 +1,160 @@
+// Package trash keeps copies of deleted notes so they can be restored.
+// Each vault gets its own directory under the lazyruin config dir holding
+// the trashed files (frontmatter included) and an index.json describing
+// where each one came from.
+package trash
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/donnellyk/lazyruin/pkg/configpath"
+)
+
+// Item is one trashed note.
+type Item struct {
+	ID      string    `json:"id"`
+	UUID    string    `json:"uuid"`
+	Title   string    `json:"title"`
+	Path    string    `json:"path"` // original location, relative to the vault when inside it
+	Deleted time.Time `json:"deleted"`
+}
+
+// Store is the trash for a single vault.
+type Store struct {
+	dir   string
+	items []Item
+}
+
+func NewStoreForVault(vaultPath string) *Store {
+	return &Store{dir: DirForVault(vaultPath)}
+}
+
+func NewStoreWithDir(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// DirForVault returns the trash directory for a given vault, stored under
+// the lazyruin config directory keyed by a hash of the vault path.
+func DirForVault(vaultPath string) string {
+	return filepath.Join(configpath.Dir(), "trash", configpath.VaultDirName(vaultPath))
+}
+
+func (s *Store) indexPath() string {
+	return filepath.Join(s.dir, "index.json")
+}
+
+func (s *Store) filePath(id string) string {
+	return filepath.Join(s.dir, id+".md")
+}
+
+func (s *Store) Load() error {
+	data, err := os.ReadFile(s.indexPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			s.items = nil
+			return nil
+		}
+		return err
+	}
+	return json.Unmarshal(data, &s.items)
+}
+
+func (s *Store) save() error {
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s.items, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.indexPath(), data, 0o644)
+}
+
+// Add stores a copy of a note's file contents and records it in the index.
+func (s *Store) Add(uuid, title, path string, data []byte) (Item, error) {
+	if err := s.Load(); err != nil {
+		return Item{}, err
+	}
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return Item{}, err
+	}
+	item := Item{
+		ID:      randomHex(6),
+		UUID:    uuid,
+		Title:   title,
+		Path:    path,
+		Deleted: time.Now(),
+	}
+	if err := os.WriteFile(s.filePath(item.ID), data, 0o644); err != nil {
+		return Item{}, err
+	}
+	s.items = append(s.items, item)
+	if err := s.save(); err != nil {
+		os.Remove(s.filePath(item.ID))
+		return Item{}, err
+	}
+	return item, nil
+}
+
+// Read returns the trashed file contents for id.
+func (s *Store) Read(id string) ([]byte, error) {
+	return os.ReadFile(s.filePath(id))
+}
+
+// Remove drops id from the trash, deleting its stored copy.
+func (s *Store) Remove(id string) error {
+	for i, item := range s.items {
+		if item.ID == id {
+			s.items = append(s.items[:i], s.items[i+1:]...)
+			if err := os.Remove(s.filePath(id)); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			return s.save()
+		}
+	}
+	return nil
+}
+
+// PurgeOlderThan removes items deleted before now-retention and returns
+// how many were purged.
+func (s *Store) PurgeOlderThan(retention time.Duration, now time.Time) (int, error) {
+	cutoff := now.Add(-retention)
+	var expired []string
+	for _, item := range s.items {
+		if item.Deleted.Before(cutoff) {
+			expired = append(expired, item.ID)
+		}
+	}
+	for _, id := range expired {
+		if err := s.Remove(id); err != nil {
+			return 0, err
+		}
+	}
+	return len(expired), nil
+}
+
+// Items returns the trashed notes, most recently deleted first.
+func (s *Store) Items() []Item {
+	sorted := make([]Item, len(s.items))
+	copy(sorted, s.items)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Deleted.After(sorted[j].Deleted)
+	})
+	return sorted
+}
+
+func (s *Store) Len() int {
+	return len(s.items)
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,89 @@
+package trash
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestAddStoresCopyAndIndex(t *testing.T) {
+	dir := t.TempDir()
+	s := NewStoreWithDir(dir)
+
+	item, err := s.Add("u1", "Note", "notes/a.md", []byte("---\nuuid: u1\n---\nbody\n"))
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	data, err := s.Read(item.ID)
+	if err != nil || string(data) != "---\nuuid: u1\n---\nbody\n" {
+		t.Fatalf("Read = %q, %v; want the original file with frontmatter", data, err)
+	}
+
+	reloaded := NewStoreWithDir(dir)
+	if err := reloaded.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if reloaded.Len() != 1 || reloaded.Items()[0].Path != "notes/a.md" {
+		t.Fatalf("index not persisted: %+v", reloaded.Items())
+	}
+}
+
+func TestRemoveDeletesCopy(t *testing.T) {
+	s := NewStoreWithDir(t.TempDir())
+	item, err := s.Add("u1", "Note", "a.md", []byte("x"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Remove(item.ID); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if s.Len() != 0 {
+		t.Errorf("expected empty trash, got %d items", s.Len())
+	}
+	if _, err := s.Read(item.ID); !os.IsNotExist(err) {
+		t.Errorf("stored copy should be gone, Read err = %v", err)
+	}
+}
+
+func TestItemsNewestFirst(t *testing.T) {
+	s := NewStoreWithDir(t.TempDir())
+	s.items = []Item{
+		{ID: "old", Deleted: time.Now().Add(-time.Hour)},
+		{ID: "new", Deleted: time.Now()},
+	}
+	if got := s.Items()[0].ID; got != "new" {
+		t.Errorf("first item = %q, want new", got)
+	}
+}
+
+func TestPurgeOlderThan(t *testing.T) {
+	s := NewStoreWithDir(t.TempDir())
+	old, _ := s.Add("u1", "Old", "old.md", []byte("x"))
+	fresh, _ := s.Add("u2", "Fresh", "fresh.md", []byte("y"))
+	s.items[0].Deleted = time.Now().AddDate(0, 0, -40)
+
+	n, err := s.PurgeOlderThan(30*24*time.Hour, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Errorf("purged %d, want 1", n)
+	}
+	if _, err := s.Read(old.ID); !os.IsNotExist(err) {
+		t.Error("expired copy should be removed")
+	}
+	if s.Len() != 1 || s.Items()[0].ID != fresh.ID {
+		t.Errorf("remaining = %+v, want only the fresh item", s.Items())
+	}
+}
+
+func TestLoadMissingIndexIsEmpty(t *testing.T) {
+	s := NewStoreWithDir(t.TempDir())
+	if err := s.Load(); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if s.Len() != 0 {
+		t.Errorf("expected empty trash, got %d", s.Len())
+	}
+}
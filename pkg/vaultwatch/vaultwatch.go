@@ -0,0 +1,175 @@
+// Package vaultwatch reports changes to note files in a vault directory.
+// It wraps fsnotify (inotify on Linux, kqueue on macOS) with recursive
+// directory watching and a debounce, so an editor's write/rename/chmod
+// burst on save arrives as one batch.
+package vaultwatch
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// DefaultDebounce is the quiet period a burst must settle for before its
+// batch is delivered.
+const DefaultDebounce = 300 * time.Millisecond
+
+// Batch is one debounced set of changed note files.
+type Batch struct {
+	// Changed holds files that exist after the burst (created or written).
+	Changed []string
+	// Removed holds files that were deleted or renamed away.
+	Removed []string
+}
+
+// Watcher watches every non-hidden directory under a vault root. Hidden
+// directories are skipped, which keeps ruin's own `.ruin/` index writes
+// (triggered by the doctor runs a batch causes) from feeding back into
+// the watcher.
+type Watcher struct {
+	root     string
+	debounce time.Duration
+	fsw      *fsnotify.Watcher
+}
+
+// New starts watching root and every non-hidden directory beneath it.
+func New(root string, debounce time.Duration) (*Watcher, error) {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	w := &Watcher{root: filepath.Clean(root), debounce: debounce, fsw: fsw}
+	if err := w.addTree(w.root); err != nil {
+		fsw.Close()
+		return nil, err
+	}
+	return w, nil
+}
+
+// Close stops the underlying fsnotify watcher, which also ends Run.
+func (w *Watcher) Close() error {
+	return w.fsw.Close()
+}
+
+// Run delivers debounced batches to onBatch until stop is closed or the
+// watcher is closed. onBatch is called on Run's goroutine.
+func (w *Watcher) Run(stop <-chan struct{}, onBatch func(Batch)) {
+	pending := make(map[string]bool) // path -> removed
+	timer := time.NewTimer(w.debounce)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case ev, ok := <-w.fsw.Events:
+			if !ok {
+				return
+			}
+			if w.handle(ev, pending) {
+				timer.Reset(w.debounce)
+			}
+		case _, ok := <-w.fsw.Errors:
+			if !ok {
+				return
+			}
+		case <-timer.C:
+			if len(pending) == 0 {
+				continue
+			}
+			onBatch(newBatch(pending))
+			pending = make(map[string]bool)
+		}
+	}
+}
+
+// handle records ev in pending, reporting whether it concerned a note
+// file. New directories are added to the watch set so notes created in
+// them are seen too.
+func (w *Watcher) handle(ev fsnotify.Event, pending map[string]bool) bool {
+	if hiddenUnder(w.root, ev.Name) {
+		return false
+	}
+	if ev.Has(fsnotify.Create) {
+		if info, err := os.Stat(ev.Name); err == nil && info.IsDir() {
+			_ = w.addTree(ev.Name)
+			return false
+		}
+	}
+	if !IsNoteFile(ev.Name) {
+		return false
+	}
+	switch {
+	case ev.Has(fsnotify.Remove), ev.Has(fsnotify.Rename):
+		pending[ev.Name] = true
+	case ev.Has(fsnotify.Create), ev.Has(fsnotify.Write):
+		pending[ev.Name] = false
+	default:
+		return false
+	}
+	return true
+}
+
+// addTree watches dir and its non-hidden subdirectories.
+func (w *Watcher) addTree(dir string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path != w.root && strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+		return w.fsw.Add(path)
+	})
+}
+
+// IsNoteFile reports whether path looks like a ruin note.
+func IsNoteFile(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".md")
+}
+
+// hiddenUnder reports whether path, relative to root, passes through a
+// dot-prefixed file or directory.
+func hiddenUnder(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+	for _, part := range strings.Split(rel, string(filepath.Separator)) {
+		if strings.HasPrefix(part, ".") && part != "." && part != ".." {
+			return true
+		}
+	}
+	return false
+}
+
+// newBatch splits pending into sorted Changed/Removed lists. A path that
+// was removed and then recreated within the burst (atomic save via
+// rename) is re-checked on disk so it lands in Changed.
+func newBatch(pending map[string]bool) Batch {
+	var b Batch
+	for path, removed := range pending {
+		if removed {
+			if _, err := os.Stat(path); err == nil {
+				removed = false
+			}
+		}
+		if removed {
+			b.Removed = append(b.Removed, path)
+		} else {
+			b.Changed = append(b.Changed, path)
+		}
+	}
+	sort.Strings(b.Changed)
+	sort.Strings(b.Removed)
+	return b
+}
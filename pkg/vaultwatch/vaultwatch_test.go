@@ -0,0 +1,144 @@
+package vaultwatch
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const testDebounce = 50 * time.Millisecond
+
+// startWatcher runs a watcher on dir and returns a channel of its batches.
+func startWatcher(t *testing.T, dir string) <-chan Batch {
+	t.Helper()
+	w, err := New(dir, testDebounce)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	stop := make(chan struct{})
+	batches := make(chan Batch, 8)
+	go w.Run(stop, func(b Batch) { batches <- b })
+	t.Cleanup(func() {
+		close(stop)
+		w.Close()
+	})
+	return batches
+}
+
+func nextBatch(t *testing.T, batches <-chan Batch) Batch {
+	t.Helper()
+	select {
+	case b := <-batches:
+		return b
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for batch")
+		return Batch{}
+	}
+}
+
+func expectNoBatch(t *testing.T, batches <-chan Batch) {
+	t.Helper()
+	select {
+	case b := <-batches:
+		t.Fatalf("unexpected batch: %+v", b)
+	case <-time.After(4 * testDebounce):
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+func TestWatcher_DebouncesBurstIntoOneBatch(t *testing.T) {
+	dir := t.TempDir()
+	batches := startWatcher(t, dir)
+
+	note := filepath.Join(dir, "note.md")
+	writeFile(t, note, "one")
+	writeFile(t, note, "two")
+	writeFile(t, filepath.Join(dir, "other.md"), "x")
+
+	b := nextBatch(t, batches)
+	if len(b.Changed) != 2 || b.Changed[0] != note {
+		t.Errorf("Changed = %v, want [note.md other.md]", b.Changed)
+	}
+	if len(b.Removed) != 0 {
+		t.Errorf("Removed = %v, want none", b.Removed)
+	}
+	expectNoBatch(t, batches)
+}
+
+func TestWatcher_IgnoresHiddenDirsAndNonNotes(t *testing.T) {
+	dir := t.TempDir()
+	hidden := filepath.Join(dir, ".ruin")
+	if err := os.Mkdir(hidden, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	batches := startWatcher(t, dir)
+
+	writeFile(t, filepath.Join(hidden, "index.md"), "x")
+	writeFile(t, filepath.Join(dir, "image.png"), "x")
+	writeFile(t, filepath.Join(dir, ".note.md.swp"), "x")
+
+	expectNoBatch(t, batches)
+}
+
+func TestWatcher_ReportsRemovedNotes(t *testing.T) {
+	dir := t.TempDir()
+	note := filepath.Join(dir, "gone.md")
+	writeFile(t, note, "x")
+	batches := startWatcher(t, dir)
+
+	if err := os.Remove(note); err != nil {
+		t.Fatal(err)
+	}
+
+	b := nextBatch(t, batches)
+	if len(b.Removed) != 1 || b.Removed[0] != note {
+		t.Errorf("Removed = %v, want [%s]", b.Removed, note)
+	}
+}
+
+func TestWatcher_AtomicSaveIsAChange(t *testing.T) {
+	dir := t.TempDir()
+	note := filepath.Join(dir, "note.md")
+	writeFile(t, note, "old")
+	batches := startWatcher(t, dir)
+
+	tmp := filepath.Join(dir, "note.md.tmp")
+	writeFile(t, tmp, "new")
+	if err := os.Rename(tmp, note); err != nil {
+		t.Fatal(err)
+	}
+
+	b := nextBatch(t, batches)
+	if len(b.Changed) != 1 || b.Changed[0] != note {
+		t.Errorf("Changed = %v, want [%s]", b.Changed, note)
+	}
+	if len(b.Removed) != 0 {
+		t.Errorf("Removed = %v, want none", b.Removed)
+	}
+}
+
+func TestWatcher_WatchesNewSubdirectories(t *testing.T) {
+	dir := t.TempDir()
+	batches := startWatcher(t, dir)
+
+	sub := filepath.Join(dir, "projects")
+	if err := os.Mkdir(sub, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	// Give the watcher a moment to add the new directory.
+	time.Sleep(testDebounce)
+	note := filepath.Join(sub, "plan.md")
+	writeFile(t, note, "x")
+
+	b := nextBatch(t, batches)
+	if len(b.Changed) != 1 || b.Changed[0] != note {
+		t.Errorf("Changed = %v, want [%s]", b.Changed, note)
+	}
+}
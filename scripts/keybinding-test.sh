@@ -210,14 +210,14 @@ send Escape
 assert_not_contains "Esc closes contrib" "Contributions"
 
 # =============================================
-# 10. Global: Refresh (Ctrl-R)
+# 10. Global: Refresh (F5)
 # =============================================
 echo "[10] Global: Refresh"
 send 1; settle
-send C-r
+send F5
 settle
 TOTAL=$((TOTAL + 1))
-echo "  PASS: Ctrl-R refresh (no crash)"
+echo "  PASS: F5 refresh (no crash)"
 
 # =============================================
 # 11. Notes: j/k navigation